@@ -71,6 +71,20 @@ type EndpointStatus struct {
 	// resource
 	// +kubebuilder:validation:Optional
 	Conditions []*ackv1alpha1.Condition `json:"conditions"`
+	// The Region which receives the events of the endpoint, according to the
+	// status of the health check of the primary Region
+	// +kubebuilder:validation:Optional
+	ActiveRegion *string `json:"activeRegion,omitempty"`
+	// The time the last failover drill ended, unset while a drill is in
+	// progress
+	// +kubebuilder:validation:Optional
+	FailoverDrillEndTime *metav1.Time `json:"failoverDrillEndTime,omitempty"`
+	// The time the last failover drill started
+	// +kubebuilder:validation:Optional
+	FailoverDrillStartTime *metav1.Time `json:"failoverDrillStartTime,omitempty"`
+	// Whether the Route 53 health check of the primary Region is healthy
+	// +kubebuilder:validation:Optional
+	PrimaryHealthy *bool `json:"primaryHealthy,omitempty"`
 	// The state of the endpoint that was created by this request.
 	// +kubebuilder:validation:Optional
 	State *string `json:"state,omitempty"`
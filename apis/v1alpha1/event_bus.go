@@ -36,6 +36,11 @@ type EventBusSpec struct {
 	//
 	// Regex Pattern: `^aws\.partner(/[\.\-_A-Za-z0-9]+){2,}$`
 	EventSourceName *string `json:"eventSourceName,omitempty"`
+	// The logging configuration settings for the event bus.
+	//
+	// For more information, see Configuring logs for event buses (https://docs.aws.amazon.com/eb-event-bus-logs.html)
+	// in the EventBridge User Guide.
+	LogConfig *LogConfig `json:"logConfig,omitempty"`
 	// The name of the new event bus.
 	//
 	// Custom event bus names can't contain the / character, but you can use the
@@ -52,6 +52,9 @@ type RuleSpec struct {
 	// in the Target structure, instead of here in this parameter.
 	RoleARN *string                                  `json:"roleARN,omitempty"`
 	RoleRef *ackv1alpha1.AWSResourceReferenceWrapper `json:"roleRef,omitempty"`
+	// A sample event, as JSON, for which the input of every target is rendered
+	// into Status.InputPreviews. Not sent to EventBridge.
+	SampleEvent *string `json:"sampleEvent,omitempty"`
 	// The scheduling expression. For example, "cron(0 20 * * ? *)" or "rate(5 minutes)".
 	ScheduleExpression *string `json:"scheduleExpression,omitempty"`
 	// The state of the rule.
@@ -96,6 +99,24 @@ type RuleStatus struct {
 	// resource
 	// +kubebuilder:validation:Optional
 	Conditions []*ackv1alpha1.Condition `json:"conditions"`
+	// The differences between the spec and the rule in AWS which aren't
+	// reconciled in drift report mode, one entry per differing field,
+	// formatted as "<path>: desired=<value>, actual=<value>". The entries are
+	// cleared by every read of the rule and reported again as long as the
+	// drift persists.
+	// +kubebuilder:validation:Optional
+	Drift []*string `json:"drift,omitempty"`
+	// The input EventBridge sends to each target, by target ID, for
+	// Spec.SampleEvent
+	// +kubebuilder:validation:Optional
+	InputPreviews map[string]*string `json:"inputPreviews,omitempty"`
+	// The generation of the Rule which was last created or updated
+	// +kubebuilder:validation:Optional
+	ObservedGeneration *int64 `json:"observedGeneration,omitempty"`
+	// The default role of the Rule namespace, set on the targets which send
+	// events to an event bus in another account or Region and omit their role
+	// +kubebuilder:validation:Optional
+	TargetRoleARN *string `json:"targetRoleARN,omitempty"`
 }
 
 // Rule is the Schema for the Rules API
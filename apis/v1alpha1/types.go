@@ -254,6 +254,18 @@ type KinesisParameters struct {
 	PartitionKeyPath *string `json:"partitionKeyPath,omitempty"`
 }
 
+// The logging configuration settings for the event bus.
+//
+// For more information, see Configuring logs for event buses (https://docs.aws.amazon.com/eb-event-bus-logs.html)
+// in the EventBridge User Guide.
+type LogConfig struct {
+	DeliveryStreamRefs []*ackv1alpha1.AWSResourceReferenceWrapper `json:"deliveryStreamRefs,omitempty"`
+	DestinationARNs    []*string                                  `json:"destinationARNs,omitempty"`
+	IncludeDetail      *string                                    `json:"includeDetail,omitempty"`
+	Level              *string                                    `json:"level,omitempty"`
+	LogGroupRefs       []*ackv1alpha1.AWSResourceReferenceWrapper `json:"logGroupRefs,omitempty"`
+}
+
 // This structure specifies the network configuration for an ECS task.
 type NetworkConfiguration struct {
 	// This structure specifies the VPC subnets and security groups for the task,
@@ -295,7 +307,8 @@ type PlacementStrategy struct {
 
 // The primary Region of the endpoint.
 type Primary struct {
-	HealthCheck *string `json:"healthCheck,omitempty"`
+	HealthCheck    *string                                  `json:"healthCheck,omitempty"`
+	HealthCheckRef *ackv1alpha1.AWSResourceReferenceWrapper `json:"healthCheckRef,omitempty"`
 }
 
 // Represents an event to be submitted.
@@ -802,6 +802,24 @@ func (in *EndpointStatus) DeepCopyInto(out *EndpointStatus) {
 			}
 		}
 	}
+	if in.ActiveRegion != nil {
+		in, out := &in.ActiveRegion, &out.ActiveRegion
+		*out = new(string)
+		**out = **in
+	}
+	if in.FailoverDrillEndTime != nil {
+		in, out := &in.FailoverDrillEndTime, &out.FailoverDrillEndTime
+		*out = (*in).DeepCopy()
+	}
+	if in.FailoverDrillStartTime != nil {
+		in, out := &in.FailoverDrillStartTime, &out.FailoverDrillStartTime
+		*out = (*in).DeepCopy()
+	}
+	if in.PrimaryHealthy != nil {
+		in, out := &in.PrimaryHealthy, &out.PrimaryHealthy
+		*out = new(bool)
+		**out = **in
+	}
 	if in.State != nil {
 		in, out := &in.State, &out.State
 		*out = new(string)
@@ -975,6 +993,11 @@ func (in *EventBusSpec) DeepCopyInto(out *EventBusSpec) {
 		*out = new(string)
 		**out = **in
 	}
+	if in.LogConfig != nil {
+		in, out := &in.LogConfig, &out.LogConfig
+		*out = new(LogConfig)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.Name != nil {
 		in, out := &in.Name, &out.Name
 		*out = new(string)
@@ -1254,6 +1277,64 @@ func (in *KinesisParameters) DeepCopy() *KinesisParameters {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *LogConfig) DeepCopyInto(out *LogConfig) {
+	*out = *in
+	if in.DeliveryStreamRefs != nil {
+		in, out := &in.DeliveryStreamRefs, &out.DeliveryStreamRefs
+		*out = make([]*corev1alpha1.AWSResourceReferenceWrapper, len(*in))
+		for i := range *in {
+			if (*in)[i] != nil {
+				in, out := &(*in)[i], &(*out)[i]
+				*out = new(corev1alpha1.AWSResourceReferenceWrapper)
+				(*in).DeepCopyInto(*out)
+			}
+		}
+	}
+	if in.DestinationARNs != nil {
+		in, out := &in.DestinationARNs, &out.DestinationARNs
+		*out = make([]*string, len(*in))
+		for i := range *in {
+			if (*in)[i] != nil {
+				in, out := &(*in)[i], &(*out)[i]
+				*out = new(string)
+				**out = **in
+			}
+		}
+	}
+	if in.IncludeDetail != nil {
+		in, out := &in.IncludeDetail, &out.IncludeDetail
+		*out = new(string)
+		**out = **in
+	}
+	if in.Level != nil {
+		in, out := &in.Level, &out.Level
+		*out = new(string)
+		**out = **in
+	}
+	if in.LogGroupRefs != nil {
+		in, out := &in.LogGroupRefs, &out.LogGroupRefs
+		*out = make([]*corev1alpha1.AWSResourceReferenceWrapper, len(*in))
+		for i := range *in {
+			if (*in)[i] != nil {
+				in, out := &(*in)[i], &(*out)[i]
+				*out = new(corev1alpha1.AWSResourceReferenceWrapper)
+				(*in).DeepCopyInto(*out)
+			}
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LogConfig.
+func (in *LogConfig) DeepCopy() *LogConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(LogConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *NetworkConfiguration) DeepCopyInto(out *NetworkConfiguration) {
 	*out = *in
@@ -1380,6 +1461,11 @@ func (in *Primary) DeepCopyInto(out *Primary) {
 		*out = new(string)
 		**out = **in
 	}
+	if in.HealthCheckRef != nil {
+		in, out := &in.HealthCheckRef, &out.HealthCheckRef
+		*out = new(corev1alpha1.AWSResourceReferenceWrapper)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Primary.
@@ -1770,6 +1856,11 @@ func (in *RuleSpec) DeepCopyInto(out *RuleSpec) {
 		*out = new(corev1alpha1.AWSResourceReferenceWrapper)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.SampleEvent != nil {
+		in, out := &in.SampleEvent, &out.SampleEvent
+		*out = new(string)
+		**out = **in
+	}
 	if in.ScheduleExpression != nil {
 		in, out := &in.ScheduleExpression, &out.ScheduleExpression
 		*out = new(string)
@@ -1833,6 +1924,43 @@ func (in *RuleStatus) DeepCopyInto(out *RuleStatus) {
 			}
 		}
 	}
+	if in.Drift != nil {
+		in, out := &in.Drift, &out.Drift
+		*out = make([]*string, len(*in))
+		for i := range *in {
+			if (*in)[i] != nil {
+				in, out := &(*in)[i], &(*out)[i]
+				*out = new(string)
+				**out = **in
+			}
+		}
+	}
+	if in.InputPreviews != nil {
+		in, out := &in.InputPreviews, &out.InputPreviews
+		*out = make(map[string]*string, len(*in))
+		for key, val := range *in {
+			var outVal *string
+			if val == nil {
+				(*out)[key] = nil
+			} else {
+				inVal := (*in)[key]
+				in, out := &inVal, &outVal
+				*out = new(string)
+				**out = **in
+			}
+			(*out)[key] = outVal
+		}
+	}
+	if in.ObservedGeneration != nil {
+		in, out := &in.ObservedGeneration, &out.ObservedGeneration
+		*out = new(int64)
+		**out = **in
+	}
+	if in.TargetRoleARN != nil {
+		in, out := &in.TargetRoleARN, &out.TargetRoleARN
+		*out = new(string)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RuleStatus.
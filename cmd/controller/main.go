@@ -40,6 +40,7 @@ import (
 	ctrlrtwebhook "sigs.k8s.io/controller-runtime/pkg/webhook"
 
 	svctypes "github.com/aws-controllers-k8s/eventbridge-controller/apis/v1alpha1"
+	"github.com/aws-controllers-k8s/eventbridge-controller/pkg/controller"
 	svcresource "github.com/aws-controllers-k8s/eventbridge-controller/pkg/resource"
 
 	_ "github.com/aws-controllers-k8s/eventbridge-controller/pkg/resource/archive"
@@ -160,6 +161,15 @@ func main() {
 		os.Exit(1)
 	}
 
+	resourceManagerFactories, err := controller.Setup(mgr)
+	if err != nil {
+		setupLog.Error(
+			err, "unable to set up resource managers",
+			"aws.service", awsServiceAlias,
+		)
+		os.Exit(1)
+	}
+
 	stopChan := ctrlrt.SetupSignalHandler()
 
 	setupLog.Info(
@@ -187,7 +197,7 @@ func main() {
 	).WithLogger(
 		ctrlrt.Log,
 	).WithResourceManagerFactories(
-		svcresource.GetManagerFactories(),
+		resourceManagerFactories,
 	).WithPrometheusRegistry(
 		ctrlrtmetrics.Registry,
 	)
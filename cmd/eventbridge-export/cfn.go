@@ -0,0 +1,92 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package main
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+
+	flag "github.com/spf13/pflag"
+
+	"github.com/aws-controllers-k8s/eventbridge-controller/pkg/cfn"
+)
+
+type cfnOptions struct {
+	template string
+	convert  cfn.Options
+	strict   bool
+	output   string
+}
+
+// runCFN converts the CloudFormation template and writes the manifests to
+// stdout or the output file, and the conversion issues to stderr
+func runCFN(args []string, stdout, stderr io.Writer) error {
+	opts, err := parseCFNFlags(args)
+	if err != nil {
+		return err
+	}
+
+	data, err := os.ReadFile(opts.template)
+	if err != nil {
+		return err
+	}
+	tmpl, err := cfn.Parse(data)
+	if err != nil {
+		return err
+	}
+	inv, issues := cfn.Convert(tmpl, opts.convert)
+	for _, issue := range issues {
+		fmt.Fprintf(stderr, "warning: %s\n", issue)
+	}
+	if opts.strict && len(issues) > 0 {
+		return fmt.Errorf("%d properties can't be converted", len(issues))
+	}
+	normalize(inv)
+	return writeManifests(opts.output, stdout, inv)
+}
+
+func parseCFNFlags(args []string) (cfnOptions, error) {
+	var opts cfnOptions
+	fs := flag.NewFlagSet(cmdName+" cfn", flag.ContinueOnError)
+	fs.StringVarP(&opts.template, "template", "f", "",
+		"CloudFormation template file in JSON or YAML format")
+	fs.StringVar(&opts.convert.Namespace, "namespace", "",
+		"namespace of the custom resources")
+	fs.StringVar(&opts.convert.AccountID, "account-id", "",
+		"AWS account ID of the stack, resolves AWS::AccountId and ARNs")
+	fs.StringVar(&opts.convert.Region, "region", "",
+		"AWS region of the stack, resolves AWS::Region and ARNs")
+	fs.StringVar(&opts.convert.Partition, "partition", "aws",
+		"AWS partition of the stack")
+	fs.StringToStringVar(&opts.convert.Parameters, "parameter", nil,
+		"template parameter value key=value, may be repeated")
+	fs.BoolVar(&opts.convert.Adopt, "adopt", false,
+		"annotate the custom resources to adopt the resources deployed by the stack")
+	fs.BoolVar(&opts.strict, "strict", false,
+		"fail if any property can't be converted")
+	fs.StringVarP(&opts.output, "output", "o", "-",
+		"output file, - for stdout")
+	if err := fs.Parse(args); err != nil {
+		return opts, err
+	}
+	if fs.NArg() > 0 {
+		return opts, fmt.Errorf("unexpected arguments: %v", fs.Args())
+	}
+	if opts.template == "" {
+		return opts, errors.New("no template set, set --template")
+	}
+	return opts, nil
+}
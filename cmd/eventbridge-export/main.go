@@ -0,0 +1,162 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+// Command eventbridge-export writes the live EventBridge configuration of an
+// account and region, i.e. the event buses, rules, archives and endpoints, as
+// custom resource manifests for the controller. Applying the manifests adopts
+// the resources.
+//
+// The cfn subcommand converts the EventBridge resources of a CloudFormation
+// template instead, without calling AWS. Properties which can't be converted
+// are reported on stderr.
+//
+// Usage:
+//
+//	eventbridge-export --region us-west-2 [--profile name] [--endpoint-url url]
+//	    [--namespace ns] [--bus-prefix prefix] [--rule-tag key=value]... [-o file]
+//	eventbridge-export cfn -f template.yaml [--namespace ns] [--account-id id]
+//	    [--region region] [--parameter key=value]... [--adopt] [--strict] [-o file]
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/config"
+	flag "github.com/spf13/pflag"
+
+	"github.com/aws-controllers-k8s/eventbridge-controller/pkg/discovery"
+)
+
+const cmdName = "eventbridge-export"
+
+type options struct {
+	profile     string
+	endpointURL string
+	region      string
+	accountID   string
+	namespace   string
+	busPrefix   string
+	ruleTags    map[string]string
+	output      string
+}
+
+func main() {
+	if err := run(context.Background(), os.Args[1:], os.Stdout, os.Stderr); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			os.Exit(0)
+		}
+		fmt.Fprintf(os.Stderr, "%s: %v\n", cmdName, err)
+		os.Exit(1)
+	}
+}
+
+// run parses args, discovers the resources and writes the manifests to
+// stdout or the output file
+func run(ctx context.Context, args []string, stdout, stderr io.Writer) error {
+	if len(args) > 0 && args[0] == "cfn" {
+		return runCFN(args[1:], stdout, stderr)
+	}
+
+	opts, err := parseFlags(args)
+	if err != nil {
+		return err
+	}
+
+	cfg, err := loadAWSConfig(ctx, opts)
+	if err != nil {
+		return err
+	}
+
+	inv, err := discovery.Discover(ctx, cfg, discovery.Options{
+		Namespace:      opts.namespace,
+		AccountID:      opts.accountID,
+		EventBusPrefix: opts.busPrefix,
+		RuleTags:       opts.ruleTags,
+	})
+	if err != nil {
+		return err
+	}
+	normalize(inv)
+	return writeManifests(opts.output, stdout, inv)
+}
+
+// writeManifests writes the manifests of the inventory to stdout or the
+// output file
+func writeManifests(output string, stdout io.Writer, inv *discovery.Inventory) error {
+	w := stdout
+	if output != "-" {
+		f, err := os.Create(output)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		w = f
+	}
+	return discovery.WriteManifests(w, inv.Objects())
+}
+
+func parseFlags(args []string) (options, error) {
+	var opts options
+	fs := flag.NewFlagSet(cmdName, flag.ContinueOnError)
+	fs.StringVar(&opts.profile, "profile", "",
+		"AWS shared config profile, the default credential chain is used if not set")
+	fs.StringVar(&opts.endpointURL, "endpoint-url", "",
+		"EventBridge endpoint URL, e.g. of a local stand-in of the API")
+	fs.StringVar(&opts.region, "region", "",
+		"AWS region, defaults to the region of the profile or environment")
+	fs.StringVar(&opts.accountID, "account-id", "",
+		"AWS account ID of the exported resources")
+	fs.StringVar(&opts.namespace, "namespace", "",
+		"namespace of the exported custom resources")
+	fs.StringVar(&opts.busPrefix, "bus-prefix", "",
+		"only export event buses with the name prefix, and their rules and archives")
+	fs.StringToStringVar(&opts.ruleTags, "rule-tag", nil,
+		"only export rules with the tag key=value, may be repeated")
+	fs.StringVarP(&opts.output, "output", "o", "-",
+		"output file, - for stdout")
+	if err := fs.Parse(args); err != nil {
+		return opts, err
+	}
+	if fs.NArg() > 0 {
+		return opts, fmt.Errorf("unexpected arguments: %v", fs.Args())
+	}
+	return opts, nil
+}
+
+// loadAWSConfig loads the AWS configuration of the profile, or the default
+// credential chain, and points it to the endpoint URL if one is set
+func loadAWSConfig(ctx context.Context, opts options) (aws.Config, error) {
+	var loadOpts []func(*config.LoadOptions) error
+	if opts.profile != "" {
+		loadOpts = append(loadOpts, config.WithSharedConfigProfile(opts.profile))
+	}
+	if opts.region != "" {
+		loadOpts = append(loadOpts, config.WithRegion(opts.region))
+	}
+	cfg, err := config.LoadDefaultConfig(ctx, loadOpts...)
+	if err != nil {
+		return cfg, fmt.Errorf("loading AWS configuration: %w", err)
+	}
+	if cfg.Region == "" {
+		return cfg, errors.New("no AWS region configured, set --region")
+	}
+	if opts.endpointURL != "" {
+		cfg.BaseEndpoint = aws.String(opts.endpointURL)
+	}
+	return cfg, nil
+}
@@ -0,0 +1,206 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package main
+
+import (
+	"bytes"
+	"context"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	svcsdk "github.com/aws/aws-sdk-go-v2/service/eventbridge"
+	svcsdktypes "github.com/aws/aws-sdk-go-v2/service/eventbridge/types"
+	"gotest.tools/v3/assert"
+
+	"github.com/aws-controllers-k8s/eventbridge-controller/pkg/testutil"
+)
+
+// newStandIn serves a fake EventBridge API with an "orders" and a "billing"
+// event bus, each with a tagged rule
+func newStandIn(t *testing.T) string {
+	t.Helper()
+	ctx := context.Background()
+	fake := testutil.NewEventBridge()
+	client := svcsdk.NewFromConfig(fake.Config())
+
+	for _, bus := range []string{"orders", "billing"} {
+		_, err := client.CreateEventBus(ctx, &svcsdk.CreateEventBusInput{
+			Name: aws.String(bus),
+			Tags: []svcsdktypes.Tag{
+				{Key: aws.String("team"), Value: aws.String(bus)},
+				{Key: aws.String("services.k8s.aws/namespace"), Value: aws.String("default")},
+			},
+		})
+		assert.NilError(t, err)
+		_, err = client.PutRule(ctx, &svcsdk.PutRuleInput{
+			Name:         aws.String(bus + "-created"),
+			EventBusName: aws.String(bus),
+			EventPattern: aws.String(`{"source":["` + bus + `"],"detail-type":["Created"]}`),
+			Tags:         []svcsdktypes.Tag{{Key: aws.String("team"), Value: aws.String(bus)}},
+		})
+		assert.NilError(t, err)
+	}
+
+	server := httptest.NewServer(fake)
+	t.Cleanup(server.Close)
+
+	// isolate the test from the AWS configuration of the host
+	t.Setenv("AWS_CONFIG_FILE", filepath.Join(t.TempDir(), "config"))
+	t.Setenv("AWS_SHARED_CREDENTIALS_FILE", filepath.Join(t.TempDir(), "credentials"))
+	t.Setenv("AWS_ACCESS_KEY_ID", "AKIDTEST")
+	t.Setenv("AWS_SECRET_ACCESS_KEY", "secret")
+	return server.URL
+}
+
+func Test_run(t *testing.T) {
+	url := newStandIn(t)
+	args := []string{"--endpoint-url", url, "--region", testutil.DefaultRegion, "--namespace", "migration"}
+
+	tests := []struct {
+		name      string
+		args      []string
+		wantKinds []string
+		want      []string
+		notWant   []string
+	}{
+		{
+			name:      "all resources",
+			args:      args,
+			wantKinds: []string{"EventBus", "EventBus", "Rule", "Rule"},
+			want: []string{
+				"namespace: migration",
+				"eventPattern: |-\n    {\n      \"source\": [\n        \"orders\"\n      ],",
+			},
+			notWant: []string{"services.k8s.aws/namespace"},
+		},
+		{
+			name:      "bus prefix",
+			args:      append(args, "--bus-prefix", "ord"),
+			wantKinds: []string{"EventBus", "Rule"},
+			notWant:   []string{"billing"},
+		},
+		{
+			name:      "rule tag",
+			args:      append(args, "--rule-tag", "team=billing"),
+			wantKinds: []string{"EventBus", "EventBus", "Rule"},
+			want:      []string{"name: billing-created"},
+			notWant:   []string{"name: orders-created"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var out bytes.Buffer
+			assert.NilError(t, run(context.Background(), tt.args, &out, &bytes.Buffer{}))
+
+			var kinds []string
+			for _, doc := range strings.Split(out.String(), "---\n") {
+				for _, line := range strings.Split(doc, "\n") {
+					if kind, ok := strings.CutPrefix(line, "kind: "); ok {
+						kinds = append(kinds, kind)
+					}
+				}
+			}
+			assert.DeepEqual(t, kinds, tt.wantKinds)
+			for _, s := range tt.want {
+				assert.Assert(t, strings.Contains(out.String(), s), "missing %q in\n%s", s, out.String())
+			}
+			for _, s := range tt.notWant {
+				assert.Assert(t, !strings.Contains(out.String(), s), "unexpected %q in\n%s", s, out.String())
+			}
+
+			// the output is deterministic
+			var again bytes.Buffer
+			assert.NilError(t, run(context.Background(), tt.args, &again, &bytes.Buffer{}))
+			assert.Equal(t, out.String(), again.String())
+		})
+	}
+}
+
+func Test_run_noRegion(t *testing.T) {
+	newStandIn(t)
+	t.Setenv("AWS_REGION", "")
+	t.Setenv("AWS_DEFAULT_REGION", "")
+	err := run(context.Background(), []string{"--endpoint-url", "http://localhost"}, &bytes.Buffer{}, &bytes.Buffer{})
+	assert.ErrorContains(t, err, "no AWS region configured")
+}
+
+func Test_run_cfn(t *testing.T) {
+	template := filepath.Join(t.TempDir(), "template.yaml")
+	assert.NilError(t, os.WriteFile(template, []byte(`
+Resources:
+  OrdersBus:
+    Type: AWS::Events::EventBus
+    Properties:
+      Name: orders
+      Description: orders
+  OrderCreated:
+    Type: AWS::Events::Rule
+    Properties:
+      Name: order-created
+      EventBusName: !Ref OrdersBus
+      EventPattern:
+        source: [orders]
+`), 0o600))
+
+	tests := []struct {
+		name       string
+		args       []string
+		wantErr    string
+		want       []string
+		wantStderr string
+	}{
+		{
+			name: "convert",
+			args: []string{"cfn", "-f", template, "--namespace", "orders", "--adopt"},
+			want: []string{
+				"kind: EventBus",
+				"kind: Rule",
+				"namespace: orders",
+				"services.k8s.aws/adoption-policy: adopt-or-create",
+				"eventBusRef:\n    from:\n      name: orders-bus",
+				"eventPattern: |-\n    {\n      \"source\": [\n        \"orders\"\n      ]\n    }",
+			},
+			wantStderr: "warning: OrdersBus: Description: property is not supported by the custom resource\n",
+		},
+		{
+			name:    "strict",
+			args:    []string{"cfn", "-f", template, "--strict"},
+			wantErr: "1 properties can't be converted",
+		},
+		{
+			name:    "no template",
+			args:    []string{"cfn"},
+			wantErr: "no template set",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var out, stderr bytes.Buffer
+			err := run(context.Background(), tt.args, &out, &stderr)
+			if tt.wantErr != "" {
+				assert.ErrorContains(t, err, tt.wantErr)
+				return
+			}
+			assert.NilError(t, err)
+			for _, s := range tt.want {
+				assert.Assert(t, strings.Contains(out.String(), s), "missing %q in\n%s", s, out.String())
+			}
+			assert.Equal(t, stderr.String(), tt.wantStderr)
+		})
+	}
+}
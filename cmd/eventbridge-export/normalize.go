@@ -0,0 +1,76 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"sort"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+
+	svcapitypes "github.com/aws-controllers-k8s/eventbridge-controller/apis/v1alpha1"
+	"github.com/aws-controllers-k8s/eventbridge-controller/pkg/discovery"
+)
+
+// systemTagPrefix is the prefix of the tags the controller adds to every
+// resource it manages
+const systemTagPrefix = "services.k8s.aws/"
+
+// normalize makes the exported manifests deterministic and readable: tags and
+// targets are sorted, system tags are removed and event patterns are indented
+func normalize(inv *discovery.Inventory) {
+	for _, bus := range inv.EventBuses {
+		bus.Spec.Tags = normalizeTags(bus.Spec.Tags)
+	}
+	for _, rule := range inv.Rules {
+		rule.Spec.Tags = normalizeTags(rule.Spec.Tags)
+		rule.Spec.EventPattern = indentPattern(rule.Spec.EventPattern)
+		sort.Slice(rule.Spec.Targets, func(i, j int) bool {
+			return aws.ToString(rule.Spec.Targets[i].ID) < aws.ToString(rule.Spec.Targets[j].ID)
+		})
+	}
+	for _, archive := range inv.Archives {
+		archive.Spec.EventPattern = indentPattern(archive.Spec.EventPattern)
+	}
+}
+
+// normalizeTags returns tags without system tags, sorted by key
+func normalizeTags(tags []*svcapitypes.Tag) []*svcapitypes.Tag {
+	var res []*svcapitypes.Tag
+	for _, t := range tags {
+		if strings.HasPrefix(aws.ToString(t.Key), systemTagPrefix) {
+			continue
+		}
+		res = append(res, t)
+	}
+	sort.Slice(res, func(i, j int) bool {
+		return aws.ToString(res[i].Key) < aws.ToString(res[j].Key)
+	})
+	return res
+}
+
+// indentPattern returns the pretty-printed event pattern. Patterns which are
+// not valid JSON are returned unchanged.
+func indentPattern(pattern *string) *string {
+	if pattern == nil {
+		return nil
+	}
+	var buf bytes.Buffer
+	if err := json.Indent(&buf, []byte(*pattern), "", "  "); err != nil {
+		return pattern
+	}
+	return aws.String(buf.String())
+}
@@ -0,0 +1,93 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+// Command eventbridge-lint checks EventBus, Rule, Archive and Endpoint
+// manifests without AWS access. The findings are written as a JSON array, or
+// as one line per finding with --format text. The command exits with status
+// 1 if there are findings.
+//
+// Usage:
+//
+//	eventbridge-lint [--format json|text] file-or-directory...
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+
+	flag "github.com/spf13/pflag"
+
+	"github.com/aws-controllers-k8s/eventbridge-controller/pkg/lint"
+)
+
+const cmdName = "eventbridge-lint"
+
+// errFindings is returned when the manifests have findings
+var errFindings = errors.New("manifests have findings")
+
+func main() {
+	if err := run(os.Args[1:], os.Stdout); err != nil {
+		switch {
+		case errors.Is(err, flag.ErrHelp):
+			os.Exit(0)
+		case errors.Is(err, errFindings):
+			os.Exit(1)
+		}
+		fmt.Fprintf(os.Stderr, "%s: %v\n", cmdName, err)
+		os.Exit(2)
+	}
+}
+
+// run lints the manifests of the paths in args and writes the findings to
+// stdout
+func run(args []string, stdout io.Writer) error {
+	fs := flag.NewFlagSet(cmdName, flag.ContinueOnError)
+	format := fs.String("format", "json", "output format, json or text")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if *format != "json" && *format != "text" {
+		return fmt.Errorf("unsupported format %q", *format)
+	}
+	if fs.NArg() == 0 {
+		return errors.New("no manifest files or directories given")
+	}
+
+	manifests, findings, err := lint.Load(fs.Args())
+	if err != nil {
+		return err
+	}
+	findings = append(findings, lint.Lint(manifests)...)
+
+	if *format == "json" {
+		if findings == nil {
+			findings = []lint.Finding{}
+		}
+		enc := json.NewEncoder(stdout)
+		enc.SetIndent("", "  ")
+		if err := enc.Encode(findings); err != nil {
+			return err
+		}
+	} else {
+		for _, f := range findings {
+			fmt.Fprintln(stdout, f)
+		}
+	}
+	if len(findings) > 0 {
+		return errFindings
+	}
+	return nil
+}
@@ -0,0 +1,104 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"gotest.tools/v3/assert"
+
+	"github.com/aws-controllers-k8s/eventbridge-controller/pkg/lint"
+)
+
+func Test_run(t *testing.T) {
+	dir := t.TempDir()
+	valid := filepath.Join(dir, "valid.yaml")
+	assert.NilError(t, os.WriteFile(valid, []byte(`apiVersion: eventbridge.services.k8s.aws/v1alpha1
+kind: Rule
+metadata:
+  name: nightly
+spec:
+  name: nightly
+  scheduleExpression: rate(1 day)
+`), 0o600))
+	invalid := filepath.Join(dir, "invalid.yaml")
+	assert.NilError(t, os.WriteFile(invalid, []byte(`apiVersion: eventbridge.services.k8s.aws/v1alpha1
+kind: Rule
+metadata:
+  name: nightly
+spec:
+  name: nightly
+  eventBusName: orders
+  scheduleExpression: rate(1 day)
+`), 0o600))
+
+	tests := []struct {
+		name       string
+		args       []string
+		wantErr    error
+		wantChecks []string
+		wantText   string
+	}{
+		{
+			name:       "no findings",
+			args:       []string{valid},
+			wantChecks: []string{},
+		},
+		{
+			name:       "findings",
+			args:       []string{invalid},
+			wantErr:    errFindings,
+			wantChecks: []string{lint.CheckScheduleBus},
+		},
+		{
+			name:     "text",
+			args:     []string{"--format", "text", invalid},
+			wantErr:  errFindings,
+			wantText: invalid + `[0] Rule nightly spec.scheduleExpression: scheduled rules are only supported on the default event bus, not "orders" (schedule-bus)` + "\n",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var out bytes.Buffer
+			err := run(tt.args, &out)
+			if tt.wantErr != nil {
+				assert.Assert(t, errors.Is(err, tt.wantErr), "got %v", err)
+			} else {
+				assert.NilError(t, err)
+			}
+
+			if tt.wantText != "" {
+				assert.Equal(t, out.String(), tt.wantText)
+				return
+			}
+			var findings []lint.Finding
+			assert.NilError(t, json.Unmarshal(out.Bytes(), &findings))
+			checks := []string{}
+			for _, f := range findings {
+				checks = append(checks, f.Check)
+			}
+			assert.DeepEqual(t, checks, tt.wantChecks)
+		})
+	}
+}
+
+func Test_run_errors(t *testing.T) {
+	assert.ErrorContains(t, run(nil, &bytes.Buffer{}), "no manifest files")
+	assert.ErrorContains(t, run([]string{"--format", "xml", "."}, &bytes.Buffer{}), "unsupported format")
+}
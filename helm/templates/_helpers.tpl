@@ -61,6 +61,13 @@ rules:
   - list
   - patch
   - watch
+- apiGroups:
+  - ""
+  resources:
+  - events
+  verbs:
+  - create
+  - patch
 - apiGroups:
   - ""
   resources:
@@ -69,6 +76,14 @@ rules:
   - get
   - list
   - watch
+- apiGroups:
+  - cloudwatchlogs.services.k8s.aws
+  resources:
+  - loggroups
+  - loggroups/status
+  verbs:
+  - get
+  - list
 - apiGroups:
   - eventbridge.services.k8s.aws
   resources:
@@ -95,6 +110,14 @@ rules:
   - get
   - patch
   - update
+- apiGroups:
+  - firehose.services.k8s.aws
+  resources:
+  - deliverystreams
+  - deliverystreams/status
+  verbs:
+  - get
+  - list
 - apiGroups:
   - iam.services.k8s.aws
   resources:
@@ -103,6 +126,14 @@ rules:
   verbs:
   - get
   - list
+- apiGroups:
+  - route53.services.k8s.aws
+  resources:
+  - healthchecks
+  - healthchecks/status
+  verbs:
+  - get
+  - list
 - apiGroups:
   - services.k8s.aws
   resources:
@@ -0,0 +1,146 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+// Package arns builds and parses the ARNs of EventBridge resources, e.g.
+//
+//	arn:aws:events:us-west-2:111122223333:event-bus/orders
+//	arn:aws:events:us-west-2:111122223333:rule/order-created
+//	arn:aws:events:us-west-2:111122223333:rule/orders/order-created
+//	arn:aws:events:us-west-2:111122223333:archive/orders
+//	arn:aws:events:us-west-2:111122223333:endpoint/orders
+//
+// Rules on the default event bus omit the event bus from their ARN.
+package arns
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/aws/arn"
+)
+
+const (
+	// Service is the service of EventBridge ARNs
+	Service = "events"
+	// DefaultEventBus is the name of the default event bus
+	DefaultEventBus = "default"
+)
+
+// Kind is the type of an EventBridge resource, which prefixes its name in
+// its ARN
+type Kind string
+
+const (
+	KindEventBus Kind = "event-bus"
+	KindRule     Kind = "rule"
+	KindArchive  Kind = "archive"
+	KindEndpoint Kind = "endpoint"
+)
+
+var kinds = []Kind{KindEventBus, KindRule, KindArchive, KindEndpoint}
+
+// ARN is the ARN of an EventBridge resource
+type ARN struct {
+	Partition string
+	Region    string
+	AccountID string
+	Kind      Kind
+	// EventBus is the name of the event bus of a rule, DefaultEventBus for
+	// rules on the default event bus. It is empty for other kinds.
+	EventBus string
+	Name     string
+}
+
+// String returns the ARN
+func (a ARN) String() string {
+	resource := string(a.Kind) + "/" + a.Name
+	if a.Kind == KindRule && a.EventBus != "" && a.EventBus != DefaultEventBus {
+		resource = string(a.Kind) + "/" + a.EventBus + "/" + a.Name
+	}
+	return arn.ARN{
+		Partition: a.Partition,
+		Service:   Service,
+		Region:    a.Region,
+		AccountID: a.AccountID,
+		Resource:  resource,
+	}.String()
+}
+
+// FromName returns the ARN of the resource of the given kind and name. The
+// name of a rule on a custom event bus is prefixed with the event bus, e.g.
+// orders/order-created.
+func FromName(partition, region, accountID string, kind Kind, name string) string {
+	a := ARN{
+		Partition: partition,
+		Region:    region,
+		AccountID: accountID,
+		Kind:      kind,
+		Name:      name,
+	}
+	if kind == KindRule {
+		a.EventBus = DefaultEventBus
+		if i := strings.LastIndex(name, "/"); i >= 0 {
+			a.EventBus, a.Name = name[:i], name[i+1:]
+		}
+	}
+	return a.String()
+}
+
+// Parse parses the ARN of an EventBridge resource
+func Parse(s string) (ARN, error) {
+	parsed, err := arn.Parse(s)
+	if err != nil {
+		return ARN{}, err
+	}
+	if parsed.Service != Service {
+		return ARN{}, fmt.Errorf("arn: %q is not an EventBridge ARN", s)
+	}
+	a := ARN{
+		Partition: parsed.Partition,
+		Region:    parsed.Region,
+		AccountID: parsed.AccountID,
+	}
+	for _, k := range kinds {
+		if name, ok := strings.CutPrefix(parsed.Resource, string(k)+"/"); ok {
+			a.Kind, a.Name = k, name
+			break
+		}
+	}
+	if a.Kind == "" {
+		return ARN{}, fmt.Errorf("arn: unknown EventBridge resource type in %q", s)
+	}
+	if a.Kind == KindRule {
+		// event bus names may contain slashes, e.g. partner event buses, but
+		// rule names may not
+		a.EventBus = DefaultEventBus
+		if i := strings.LastIndex(a.Name, "/"); i >= 0 {
+			a.EventBus, a.Name = a.Name[:i], a.Name[i+1:]
+		}
+	}
+	if a.Name == "" || a.EventBus == "" && a.Kind == KindRule {
+		return ARN{}, fmt.Errorf("arn: missing resource name in %q", s)
+	}
+	return a, nil
+}
+
+// EventBusName returns the name of an event bus given by name or ARN, and
+// DefaultEventBus if it is empty
+func EventBusName(nameOrARN string) string {
+	if a, err := Parse(nameOrARN); err == nil && a.Kind == KindEventBus {
+		return a.Name
+	}
+	if nameOrARN == "" {
+		return DefaultEventBus
+	}
+	return nameOrARN
+}
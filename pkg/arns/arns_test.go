@@ -0,0 +1,177 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package arns
+
+import (
+	"testing"
+
+	"gotest.tools/v3/assert"
+)
+
+func TestFromName(t *testing.T) {
+	tests := []struct {
+		name      string
+		partition string
+		kind      Kind
+		resource  string
+		want      string
+	}{
+		{
+			name: "event bus", partition: "aws", kind: KindEventBus, resource: "orders",
+			want: "arn:aws:events:us-west-2:111122223333:event-bus/orders",
+		},
+		{
+			name: "partner event bus", partition: "aws", kind: KindEventBus, resource: "aws.partner/example.com/123/orders",
+			want: "arn:aws:events:us-west-2:111122223333:event-bus/aws.partner/example.com/123/orders",
+		},
+		{
+			name: "rule on the default event bus", partition: "aws", kind: KindRule, resource: "order-created",
+			want: "arn:aws:events:us-west-2:111122223333:rule/order-created",
+		},
+		{
+			name: "rule on the explicit default event bus", partition: "aws", kind: KindRule, resource: "default/order-created",
+			want: "arn:aws:events:us-west-2:111122223333:rule/order-created",
+		},
+		{
+			name: "rule on a custom event bus", partition: "aws", kind: KindRule, resource: "orders/order-created",
+			want: "arn:aws:events:us-west-2:111122223333:rule/orders/order-created",
+		},
+		{
+			name: "rule on a partner event bus", partition: "aws", kind: KindRule, resource: "aws.partner/example.com/123/orders/order-created",
+			want: "arn:aws:events:us-west-2:111122223333:rule/aws.partner/example.com/123/orders/order-created",
+		},
+		{
+			name: "archive", partition: "aws", kind: KindArchive, resource: "orders",
+			want: "arn:aws:events:us-west-2:111122223333:archive/orders",
+		},
+		{
+			name: "endpoint", partition: "aws", kind: KindEndpoint, resource: "orders",
+			want: "arn:aws:events:us-west-2:111122223333:endpoint/orders",
+		},
+		{
+			name: "other partition", partition: "aws-cn", kind: KindEventBus, resource: "orders",
+			want: "arn:aws-cn:events:us-west-2:111122223333:event-bus/orders",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := FromName(tt.partition, "us-west-2", "111122223333", tt.kind, tt.resource)
+			assert.Equal(t, got, tt.want)
+		})
+	}
+}
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		name    string
+		arn     string
+		want    ARN
+		wantErr string
+	}{
+		{
+			name: "event bus",
+			arn:  "arn:aws:events:us-west-2:111122223333:event-bus/orders",
+			want: ARN{Partition: "aws", Region: "us-west-2", AccountID: "111122223333", Kind: KindEventBus, Name: "orders"},
+		},
+		{
+			name: "partner event bus",
+			arn:  "arn:aws:events:us-west-2:111122223333:event-bus/aws.partner/example.com/123/orders",
+			want: ARN{
+				Partition: "aws", Region: "us-west-2", AccountID: "111122223333",
+				Kind: KindEventBus, Name: "aws.partner/example.com/123/orders",
+			},
+		},
+		{
+			name: "rule on the default event bus",
+			arn:  "arn:aws:events:us-west-2:111122223333:rule/order-created",
+			want: ARN{
+				Partition: "aws", Region: "us-west-2", AccountID: "111122223333",
+				Kind: KindRule, EventBus: DefaultEventBus, Name: "order-created",
+			},
+		},
+		{
+			name: "rule on a partner event bus",
+			arn:  "arn:aws:events:us-west-2:111122223333:rule/aws.partner/example.com/123/orders/order-created",
+			want: ARN{
+				Partition: "aws", Region: "us-west-2", AccountID: "111122223333",
+				Kind: KindRule, EventBus: "aws.partner/example.com/123/orders", Name: "order-created",
+			},
+		},
+		{
+			name: "archive",
+			arn:  "arn:aws-us-gov:events:us-gov-west-1:111122223333:archive/orders",
+			want: ARN{Partition: "aws-us-gov", Region: "us-gov-west-1", AccountID: "111122223333", Kind: KindArchive, Name: "orders"},
+		},
+		{
+			name: "endpoint",
+			arn:  "arn:aws:events:us-west-2:111122223333:endpoint/orders",
+			want: ARN{Partition: "aws", Region: "us-west-2", AccountID: "111122223333", Kind: KindEndpoint, Name: "orders"},
+		},
+		{name: "not an ARN", arn: "orders", wantErr: "arn: invalid prefix"},
+		{
+			name:    "other service",
+			arn:     "arn:aws:sqs:us-west-2:111122223333:orders",
+			wantErr: `arn: "arn:aws:sqs:us-west-2:111122223333:orders" is not an EventBridge ARN`,
+		},
+		{
+			name:    "unknown resource type",
+			arn:     "arn:aws:events:us-west-2:111122223333:connection/orders",
+			wantErr: `arn: unknown EventBridge resource type in "arn:aws:events:us-west-2:111122223333:connection/orders"`,
+		},
+		{
+			name:    "missing name",
+			arn:     "arn:aws:events:us-west-2:111122223333:event-bus/",
+			wantErr: `arn: missing resource name in "arn:aws:events:us-west-2:111122223333:event-bus/"`,
+		},
+		{
+			name:    "missing rule name",
+			arn:     "arn:aws:events:us-west-2:111122223333:rule/orders/",
+			wantErr: `arn: missing resource name in "arn:aws:events:us-west-2:111122223333:rule/orders/"`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Parse(tt.arn)
+			if tt.wantErr != "" {
+				assert.ErrorContains(t, err, tt.wantErr)
+				return
+			}
+			assert.NilError(t, err)
+			assert.DeepEqual(t, got, tt.want)
+			assert.Equal(t, got.String(), tt.arn)
+		})
+	}
+}
+
+func TestEventBusName(t *testing.T) {
+	tests := []struct {
+		nameOrARN string
+		want      string
+	}{
+		{nameOrARN: "", want: DefaultEventBus},
+		{nameOrARN: "default", want: DefaultEventBus},
+		{nameOrARN: "orders", want: "orders"},
+		{nameOrARN: "arn:aws:events:us-west-2:111122223333:event-bus/orders", want: "orders"},
+		{nameOrARN: "arn:aws:events:us-west-2:111122223333:event-bus/default", want: DefaultEventBus},
+		{
+			nameOrARN: "arn:aws:events:us-west-2:111122223333:event-bus/aws.partner/example.com/123/orders",
+			want:      "aws.partner/example.com/123/orders",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.nameOrARN, func(t *testing.T) {
+			assert.Equal(t, EventBusName(tt.nameOrARN), tt.want)
+		})
+	}
+}
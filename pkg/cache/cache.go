@@ -0,0 +1,123 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+// Package cache caches the reads of sub-resources, such as the tags and the
+// targets of a rule, between reconciliations. Entries are keyed by the ARN of
+// the resource and the generation of its custom resource, and expire after
+// the TTL set by the --read-cache-ttl flag. Resource managers invalidate the
+// entries of a resource when they write its sub-resources.
+package cache
+
+import (
+	"sync"
+	"time"
+
+	flag "github.com/spf13/pflag"
+
+	"github.com/aws-controllers-k8s/eventbridge-controller/pkg/metrics"
+)
+
+const flagTTL = "read-cache-ttl"
+
+// ttl is set by the controller flag. Changes made outside of the controller
+// are detected once the cached reads expire.
+var ttl = time.Minute
+
+func init() {
+	flag.DurationVar(
+		&ttl, flagTTL, ttl,
+		"How long the tags, targets and log deliveries read from AWS are reused between reconciliations. "+
+			"Changes made outside of the controller are detected once the reads expire. 0 disables the cache.",
+	)
+}
+
+type entry[V any] struct {
+	generation int64
+	value      V
+	expires    time.Time
+}
+
+// Cache caches values by ARN and generation. It is safe for concurrent use.
+type Cache[V any] struct {
+	name string
+	// copy returns a deep copy of a value, so that callers can't modify the
+	// cached values
+	copy func(V) V
+	now  func() time.Time
+	ttl  func() time.Duration
+
+	mu      sync.Mutex
+	entries map[string]entry[V]
+}
+
+// New returns an empty cache. The name identifies the cache in the metrics.
+func New[V any](name string, copy func(V) V) *Cache[V] {
+	return &Cache[V]{
+		name:    name,
+		copy:    copy,
+		now:     time.Now,
+		ttl:     func() time.Duration { return ttl },
+		entries: make(map[string]entry[V]),
+	}
+}
+
+// Get returns the value cached for the ARN and generation, if it hasn't
+// expired
+func (c *Cache[V]) Get(arn string, generation int64) (V, bool) {
+	var zero V
+	if c.ttl() <= 0 {
+		return zero, false
+	}
+	c.mu.Lock()
+	e, ok := c.entries[arn]
+	if ok && (e.generation != generation || !c.now().Before(e.expires)) {
+		delete(c.entries, arn)
+		ok = false
+	}
+	c.mu.Unlock()
+
+	metrics.RecordCacheLookup(c.name, ok)
+	if !ok {
+		return zero, false
+	}
+	return c.copy(e.value), true
+}
+
+// Set caches the value read for the ARN and generation
+func (c *Cache[V]) Set(arn string, generation int64, value V) {
+	ttl := c.ttl()
+	if ttl <= 0 {
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.entries[arn] = entry[V]{
+		generation: generation,
+		value:      c.copy(value),
+		expires:    c.now().Add(ttl),
+	}
+}
+
+// Reset removes all cached values
+func (c *Cache[V]) Reset() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.entries = make(map[string]entry[V])
+}
+
+// Invalidate removes the value cached for the ARN
+func (c *Cache[V]) Invalidate(arn string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	delete(c.entries, arn)
+}
@@ -0,0 +1,79 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package cache
+
+import (
+	"testing"
+	"time"
+
+	"gotest.tools/v3/assert"
+)
+
+const testARN = "arn:aws:events:us-west-2:123456789012:rule/test"
+
+func newTestCache(ttl time.Duration) (*Cache[[]string], *time.Time) {
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	c := New("test", func(v []string) []string { return append([]string(nil), v...) })
+	c.now = func() time.Time { return now }
+	c.ttl = func() time.Duration { return ttl }
+	return c, &now
+}
+
+func TestCache(t *testing.T) {
+	tests := []struct {
+		name       string
+		ttl        time.Duration
+		generation int64
+		elapsed    time.Duration
+		invalidate bool
+		wantHit    bool
+	}{
+		{name: "hit", ttl: time.Minute, generation: 1, elapsed: 59 * time.Second, wantHit: true},
+		{name: "expired", ttl: time.Minute, generation: 1, elapsed: time.Minute},
+		{name: "new generation", ttl: time.Minute, generation: 2},
+		{name: "invalidated", ttl: time.Minute, generation: 1, invalidate: true},
+		{name: "disabled", generation: 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c, now := newTestCache(tt.ttl)
+			c.Set(testARN, 1, []string{"a"})
+			*now = now.Add(tt.elapsed)
+			if tt.invalidate {
+				c.Invalidate(testARN)
+			}
+
+			got, ok := c.Get(testARN, tt.generation)
+			assert.Equal(t, ok, tt.wantHit)
+			if tt.wantHit {
+				assert.DeepEqual(t, got, []string{"a"})
+			}
+		})
+	}
+}
+
+func TestCache_copies(t *testing.T) {
+	c, _ := newTestCache(time.Minute)
+	v := []string{"a"}
+	c.Set(testARN, 1, v)
+	v[0] = "b"
+
+	got, ok := c.Get(testARN, 1)
+	assert.Assert(t, ok)
+	assert.DeepEqual(t, got, []string{"a"})
+	got[0] = "c"
+
+	got, _ = c.Get(testARN, 1)
+	assert.DeepEqual(t, got, []string{"a"})
+}
@@ -0,0 +1,367 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package cfn
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+	"unicode"
+
+	ackv1alpha1 "github.com/aws-controllers-k8s/runtime/apis/core/v1alpha1"
+	ackrt "github.com/aws-controllers-k8s/runtime/pkg/runtime"
+	"github.com/aws/aws-sdk-go-v2/aws"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	svcapitypes "github.com/aws-controllers-k8s/eventbridge-controller/apis/v1alpha1"
+	"github.com/aws-controllers-k8s/eventbridge-controller/pkg/discovery"
+)
+
+const (
+	typeEventBus = "AWS::Events::EventBus"
+	typeRule     = "AWS::Events::Rule"
+	typeArchive  = "AWS::Events::Archive"
+	typeEndpoint = "AWS::Events::Endpoint"
+	typeIAMRole  = "AWS::IAM::Role"
+)
+
+// nameProperties are the properties holding the name of the converted
+// resource types
+var nameProperties = map[string]string{
+	typeEventBus: "Name",
+	typeRule:     "Name",
+	typeArchive:  "ArchiveName",
+	typeEndpoint: "Name",
+}
+
+// Options configure the conversion
+type Options struct {
+	// Namespace is the namespace of the custom resources
+	Namespace string
+	// AccountID, Region and Partition resolve the pseudo parameters and ARNs
+	// of the converted resources. Partition defaults to "aws".
+	AccountID string
+	Region    string
+	Partition string
+	// Parameters are the template parameter values, which take precedence
+	// over the parameter defaults
+	Parameters map[string]string
+	// Adopt annotates the custom resources with the adopt-or-create policy,
+	// so the controller takes over the resources deployed by the stack
+	Adopt bool
+}
+
+// Issue is a property which couldn't be converted
+type Issue struct {
+	LogicalID string
+	// Path is the property path, e.g. Targets[0].EcsParameters, or empty if
+	// the issue concerns the whole resource
+	Path   string
+	Reason string
+}
+
+func (i Issue) String() string {
+	if i.Path == "" {
+		return fmt.Sprintf("%s: %s", i.LogicalID, i.Reason)
+	}
+	return fmt.Sprintf("%s: %s: %s", i.LogicalID, i.Path, i.Reason)
+}
+
+type converter struct {
+	t         *Template
+	opts      Options
+	logicalID string
+	issues    []Issue
+}
+
+// Convert converts the EventBridge resources of the template to custom
+// resources. Properties which can't be converted are omitted from the custom
+// resources and returned as issues.
+func Convert(t *Template, opts Options) (*discovery.Inventory, []Issue) {
+	c := &converter{t: t, opts: opts}
+	ids := make([]string, 0, len(t.Resources))
+	for id := range t.Resources {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+
+	inv := &discovery.Inventory{}
+	for _, id := range ids {
+		r := t.Resources[id]
+		c.logicalID = id
+		switch r.Type {
+		case typeEventBus:
+			inv.EventBuses = append(inv.EventBuses, c.eventBus(r))
+		case typeRule:
+			inv.Rules = append(inv.Rules, c.rule(r))
+		case typeArchive:
+			inv.Archives = append(inv.Archives, c.archive(r))
+		case typeEndpoint:
+			inv.Endpoints = append(inv.Endpoints, c.endpoint(r))
+		default:
+			if strings.HasPrefix(r.Type, "AWS::Events::") {
+				c.issue("", "resource type %s is not supported", r.Type)
+			}
+		}
+	}
+	return inv, c.issues
+}
+
+func (c *converter) eventBus(r Resource) *svcapitypes.EventBus {
+	props := copyProperties(r.Properties)
+	obj := &svcapitypes.EventBus{
+		TypeMeta:   c.typeMeta("EventBus"),
+		ObjectMeta: c.objectMeta(),
+	}
+	obj.Spec.Name = c.name(r, props)
+	c.decodeStruct("", props, reflect.ValueOf(&obj.Spec).Elem())
+	return obj
+}
+
+func (c *converter) rule(r Resource) *svcapitypes.Rule {
+	props := copyProperties(r.Properties)
+	obj := &svcapitypes.Rule{
+		TypeMeta:   c.typeMeta("Rule"),
+		ObjectMeta: c.objectMeta(),
+	}
+	obj.Spec.Name = c.name(r, props)
+	if v, ok := take(props, "EventBusName"); ok {
+		if bus := c.resourceRef(v, typeEventBus, "Name", "Arn"); bus != nil {
+			obj.Spec.EventBusRef = bus
+		} else {
+			obj.Spec.EventBusName = c.string("EventBusName", v)
+		}
+	}
+	if v, ok := take(props, "EventPattern"); ok {
+		obj.Spec.EventPattern = c.document("EventPattern", v)
+	}
+	if v, ok := take(props, "RoleArn"); ok {
+		if role := c.resourceRef(v, typeIAMRole, "Arn"); role != nil {
+			obj.Spec.RoleRef = role
+		} else {
+			obj.Spec.RoleARN = c.string("RoleArn", v)
+		}
+	}
+	c.decodeStruct("", props, reflect.ValueOf(&obj.Spec).Elem())
+	return obj
+}
+
+func (c *converter) archive(r Resource) *svcapitypes.Archive {
+	props := copyProperties(r.Properties)
+	obj := &svcapitypes.Archive{
+		TypeMeta:   c.typeMeta("Archive"),
+		ObjectMeta: c.objectMeta(),
+	}
+	obj.Spec.Name = c.name(r, props)
+	if v, ok := take(props, "SourceArn"); ok {
+		if bus := c.resourceRef(v, typeEventBus, "Arn"); bus != nil {
+			obj.Spec.EventSourceRef = bus
+		} else {
+			obj.Spec.EventSourceARN = c.string("SourceArn", v)
+		}
+	}
+	if v, ok := take(props, "EventPattern"); ok {
+		obj.Spec.EventPattern = c.document("EventPattern", v)
+	}
+	c.decodeStruct("", props, reflect.ValueOf(&obj.Spec).Elem())
+	return obj
+}
+
+func (c *converter) endpoint(r Resource) *svcapitypes.Endpoint {
+	props := copyProperties(r.Properties)
+	obj := &svcapitypes.Endpoint{
+		TypeMeta:   c.typeMeta("Endpoint"),
+		ObjectMeta: c.objectMeta(),
+	}
+	obj.Spec.Name = c.name(r, props)
+	if v, ok := take(props, "RoleArn"); ok {
+		if role := c.resourceRef(v, typeIAMRole, "Arn"); role != nil {
+			obj.Spec.RoleRef = role
+		} else {
+			obj.Spec.RoleARN = c.string("RoleArn", v)
+		}
+	}
+	c.decodeStruct("", props, reflect.ValueOf(&obj.Spec).Elem())
+	return obj
+}
+
+func (c *converter) typeMeta(kind string) metav1.TypeMeta {
+	return metav1.TypeMeta{
+		APIVersion: svcapitypes.GroupVersion.String(),
+		Kind:       kind,
+	}
+}
+
+func (c *converter) objectMeta() metav1.ObjectMeta {
+	meta := metav1.ObjectMeta{
+		Name:      objectName(c.logicalID),
+		Namespace: c.opts.Namespace,
+	}
+	if c.opts.Adopt {
+		meta.Annotations = map[string]string{
+			ackv1alpha1.AnnotationAdoptionPolicy: string(ackrt.AdoptionPolicy_AdoptOrCreate),
+		}
+	}
+	return meta
+}
+
+// name takes the name property of the resource. Without one, CloudFormation
+// generates the name, which can't be known offline, so the logical ID is used
+// instead.
+func (c *converter) name(r Resource, props map[string]interface{}) *string {
+	prop := nameProperties[r.Type]
+	v, ok := take(props, prop)
+	if !ok {
+		c.issue(prop, "not set, the name generated by CloudFormation is replaced by %q", c.logicalID)
+		return aws.String(c.logicalID)
+	}
+	return c.string(prop, v)
+}
+
+// physicalName returns the name of the converted resource with the logical
+// ID, the same way name does
+func (c *converter) physicalName(id string) (string, error) {
+	r := c.t.Resources[id]
+	v, ok := r.Properties[nameProperties[r.Type]]
+	if !ok {
+		return id, nil
+	}
+	return c.resolveString(v)
+}
+
+// resourceRef returns a reference to the custom resource converted from the
+// resource of the given type, if v is a Ref or an Fn::GetAtt of one of the
+// attributes of such a resource
+func (c *converter) resourceRef(v interface{}, resourceType string, attrs ...string) *ackv1alpha1.AWSResourceReferenceWrapper {
+	var id, attr string
+	switch name, arg, _ := intrinsic(v); name {
+	case "Ref":
+		// Ref returns the name of an event bus
+		id, _ = arg.(string)
+		if resourceType == typeEventBus {
+			attr = "Name"
+		}
+	case "Fn::GetAtt":
+		var err error
+		if id, attr, err = getAtt(arg); err != nil {
+			return nil
+		}
+	default:
+		return nil
+	}
+	found := false
+	for _, a := range attrs {
+		found = found || a == attr
+	}
+	if !found {
+		return nil
+	}
+	if r, ok := c.t.Resources[id]; !ok || r.Type != resourceType {
+		return nil
+	}
+	return &ackv1alpha1.AWSResourceReferenceWrapper{
+		From: &ackv1alpha1.AWSResourceReference{Name: aws.String(objectName(id))},
+	}
+}
+
+// string resolves a string property, reporting an issue if it can't be
+// resolved
+func (c *converter) string(path string, v interface{}) *string {
+	s, err := c.resolveString(v)
+	if err != nil {
+		c.resolveIssue(path, err)
+		return nil
+	}
+	return aws.String(s)
+}
+
+// document converts a JSON document property, given as an object or as a
+// string, to a JSON string
+func (c *converter) document(path string, v interface{}) *string {
+	if _, ok := v.(map[string]interface{}); !ok || isIntrinsic(v) {
+		return c.string(path, v)
+	}
+	doc, err := c.resolveDocument(v)
+	if err != nil {
+		c.resolveIssue(path, err)
+		return nil
+	}
+	b, err := json.Marshal(doc)
+	if err != nil {
+		c.issue(path, "%v", err)
+		return nil
+	}
+	return aws.String(string(b))
+}
+
+func (c *converter) issue(path, format string, args ...interface{}) {
+	c.issues = append(c.issues, Issue{
+		LogicalID: c.logicalID,
+		Path:      path,
+		Reason:    fmt.Sprintf(format, args...),
+	})
+}
+
+// resolveIssue reports an error resolving the property, unless the property
+// is omitted with AWS::NoValue
+func (c *converter) resolveIssue(path string, err error) {
+	if errors.Is(err, errNoValue) {
+		return
+	}
+	c.issue(path, "%v", err)
+}
+
+func isIntrinsic(v interface{}) bool {
+	_, _, ok := intrinsic(v)
+	return ok
+}
+
+// take removes and returns the property
+func take(props map[string]interface{}, name string) (interface{}, bool) {
+	v, ok := props[name]
+	delete(props, name)
+	return v, ok
+}
+
+func copyProperties(props map[string]interface{}) map[string]interface{} {
+	res := make(map[string]interface{}, len(props))
+	for k, v := range props {
+		res[k] = v
+	}
+	return res
+}
+
+// objectName converts a logical ID like OrdersEventBus to a Kubernetes object
+// name like orders-event-bus
+func objectName(id string) string {
+	runes := []rune(id)
+	var b strings.Builder
+	for i, r := range runes {
+		if !unicode.IsLetter(r) && !unicode.IsDigit(r) {
+			continue
+		}
+		if unicode.IsUpper(r) && i > 0 {
+			prev := runes[i-1]
+			nextLower := i+1 < len(runes) && unicode.IsLower(runes[i+1])
+			if unicode.IsLower(prev) || unicode.IsDigit(prev) || (unicode.IsUpper(prev) && nextLower) {
+				b.WriteRune('-')
+			}
+		}
+		b.WriteRune(unicode.ToLower(r))
+	}
+	return b.String()
+}
@@ -0,0 +1,440 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package cfn
+
+import (
+	"testing"
+
+	ackv1alpha1 "github.com/aws-controllers-k8s/runtime/apis/core/v1alpha1"
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"gotest.tools/v3/assert"
+
+	svcapitypes "github.com/aws-controllers-k8s/eventbridge-controller/apis/v1alpha1"
+	"github.com/aws-controllers-k8s/eventbridge-controller/pkg/discovery"
+)
+
+const ordersTemplate = `
+Parameters:
+  Stage:
+    Type: String
+    Default: dev
+  RetentionDays:
+    Type: Number
+Resources:
+  OrdersBus:
+    Type: AWS::Events::EventBus
+    Properties:
+      Name: !Sub orders-${Stage}
+      Description: not supported by the custom resource
+      Tags:
+        - Key: stage
+          Value: !Ref Stage
+  OrdersRole:
+    Type: AWS::IAM::Role
+  OrdersQueue:
+    Type: AWS::SQS::Queue
+  OrderCreatedRule:
+    Type: AWS::Events::Rule
+    Properties:
+      Name: order-created
+      EventBusName: !Ref OrdersBus
+      RoleArn: !GetAtt OrdersRole.Arn
+      State: ENABLED
+      EventPattern:
+        source: [!Sub "${Stage}.orders"]
+        detail-type: [OrderCreated]
+      Targets:
+        - Id: queue
+          Arn: !GetAtt OrdersQueue.Arn
+        - Id: bus
+          Arn: !GetAtt OrdersBus.Arn
+  NightlyReport:
+    Type: AWS::Events::Rule
+    Properties:
+      ScheduleExpression: rate(1 day)
+      Tags: !Ref AWS::NoValue
+  OrdersArchive:
+    Type: AWS::Events::Archive
+    Properties:
+      ArchiveName: orders-archive
+      SourceArn: !GetAtt OrdersBus.Arn
+      RetentionDays: !Ref RetentionDays
+  OrdersEndpoint:
+    Type: AWS::Events::Endpoint
+    Properties:
+      Name: orders-endpoint
+      RoleArn: arn:aws:iam::123456789012:role/replication
+      EventBuses:
+        - EventBusArn: !GetAtt OrdersBus.Arn
+        - EventBusArn: !Sub arn:${AWS::Partition}:events:us-east-1:${AWS::AccountId}:event-bus/orders-${Stage}
+      ReplicationConfig:
+        State: ENABLED
+      RoutingConfig:
+        FailoverConfig:
+          Primary:
+            HealthCheck: arn:aws:route53:::healthcheck/orders
+          Secondary:
+            Route: us-east-1
+  OrdersConnection:
+    Type: AWS::Events::Connection
+`
+
+func TestConvert(t *testing.T) {
+	tmpl, err := Parse([]byte(ordersTemplate))
+	assert.NilError(t, err)
+
+	tests := []struct {
+		name       string
+		opts       Options
+		wantIssues []string
+		check      func(t *testing.T, r *discovery.Inventory)
+	}{
+		{
+			name: "account and region",
+			opts: Options{
+				Namespace:  "orders",
+				AccountID:  "123456789012",
+				Region:     "us-west-2",
+				Parameters: map[string]string{"RetentionDays": "7"},
+				Adopt:      true,
+			},
+			wantIssues: []string{
+				"NightlyReport: Name: not set, the name generated by CloudFormation is replaced by \"NightlyReport\"",
+				"OrderCreatedRule: Targets[0].Arn: Fn::GetAtt OrdersQueue.Arn references a AWS::SQS::Queue resource, which isn't converted",
+				"OrdersBus: Description: property is not supported by the custom resource",
+				"OrdersConnection: resource type AWS::Events::Connection is not supported",
+			},
+			check: func(t *testing.T, r *discovery.Inventory) {
+				assert.Equal(t, len(r.EventBuses), 1)
+				bus := r.EventBuses[0]
+				assert.Equal(t, bus.Name, "orders-bus")
+				assert.Equal(t, bus.Namespace, "orders")
+				assert.Equal(t, bus.Kind, "EventBus")
+				assert.Equal(t, bus.Annotations[ackv1alpha1.AnnotationAdoptionPolicy], "adopt-or-create")
+				assert.Equal(t, aws.ToString(bus.Spec.Name), "orders-dev")
+				assert.DeepEqual(t, bus.Spec.Tags, []*svcapitypes.Tag{{Key: aws.String("stage"), Value: aws.String("dev")}})
+
+				assert.Equal(t, len(r.Rules), 2)
+				report, created := r.Rules[0], r.Rules[1]
+				assert.Equal(t, report.Name, "nightly-report")
+				assert.Equal(t, aws.ToString(report.Spec.Name), "NightlyReport")
+				assert.Assert(t, report.Spec.Tags == nil)
+
+				assert.Equal(t, created.Name, "order-created-rule")
+				assert.Assert(t, created.Spec.EventBusName == nil)
+				assert.Equal(t, aws.ToString(created.Spec.EventBusRef.From.Name), "orders-bus")
+				assert.Assert(t, created.Spec.RoleARN == nil)
+				assert.Equal(t, aws.ToString(created.Spec.RoleRef.From.Name), "orders-role")
+				assert.Equal(t, aws.ToString(created.Spec.EventPattern), `{"detail-type":["OrderCreated"],"source":["dev.orders"]}`)
+				assert.DeepEqual(t, created.Spec.Targets, []*svcapitypes.Target{
+					{ID: aws.String("queue")},
+					{ID: aws.String("bus"), ARN: aws.String("arn:aws:events:us-west-2:123456789012:event-bus/orders-dev")},
+				})
+
+				assert.Equal(t, len(r.Archives), 1)
+				archive := r.Archives[0]
+				assert.Equal(t, aws.ToString(archive.Spec.Name), "orders-archive")
+				assert.Equal(t, aws.ToString(archive.Spec.EventSourceRef.From.Name), "orders-bus")
+				assert.Equal(t, aws.ToInt64(archive.Spec.RetentionDays), int64(7))
+
+				assert.Equal(t, len(r.Endpoints), 1)
+				endpoint := r.Endpoints[0]
+				assert.Equal(t, aws.ToString(endpoint.Spec.RoleARN), "arn:aws:iam::123456789012:role/replication")
+				assert.DeepEqual(t, endpoint.Spec.EventBuses, []*svcapitypes.EndpointEventBus{
+					{EventBusARN: aws.String("arn:aws:events:us-west-2:123456789012:event-bus/orders-dev")},
+					{EventBusARN: aws.String("arn:aws:events:us-east-1:123456789012:event-bus/orders-dev")},
+				})
+				assert.Equal(t, aws.ToString(endpoint.Spec.ReplicationConfig.State), "ENABLED")
+				assert.Equal(t, aws.ToString(endpoint.Spec.RoutingConfig.FailoverConfig.Secondary.Route), "us-east-1")
+			},
+		},
+		{
+			name: "no account, region or parameters",
+			wantIssues: []string{
+				"NightlyReport: Name: not set, the name generated by CloudFormation is replaced by \"NightlyReport\"",
+				"OrderCreatedRule: Targets[0].Arn: Fn::GetAtt OrdersQueue.Arn references a AWS::SQS::Queue resource, which isn't converted",
+				"OrderCreatedRule: Targets[1].Arn: Fn::GetAtt OrdersBus.Arn can't be resolved without the region option",
+				"OrdersArchive: RetentionDays: parameter RetentionDays has no default value, set it with the parameters option",
+				"OrdersBus: Description: property is not supported by the custom resource",
+				"OrdersConnection: resource type AWS::Events::Connection is not supported",
+				"OrdersEndpoint: EventBuses[0].EventBusArn: Fn::GetAtt OrdersBus.Arn can't be resolved without the region option",
+				"OrdersEndpoint: EventBuses[1].EventBusArn: Ref AWS::AccountId can't be resolved without the account ID option",
+			},
+			check: func(t *testing.T, r *discovery.Inventory) {
+				assert.Assert(t, r.Archives[0].Annotations == nil)
+				assert.Assert(t, r.Archives[0].Spec.RetentionDays == nil)
+				// references don't need the account and region
+				assert.Equal(t, aws.ToString(r.Archives[0].Spec.EventSourceRef.From.Name), "orders-bus")
+				assert.DeepEqual(t, r.Endpoints[0].Spec.EventBuses, []*svcapitypes.EndpointEventBus{{}, {}})
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			inv, issues := Convert(tmpl, tt.opts)
+			var got []string
+			for _, i := range issues {
+				got = append(got, i.String())
+			}
+			assert.DeepEqual(t, got, tt.wantIssues)
+			tt.check(t, inv)
+		})
+	}
+}
+
+func TestConvert_targets(t *testing.T) {
+	tmpl, err := Parse([]byte(`{
+  "Resources": {
+    "Rule": {
+      "Type": "AWS::Events::Rule",
+      "Properties": {
+        "Name": "all-targets",
+        "Targets": [
+          {
+            "Id": "batch",
+            "Arn": "arn:aws:batch:us-west-2:123456789012:job-queue/q",
+            "RoleArn": "arn:aws:iam::123456789012:role/events",
+            "BatchParameters": {
+              "ArrayProperties": {"Size": 10},
+              "JobDefinition": "def",
+              "JobName": "job",
+              "RetryStrategy": {"Attempts": "3"}
+            },
+            "DeadLetterConfig": {"Arn": "arn:aws:sqs:us-west-2:123456789012:dlq"},
+            "RetryPolicy": {"MaximumEventAgeInSeconds": 60, "MaximumRetryAttempts": 2}
+          },
+          {
+            "Id": "ecs",
+            "Arn": "arn:aws:ecs:us-west-2:123456789012:cluster/c",
+            "EcsParameters": {
+              "CapacityProviderStrategy": [{"Base": 1, "CapacityProvider": "FARGATE", "Weight": 2}],
+              "EnableECSManagedTags": true,
+              "EnableExecuteCommand": "false",
+              "Group": "g",
+              "LaunchType": "FARGATE",
+              "NetworkConfiguration": {
+                "AwsVpcConfiguration": {"AssignPublicIp": "ENABLED", "SecurityGroups": ["sg-1"], "Subnets": ["subnet-1", "subnet-2"]}
+              },
+              "PlacementConstraints": [{"Expression": "attribute:ecs.os-type == linux", "Type": "memberOf"}],
+              "PlacementStrategies": [{"Field": "cpu", "Type": "binpack"}],
+              "PlatformVersion": "LATEST",
+              "PropagateTags": "TASK_DEFINITION",
+              "ReferenceId": "ref",
+              "TagList": [{"Key": "k", "Value": "v"}],
+              "TaskCount": 1,
+              "TaskDefinitionArn": "arn:aws:ecs:us-west-2:123456789012:task-definition/t"
+            }
+          },
+          {
+            "Id": "http",
+            "Arn": "arn:aws:execute-api:us-west-2:123456789012:api/stage/GET/",
+            "HttpParameters": {
+              "HeaderParameters": {"x-source": "events"},
+              "PathParameterValues": ["a"],
+              "QueryStringParameters": {"q": "1"}
+            },
+            "InputTransformer": {
+              "InputPathsMap": {"id": "$.id"},
+              "InputTemplate": "{\"id\": <id>}"
+            }
+          },
+          {
+            "Id": "kinesis",
+            "Arn": "arn:aws:kinesis:us-west-2:123456789012:stream/s",
+            "InputPath": "$.detail",
+            "KinesisParameters": {"PartitionKeyPath": "$.id"}
+          },
+          {
+            "Id": "redshift",
+            "Arn": "arn:aws:redshift:us-west-2:123456789012:cluster:c",
+            "RedshiftDataParameters": {
+              "Database": "db",
+              "DbUser": "user",
+              "SecretManagerArn": "arn:aws:secretsmanager:us-west-2:123456789012:secret:s",
+              "Sql": "select 1",
+              "Sqls": ["select 2"],
+              "StatementName": "stmt",
+              "WithEvent": true
+            }
+          },
+          {
+            "Id": "ssm",
+            "Arn": "arn:aws:ssm:us-west-2::document/AWS-RunShellScript",
+            "Input": "{\"commands\": [\"uptime\"]}",
+            "RunCommandParameters": {"RunCommandTargets": [{"Key": "tag:app", "Values": ["orders"]}]}
+          },
+          {
+            "Id": "sagemaker",
+            "Arn": "arn:aws:sagemaker:us-west-2:123456789012:pipeline/p",
+            "SageMakerPipelineParameters": {"PipelineParameterList": [{"Name": "n", "Value": "v"}]}
+          },
+          {
+            "Id": "sqs",
+            "Arn": "arn:aws:sqs:us-west-2:123456789012:orders.fifo",
+            "SqsParameters": {"MessageGroupId": "orders"}
+          },
+          {
+            "Id": "appsync",
+            "Arn": "arn:aws:appsync:us-west-2:123456789012:apis/a",
+            "AppSyncParameters": {"GraphQLOperation": "mutation {}"}
+          }
+        ]
+      }
+    }
+  }
+}`))
+	assert.NilError(t, err)
+
+	inv, issues := Convert(tmpl, Options{})
+	assert.DeepEqual(t, issues, []Issue{{
+		LogicalID: "Rule",
+		Path:      "Targets[8].AppSyncParameters",
+		Reason:    "property is not supported by the custom resource",
+	}})
+	assert.Equal(t, len(inv.Rules), 1)
+	assert.DeepEqual(t, inv.Rules[0].Spec.Targets, []*svcapitypes.Target{
+		{
+			ID:      aws.String("batch"),
+			ARN:     aws.String("arn:aws:batch:us-west-2:123456789012:job-queue/q"),
+			RoleARN: aws.String("arn:aws:iam::123456789012:role/events"),
+			BatchParameters: &svcapitypes.BatchParameters{
+				ArrayProperties: &svcapitypes.BatchArrayProperties{Size: aws.Int64(10)},
+				JobDefinition:   aws.String("def"),
+				JobName:         aws.String("job"),
+				RetryStrategy:   &svcapitypes.BatchRetryStrategy{Attempts: aws.Int64(3)},
+			},
+			DeadLetterConfig: &svcapitypes.DeadLetterConfig{ARN: aws.String("arn:aws:sqs:us-west-2:123456789012:dlq")},
+			RetryPolicy: &svcapitypes.RetryPolicy{
+				MaximumEventAgeInSeconds: aws.Int64(60),
+				MaximumRetryAttempts:     aws.Int64(2),
+			},
+		},
+		{
+			ID:  aws.String("ecs"),
+			ARN: aws.String("arn:aws:ecs:us-west-2:123456789012:cluster/c"),
+			ECSParameters: &svcapitypes.ECSParameters{
+				CapacityProviderStrategy: []*svcapitypes.CapacityProviderStrategyItem{{
+					Base:             aws.Int64(1),
+					CapacityProvider: aws.String("FARGATE"),
+					Weight:           aws.Int64(2),
+				}},
+				EnableECSManagedTags: aws.Bool(true),
+				EnableExecuteCommand: aws.Bool(false),
+				Group:                aws.String("g"),
+				LaunchType:           aws.String("FARGATE"),
+				NetworkConfiguration: &svcapitypes.NetworkConfiguration{
+					AWSVPCConfiguration: &svcapitypes.AWSVPCConfiguration{
+						AssignPublicIP: aws.String("ENABLED"),
+						SecurityGroups: []*string{aws.String("sg-1")},
+						Subnets:        []*string{aws.String("subnet-1"), aws.String("subnet-2")},
+					},
+				},
+				PlacementConstraints: []*svcapitypes.PlacementConstraint{{
+					Expression: aws.String("attribute:ecs.os-type == linux"),
+					Type:       aws.String("memberOf"),
+				}},
+				PlacementStrategy: []*svcapitypes.PlacementStrategy{{
+					Field: aws.String("cpu"),
+					Type:  aws.String("binpack"),
+				}},
+				PlatformVersion:   aws.String("LATEST"),
+				PropagateTags:     aws.String("TASK_DEFINITION"),
+				ReferenceID:       aws.String("ref"),
+				Tags:              []*svcapitypes.Tag{{Key: aws.String("k"), Value: aws.String("v")}},
+				TaskCount:         aws.Int64(1),
+				TaskDefinitionARN: aws.String("arn:aws:ecs:us-west-2:123456789012:task-definition/t"),
+			},
+		},
+		{
+			ID:  aws.String("http"),
+			ARN: aws.String("arn:aws:execute-api:us-west-2:123456789012:api/stage/GET/"),
+			HTTPParameters: &svcapitypes.HTTPParameters{
+				HeaderParameters:      map[string]*string{"x-source": aws.String("events")},
+				PathParameterValues:   []*string{aws.String("a")},
+				QueryStringParameters: map[string]*string{"q": aws.String("1")},
+			},
+			InputTransformer: &svcapitypes.InputTransformer{
+				InputPathsMap: map[string]*string{"id": aws.String("$.id")},
+				InputTemplate: aws.String(`{"id": <id>}`),
+			},
+		},
+		{
+			ID:                aws.String("kinesis"),
+			ARN:               aws.String("arn:aws:kinesis:us-west-2:123456789012:stream/s"),
+			InputPath:         aws.String("$.detail"),
+			KinesisParameters: &svcapitypes.KinesisParameters{PartitionKeyPath: aws.String("$.id")},
+		},
+		{
+			ID:  aws.String("redshift"),
+			ARN: aws.String("arn:aws:redshift:us-west-2:123456789012:cluster:c"),
+			RedshiftDataParameters: &svcapitypes.RedshiftDataParameters{
+				Database:         aws.String("db"),
+				DBUser:           aws.String("user"),
+				SecretManagerARN: aws.String("arn:aws:secretsmanager:us-west-2:123456789012:secret:s"),
+				SQL:              aws.String("select 1"),
+				SQLs:             []*string{aws.String("select 2")},
+				StatementName:    aws.String("stmt"),
+				WithEvent:        aws.Bool(true),
+			},
+		},
+		{
+			ID:    aws.String("ssm"),
+			ARN:   aws.String("arn:aws:ssm:us-west-2::document/AWS-RunShellScript"),
+			Input: aws.String(`{"commands": ["uptime"]}`),
+			RunCommandParameters: &svcapitypes.RunCommandParameters{
+				RunCommandTargets: []*svcapitypes.RunCommandTarget{{
+					Key:    aws.String("tag:app"),
+					Values: []*string{aws.String("orders")},
+				}},
+			},
+		},
+		{
+			ID:  aws.String("sagemaker"),
+			ARN: aws.String("arn:aws:sagemaker:us-west-2:123456789012:pipeline/p"),
+			SageMakerPipelineParameters: &svcapitypes.SageMakerPipelineParameters{
+				PipelineParameterList: []*svcapitypes.SageMakerPipelineParameter{{
+					Name:  aws.String("n"),
+					Value: aws.String("v"),
+				}},
+			},
+		},
+		{
+			ID:            aws.String("sqs"),
+			ARN:           aws.String("arn:aws:sqs:us-west-2:123456789012:orders.fifo"),
+			SQSParameters: &svcapitypes.SQSParameters{MessageGroupID: aws.String("orders")},
+		},
+		{
+			ID:  aws.String("appsync"),
+			ARN: aws.String("arn:aws:appsync:us-west-2:123456789012:apis/a"),
+		},
+	})
+}
+
+func Test_objectName(t *testing.T) {
+	tests := []struct {
+		id   string
+		want string
+	}{
+		{"OrdersBus", "orders-bus"},
+		{"SQSQueueRule", "sqs-queue-rule"},
+		{"Rule2", "rule2"},
+		{"Rule2Target", "rule2-target"},
+		{"lower", "lower"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.id, func(t *testing.T) {
+			assert.Equal(t, objectName(tt.id), tt.want)
+		})
+	}
+}
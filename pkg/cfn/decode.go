@@ -0,0 +1,166 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package cfn
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// propertyAliases maps the CloudFormation properties whose names differ from
+// the fields of the API types by more than the case
+var propertyAliases = map[string]string{
+	"PlacementStrategies": "PlacementStrategy",
+	"TagList":             "Tags",
+}
+
+// decodeStruct sets the fields of dst, a struct of an API type, from the
+// properties. Properties without a matching field are reported.
+func (c *converter) decodeStruct(path string, props map[string]interface{}, dst reflect.Value) {
+	keys := make([]string, 0, len(props))
+	for k := range props {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		p := k
+		if path != "" {
+			p = path + "." + k
+		}
+		field, ok := fieldByProperty(dst.Type(), k)
+		if !ok {
+			c.issue(p, "property is not supported by the custom resource")
+			continue
+		}
+		c.decode(p, props[k], dst.FieldByIndex(field.Index))
+	}
+}
+
+// fieldByProperty returns the field of the struct type matching the property
+func fieldByProperty(t reflect.Type, prop string) (reflect.StructField, bool) {
+	if alias, ok := propertyAliases[prop]; ok {
+		prop = alias
+	}
+	return t.FieldByNameFunc(func(name string) bool {
+		return strings.EqualFold(name, prop)
+	})
+}
+
+// decode sets dst, a pointer, slice or map field, from the property value
+func (c *converter) decode(path string, v interface{}, dst reflect.Value) {
+	switch dst.Kind() {
+	case reflect.Ptr:
+		elem := reflect.New(dst.Type().Elem())
+		if !c.decodeValue(path, v, elem.Elem()) {
+			return
+		}
+		dst.Set(elem)
+	case reflect.Slice:
+		if name, _, ok := intrinsic(v); ok {
+			c.resolveIssue(path, c.unsupported(name, v))
+			return
+		}
+		items, ok := v.([]interface{})
+		if !ok {
+			c.issue(path, "expected a list, got %T", v)
+			return
+		}
+		res := reflect.MakeSlice(dst.Type(), 0, len(items))
+		for i, item := range items {
+			elem := reflect.New(dst.Type().Elem()).Elem()
+			c.decode(fmt.Sprintf("%s[%d]", path, i), item, elem)
+			if !elem.IsZero() {
+				res = reflect.Append(res, elem)
+			}
+		}
+		dst.Set(res)
+	case reflect.Map:
+		m, ok := v.(map[string]interface{})
+		if !ok || isIntrinsic(v) {
+			c.issue(path, "expected a map, got %T", v)
+			return
+		}
+		res := reflect.MakeMapWithSize(dst.Type(), len(m))
+		for k, val := range m {
+			elem := reflect.New(dst.Type().Elem()).Elem()
+			c.decode(path+"."+k, val, elem)
+			if !elem.IsZero() {
+				res.SetMapIndex(reflect.ValueOf(k), elem)
+			}
+		}
+		dst.Set(res)
+	default:
+		c.issue(path, "unsupported field type %s", dst.Type())
+	}
+}
+
+// decodeValue sets dst, a struct, string, integer or boolean, from the
+// property value and returns whether it succeeded
+func (c *converter) decodeValue(path string, v interface{}, dst reflect.Value) bool {
+	if dst.Kind() == reflect.Struct {
+		props, ok := v.(map[string]interface{})
+		if !ok || isIntrinsic(v) {
+			if name, _, ok := intrinsic(v); ok {
+				c.resolveIssue(path, c.unsupported(name, v))
+			} else {
+				c.issue(path, "expected an object, got %T", v)
+			}
+			return false
+		}
+		c.decodeStruct(path, props, dst)
+		return true
+	}
+
+	s, err := c.resolveString(v)
+	if err != nil {
+		c.resolveIssue(path, err)
+		return false
+	}
+	switch dst.Kind() {
+	case reflect.String:
+		dst.SetString(s)
+	case reflect.Int64:
+		n, err := strconv.ParseInt(s, 10, 64)
+		if err != nil {
+			c.issue(path, "expected an integer, got %q", s)
+			return false
+		}
+		dst.SetInt(n)
+	case reflect.Bool:
+		b, err := strconv.ParseBool(s)
+		if err != nil {
+			c.issue(path, "expected a boolean, got %q", s)
+			return false
+		}
+		dst.SetBool(b)
+	default:
+		c.issue(path, "unsupported field type %s", dst.Type())
+		return false
+	}
+	return true
+}
+
+// unsupported returns the error for an intrinsic function where a list or
+// object is expected
+func (c *converter) unsupported(name string, v interface{}) error {
+	if name == "Ref" {
+		if _, err := c.resolveString(v); err != nil {
+			return err
+		}
+	}
+	return fmt.Errorf("intrinsic function %s is not supported here", name)
+}
@@ -0,0 +1,306 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package cfn
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// errNoValue is returned when a property is set to AWS::NoValue and is
+// therefore omitted
+var errNoValue = errors.New("AWS::NoValue")
+
+// intrinsic returns the name and argument of the intrinsic function v, if v
+// is one
+func intrinsic(v interface{}) (string, interface{}, bool) {
+	m, ok := v.(map[string]interface{})
+	if !ok || len(m) != 1 {
+		return "", nil, false
+	}
+	for name, arg := range m {
+		if name == "Ref" || name == "Condition" || strings.HasPrefix(name, "Fn::") {
+			return name, arg, true
+		}
+	}
+	return "", nil, false
+}
+
+// getAtt returns the logical ID and attribute name of an Fn::GetAtt argument
+func getAtt(arg interface{}) (string, string, error) {
+	switch a := arg.(type) {
+	case string:
+		id, attr, ok := strings.Cut(a, ".")
+		if ok {
+			return id, attr, nil
+		}
+	case []interface{}:
+		if len(a) == 2 {
+			id, ok1 := a[0].(string)
+			attr, ok2 := a[1].(string)
+			if ok1 && ok2 {
+				return id, attr, nil
+			}
+		}
+	}
+	return "", "", fmt.Errorf("invalid Fn::GetAtt argument %v", arg)
+}
+
+// resolveString resolves v, a string or an intrinsic function evaluating to a
+// string, to its value
+func (c *converter) resolveString(v interface{}) (string, error) {
+	switch s := v.(type) {
+	case string:
+		return s, nil
+	case int, int64, float64, bool:
+		return fmt.Sprint(s), nil
+	}
+	name, arg, ok := intrinsic(v)
+	if !ok {
+		return "", fmt.Errorf("expected a string, got %T", v)
+	}
+	switch name {
+	case "Ref":
+		id, ok := arg.(string)
+		if !ok {
+			return "", fmt.Errorf("invalid Ref argument %v", arg)
+		}
+		return c.ref(id)
+	case "Fn::GetAtt":
+		id, attr, err := getAtt(arg)
+		if err != nil {
+			return "", err
+		}
+		return c.attribute(id, attr)
+	case "Fn::Sub":
+		return c.sub(arg)
+	case "Fn::Join":
+		return c.join(arg)
+	}
+	return "", fmt.Errorf("intrinsic function %s is not supported", name)
+}
+
+// ref resolves a Ref to a parameter, pseudo parameter or converted resource
+func (c *converter) ref(id string) (string, error) {
+	switch id {
+	case "AWS::NoValue":
+		return "", errNoValue
+	case "AWS::AccountId":
+		return c.required(c.opts.AccountID, "Ref AWS::AccountId", "account ID")
+	case "AWS::Region":
+		return c.required(c.opts.Region, "Ref AWS::Region", "region")
+	case "AWS::Partition":
+		return c.partition(), nil
+	case "AWS::URLSuffix":
+		return "amazonaws.com", nil
+	}
+	if v, ok := c.opts.Parameters[id]; ok {
+		return v, nil
+	}
+	if p, ok := c.t.Parameters[id]; ok {
+		if p.Default == nil {
+			return "", fmt.Errorf("parameter %s has no default value, set it with the parameters option", id)
+		}
+		return c.resolveString(p.Default)
+	}
+	if r, ok := c.t.Resources[id]; ok {
+		switch r.Type {
+		case typeEventBus, typeArchive, typeEndpoint:
+			// Ref returns the name of these resources
+			return c.physicalName(id)
+		}
+		return "", fmt.Errorf("Ref %s references a %s resource, which isn't converted", id, r.Type)
+	}
+	return "", fmt.Errorf("Ref %s references an unknown parameter or resource", id)
+}
+
+// attribute resolves an Fn::GetAtt of a converted resource
+func (c *converter) attribute(id, attr string) (string, error) {
+	r, ok := c.t.Resources[id]
+	if !ok {
+		return "", fmt.Errorf("Fn::GetAtt %s.%s references an unknown resource", id, attr)
+	}
+	var resourceType string
+	switch r.Type {
+	case typeEventBus:
+		resourceType = "event-bus"
+	case typeRule:
+		resourceType = "rule"
+	case typeArchive:
+		resourceType = "archive"
+	case typeEndpoint:
+		resourceType = "endpoint"
+	default:
+		return "", fmt.Errorf("Fn::GetAtt %s.%s references a %s resource, which isn't converted", id, attr, r.Type)
+	}
+	switch {
+	case attr == "Name" && r.Type == typeEventBus:
+		return c.physicalName(id)
+	case attr == "Arn":
+		name, err := c.physicalName(id)
+		if err != nil {
+			return "", err
+		}
+		if r.Type == typeRule {
+			bus, _ := c.resolveString(r.Properties["EventBusName"])
+			if i := strings.LastIndex(bus, "event-bus/"); i >= 0 {
+				bus = bus[i+len("event-bus/"):]
+			}
+			if bus != "" && bus != "default" {
+				name = bus + "/" + name
+			}
+		}
+		return c.arn(fmt.Sprintf("Fn::GetAtt %s.%s", id, attr), resourceType+"/"+name)
+	}
+	return "", fmt.Errorf("Fn::GetAtt %s.%s is not supported", id, attr)
+}
+
+// sub resolves an Fn::Sub with the string or [string, variables] argument
+func (c *converter) sub(arg interface{}) (string, error) {
+	var (
+		s    string
+		vars map[string]interface{}
+	)
+	switch a := arg.(type) {
+	case string:
+		s = a
+	case []interface{}:
+		if len(a) == 2 {
+			s, _ = a[0].(string)
+			vars, _ = a[1].(map[string]interface{})
+		}
+	}
+	if s == "" {
+		return "", fmt.Errorf("invalid Fn::Sub argument %v", arg)
+	}
+
+	var b strings.Builder
+	for {
+		start := strings.Index(s, "${")
+		if start < 0 {
+			b.WriteString(s)
+			return b.String(), nil
+		}
+		end := strings.Index(s[start:], "}")
+		if end < 0 {
+			return "", fmt.Errorf("unterminated variable in Fn::Sub %q", s)
+		}
+		b.WriteString(s[:start])
+		name := s[start+2 : start+end]
+		s = s[start+end+1:]
+
+		var (
+			val string
+			err error
+		)
+		switch id, attr, isAtt := strings.Cut(name, "."); {
+		case strings.HasPrefix(name, "!"):
+			// ${!Literal} is written as ${Literal}
+			val = "${" + name[1:] + "}"
+		case vars[name] != nil:
+			val, err = c.resolveString(vars[name])
+		case isAtt:
+			val, err = c.attribute(id, attr)
+		default:
+			val, err = c.ref(name)
+		}
+		if err != nil {
+			return "", err
+		}
+		b.WriteString(val)
+	}
+}
+
+// join resolves an Fn::Join with the [delimiter, [values]] argument
+func (c *converter) join(arg interface{}) (string, error) {
+	a, ok := arg.([]interface{})
+	if !ok || len(a) != 2 {
+		return "", fmt.Errorf("invalid Fn::Join argument %v", arg)
+	}
+	sep, ok1 := a[0].(string)
+	values, ok2 := a[1].([]interface{})
+	if !ok1 || !ok2 {
+		return "", fmt.Errorf("invalid Fn::Join argument %v", arg)
+	}
+	parts := make([]string, 0, len(values))
+	for _, v := range values {
+		s, err := c.resolveString(v)
+		if err != nil {
+			return "", err
+		}
+		parts = append(parts, s)
+	}
+	return strings.Join(parts, sep), nil
+}
+
+// resolveDocument resolves the intrinsic functions in a JSON document like an
+// event pattern
+func (c *converter) resolveDocument(v interface{}) (interface{}, error) {
+	if _, _, ok := intrinsic(v); ok {
+		return c.resolveString(v)
+	}
+	switch d := v.(type) {
+	case map[string]interface{}:
+		res := make(map[string]interface{}, len(d))
+		for k, e := range d {
+			val, err := c.resolveDocument(e)
+			if err != nil {
+				return nil, err
+			}
+			res[k] = val
+		}
+		return res, nil
+	case []interface{}:
+		res := make([]interface{}, 0, len(d))
+		for _, e := range d {
+			val, err := c.resolveDocument(e)
+			if err != nil {
+				return nil, err
+			}
+			res = append(res, val)
+		}
+		return res, nil
+	}
+	return v, nil
+}
+
+// required returns the value of an option which is required to resolve expr
+func (c *converter) required(v, expr, option string) (string, error) {
+	if v == "" {
+		return "", fmt.Errorf("%s can't be resolved without the %s option", expr, option)
+	}
+	return v, nil
+}
+
+func (c *converter) partition() string {
+	if c.opts.Partition != "" {
+		return c.opts.Partition
+	}
+	return "aws"
+}
+
+// arn returns the ARN of an EventBridge resource in the account and region of
+// the options
+func (c *converter) arn(expr, resource string) (string, error) {
+	region, err := c.required(c.opts.Region, expr, "region")
+	if err != nil {
+		return "", err
+	}
+	account, err := c.required(c.opts.AccountID, expr, "account ID")
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("arn:%s:events:%s:%s:%s", c.partition(), region, account, resource), nil
+}
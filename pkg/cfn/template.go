@@ -0,0 +1,154 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+// Package cfn converts the AWS::Events::EventBus, AWS::Events::Rule,
+// AWS::Events::Archive and AWS::Events::Endpoint resources of CloudFormation
+// templates into custom resources of the controller.
+//
+// References between the converted resources, written with Ref or
+// Fn::GetAtt, become EventBusRef and EventSourceRef references, and IAM roles
+// of the template become RoleRef references to iam-controller Roles.
+// Properties which can't be represented by the custom resources, or values
+// which can't be resolved offline, are reported as issues.
+package cfn
+
+import (
+	"fmt"
+	"strings"
+
+	"go.yaml.in/yaml/v3"
+)
+
+// Template is the subset of a CloudFormation template used by the converter
+type Template struct {
+	Parameters map[string]Parameter
+	Resources  map[string]Resource
+}
+
+// Parameter is a template parameter
+type Parameter struct {
+	Type    string
+	Default interface{}
+}
+
+// Resource is a template resource
+type Resource struct {
+	Type       string
+	Properties map[string]interface{}
+}
+
+// Parse parses a CloudFormation template in JSON or YAML format. The short
+// form of intrinsic functions in YAML, e.g. !Ref or !GetAtt, is converted to
+// the long form used in JSON templates.
+func Parse(data []byte) (*Template, error) {
+	var doc yaml.Node
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return nil, fmt.Errorf("parsing template: %w", err)
+	}
+	if len(doc.Content) == 0 {
+		return nil, fmt.Errorf("parsing template: empty document")
+	}
+	v, err := nodeValue(doc.Content[0])
+	if err != nil {
+		return nil, fmt.Errorf("parsing template: %w", err)
+	}
+	root, ok := v.(map[string]interface{})
+	if !ok {
+		return nil, fmt.Errorf("parsing template: template is not an object")
+	}
+
+	t := &Template{
+		Parameters: make(map[string]Parameter),
+		Resources:  make(map[string]Resource),
+	}
+	params, _ := root["Parameters"].(map[string]interface{})
+	for name, p := range params {
+		m, _ := p.(map[string]interface{})
+		typ, _ := m["Type"].(string)
+		t.Parameters[name] = Parameter{Type: typ, Default: m["Default"]}
+	}
+	resources, ok := root["Resources"].(map[string]interface{})
+	if !ok {
+		return nil, fmt.Errorf("parsing template: missing Resources")
+	}
+	for id, r := range resources {
+		m, ok := r.(map[string]interface{})
+		if !ok {
+			return nil, fmt.Errorf("parsing template: resource %s is not an object", id)
+		}
+		typ, _ := m["Type"].(string)
+		props, _ := m["Properties"].(map[string]interface{})
+		t.Resources[id] = Resource{Type: typ, Properties: props}
+	}
+	return t, nil
+}
+
+// nodeValue converts a YAML node to the generic JSON representation
+func nodeValue(n *yaml.Node) (interface{}, error) {
+	var v interface{}
+	switch n.Kind {
+	case yaml.AliasNode:
+		return nodeValue(n.Alias)
+	case yaml.MappingNode:
+		m := make(map[string]interface{}, len(n.Content)/2)
+		for i := 0; i+1 < len(n.Content); i += 2 {
+			val, err := nodeValue(n.Content[i+1])
+			if err != nil {
+				return nil, err
+			}
+			m[n.Content[i].Value] = val
+		}
+		v = m
+	case yaml.SequenceNode:
+		s := make([]interface{}, 0, len(n.Content))
+		for _, c := range n.Content {
+			val, err := nodeValue(c)
+			if err != nil {
+				return nil, err
+			}
+			s = append(s, val)
+		}
+		v = s
+	case yaml.ScalarNode:
+		if strings.HasPrefix(n.Tag, "!!") || n.Tag == "" {
+			if err := n.Decode(&v); err != nil {
+				return nil, err
+			}
+			return v, nil
+		}
+		v = n.Value
+	default:
+		return nil, fmt.Errorf("line %d: unsupported YAML node", n.Line)
+	}
+	return shortFormFunction(n, v), nil
+}
+
+// shortFormFunction converts a value with a short form intrinsic function tag
+// like !Ref to its long form
+func shortFormFunction(n *yaml.Node, v interface{}) interface{} {
+	if !strings.HasPrefix(n.Tag, "!") || strings.HasPrefix(n.Tag, "!!") {
+		return v
+	}
+	name := strings.TrimPrefix(n.Tag, "!")
+	switch name {
+	case "Ref", "Condition":
+		return map[string]interface{}{name: v}
+	case "GetAtt":
+		// !GetAtt Resource.Attribute
+		if s, ok := v.(string); ok {
+			id, attr, _ := strings.Cut(s, ".")
+			v = []interface{}{id, attr}
+		}
+	}
+	return map[string]interface{}{"Fn::" + name: v}
+}
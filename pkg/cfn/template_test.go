@@ -0,0 +1,91 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package cfn
+
+import (
+	"testing"
+
+	"gotest.tools/v3/assert"
+)
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		name    string
+		data    string
+		want    map[string]interface{}
+		wantErr string
+	}{
+		{
+			name: "yaml short form functions",
+			data: `
+Resources:
+  Rule:
+    Type: AWS::Events::Rule
+    Properties:
+      EventBusName: !Ref Bus
+      RoleArn: !GetAtt Role.Arn
+      Description: !Sub "${AWS::Region} rule"
+      ScheduleExpression: !Join ["", ["rate(", 5, " minutes)"]]
+      State: !If [Enabled, ENABLED, DISABLED]
+`,
+			want: map[string]interface{}{
+				"EventBusName":       map[string]interface{}{"Ref": "Bus"},
+				"RoleArn":            map[string]interface{}{"Fn::GetAtt": []interface{}{"Role", "Arn"}},
+				"Description":        map[string]interface{}{"Fn::Sub": "${AWS::Region} rule"},
+				"ScheduleExpression": map[string]interface{}{"Fn::Join": []interface{}{"", []interface{}{"rate(", 5, " minutes)"}}},
+				"State":              map[string]interface{}{"Fn::If": []interface{}{"Enabled", "ENABLED", "DISABLED"}},
+			},
+		},
+		{
+			name: "json",
+			data: `{
+  "Resources": {
+    "Rule": {
+      "Type": "AWS::Events::Rule",
+      "Properties": {
+        "EventBusName": {"Ref": "Bus"},
+        "RoleArn": {"Fn::GetAtt": ["Role", "Arn"]}
+      }
+    }
+  }
+}`,
+			want: map[string]interface{}{
+				"EventBusName": map[string]interface{}{"Ref": "Bus"},
+				"RoleArn":      map[string]interface{}{"Fn::GetAtt": []interface{}{"Role", "Arn"}},
+			},
+		},
+		{
+			name:    "no resources",
+			data:    `AWSTemplateFormatVersion: "2010-09-09"`,
+			wantErr: "missing Resources",
+		},
+		{
+			name:    "invalid",
+			data:    `Resources: [`,
+			wantErr: "parsing template",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tmpl, err := Parse([]byte(tt.data))
+			if tt.wantErr != "" {
+				assert.ErrorContains(t, err, tt.wantErr)
+				return
+			}
+			assert.NilError(t, err)
+			assert.Equal(t, tmpl.Resources["Rule"].Type, "AWS::Events::Rule")
+			assert.DeepEqual(t, tmpl.Resources["Rule"].Properties, tt.want)
+		})
+	}
+}
@@ -0,0 +1,93 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+// Package clients holds the clients the resource hooks use besides the
+// EventBridge client of their resource manager: the Kubernetes clients of the
+// controller manager, e.g. to read the resources of other ACK controllers,
+// and the clients of other AWS services. The generated resource managers
+// don't have these clients, so the resource manager factories are wrapped
+// with WrapManagerFactories, which passes the clients to the hooks in the
+// context of the reconciliations.
+package clients
+
+import (
+	"context"
+	"errors"
+	"sync"
+
+	ackv1alpha1 "github.com/aws-controllers-k8s/runtime/apis/core/v1alpha1"
+	rtclient "sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// Clients are the clients of a controller
+type Clients struct {
+	// Client reads and writes the resources of the controller, through the
+	// cache of the controller manager
+	Client rtclient.Client
+	// APIReader reads resources without the cache of the controller
+	// manager, e.g. the resources of other controllers, which aren't watched
+	APIReader rtclient.Reader
+	// aws are the clients of other AWS services, by service, account and
+	// Region
+	aws sync.Map
+}
+
+// New returns the clients of a controller with the given Kubernetes clients
+func New(client rtclient.Client, apiReader rtclient.Reader) *Clients {
+	return &Clients{Client: client, APIReader: apiReader}
+}
+
+// awsKey identifies the client of an AWS service for an account and Region
+type awsKey struct {
+	service   string
+	accountID ackv1alpha1.AWSAccountID
+	region    ackv1alpha1.AWSRegion
+}
+
+// AWS returns the client of the given AWS service for an account and Region.
+// The client is created with newClient once and reused by the resource
+// managers of the account and Region.
+func AWS[T any](
+	c *Clients,
+	service string,
+	accountID ackv1alpha1.AWSAccountID,
+	region ackv1alpha1.AWSRegion,
+	newClient func() T,
+) T {
+	key := awsKey{service: service, accountID: accountID, region: region}
+	if client, ok := c.aws.Load(key); ok {
+		return client.(T)
+	}
+	client, _ := c.aws.LoadOrStore(key, newClient())
+	return client.(T)
+}
+
+// errNoClients is returned when a context has no clients, e.g. when the
+// resource manager factories aren't wrapped with WrapManagerFactories
+var errNoClients = errors.New("no clients in the context of the reconciliation")
+
+type contextKey struct{}
+
+// NewContext returns a context with the given clients
+func NewContext(ctx context.Context, c *Clients) context.Context {
+	return context.WithValue(ctx, contextKey{}, c)
+}
+
+// FromContext returns the clients of a context, or an error when it has none
+func FromContext(ctx context.Context) (*Clients, error) {
+	c, ok := ctx.Value(contextKey{}).(*Clients)
+	if !ok || c == nil {
+		return nil, errNoClients
+	}
+	return c, nil
+}
@@ -0,0 +1,91 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package clients
+
+import (
+	"context"
+	"testing"
+
+	ackv1alpha1 "github.com/aws-controllers-k8s/runtime/apis/core/v1alpha1"
+	ackcfg "github.com/aws-controllers-k8s/runtime/pkg/config"
+	ackmetrics "github.com/aws-controllers-k8s/runtime/pkg/metrics"
+	acktypes "github.com/aws-controllers-k8s/runtime/pkg/types"
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/go-logr/logr"
+	"gotest.tools/v3/assert"
+)
+
+func TestAWS(t *testing.T) {
+	c := New(nil, nil)
+	var created int
+	newClient := func() *int {
+		created++
+		n := created
+		return &n
+	}
+
+	first := AWS(c, "logs", "111111111111", "us-west-2", newClient)
+	assert.Equal(t, AWS(c, "logs", "111111111111", "us-west-2", newClient), first)
+	assert.Assert(t, AWS(c, "logs", "222222222222", "us-west-2", newClient) != first)
+	assert.Assert(t, AWS(c, "logs", "111111111111", "eu-west-1", newClient) != first)
+	assert.Assert(t, AWS(c, "route53", "111111111111", "us-west-2", newClient) != first)
+	assert.Equal(t, created, 4)
+}
+
+func TestFromContext(t *testing.T) {
+	_, err := FromContext(context.Background())
+	assert.Equal(t, err, errNoClients)
+
+	c := New(nil, nil)
+	got, err := FromContext(NewContext(context.Background(), c))
+	assert.NilError(t, err)
+	assert.Equal(t, got, c)
+}
+
+// stubFactory produces stubManagers
+type stubFactory struct {
+	acktypes.AWSResourceManagerFactory
+	rm *stubManager
+}
+
+func (f *stubFactory) ManagerFor(
+	ackcfg.Config, aws.Config, logr.Logger, *ackmetrics.Metrics, acktypes.Reconciler,
+	ackv1alpha1.AWSAccountID, ackv1alpha1.AWSRegion, ackv1alpha1.AWSResourceName,
+) (acktypes.AWSResourceManager, error) {
+	return f.rm, nil
+}
+
+// stubManager records the clients in the context of its reads
+type stubManager struct {
+	acktypes.AWSResourceManager
+	clients *Clients
+}
+
+func (rm *stubManager) ReadOne(ctx context.Context, res acktypes.AWSResource) (acktypes.AWSResource, error) {
+	c, err := FromContext(ctx)
+	rm.clients = c
+	return res, err
+}
+
+func TestWrapManagerFactories(t *testing.T) {
+	c := New(nil, nil)
+	stub := &stubManager{}
+	fs := WrapManagerFactories([]acktypes.AWSResourceManagerFactory{&stubFactory{rm: stub}}, c)
+	rm, err := fs[0].ManagerFor(ackcfg.Config{}, aws.Config{}, logr.Discard(), nil, nil, "111111111111", "us-west-2", "")
+	assert.NilError(t, err)
+
+	_, err = rm.ReadOne(context.Background(), nil)
+	assert.NilError(t, err)
+	assert.Equal(t, stub.clients, c)
+}
@@ -0,0 +1,99 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package clients
+
+import (
+	"context"
+
+	ackv1alpha1 "github.com/aws-controllers-k8s/runtime/apis/core/v1alpha1"
+	ackcompare "github.com/aws-controllers-k8s/runtime/pkg/compare"
+	ackcfg "github.com/aws-controllers-k8s/runtime/pkg/config"
+	ackmetrics "github.com/aws-controllers-k8s/runtime/pkg/metrics"
+	acktypes "github.com/aws-controllers-k8s/runtime/pkg/types"
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/go-logr/logr"
+)
+
+// WrapManagerFactory returns a resource manager factory whose resource
+// managers pass the given clients to the hooks in the context of their
+// reads, creates, updates and deletes
+func WrapManagerFactory(f acktypes.AWSResourceManagerFactory, c *Clients) acktypes.AWSResourceManagerFactory {
+	return &managerFactory{f, c}
+}
+
+// WrapManagerFactories wraps each of the resource manager factories with
+// WrapManagerFactory
+func WrapManagerFactories(fs []acktypes.AWSResourceManagerFactory, c *Clients) []acktypes.AWSResourceManagerFactory {
+	res := make([]acktypes.AWSResourceManagerFactory, len(fs))
+	for i, f := range fs {
+		res[i] = WrapManagerFactory(f, c)
+	}
+	return res
+}
+
+type managerFactory struct {
+	acktypes.AWSResourceManagerFactory
+	clients *Clients
+}
+
+// ManagerFor implements acktypes.AWSResourceManagerFactory
+func (f *managerFactory) ManagerFor(
+	cfg ackcfg.Config,
+	clientcfg aws.Config,
+	log logr.Logger,
+	metrics *ackmetrics.Metrics,
+	rr acktypes.Reconciler,
+	id ackv1alpha1.AWSAccountID,
+	region ackv1alpha1.AWSRegion,
+	roleARN ackv1alpha1.AWSResourceName,
+) (acktypes.AWSResourceManager, error) {
+	rm, err := f.AWSResourceManagerFactory.ManagerFor(
+		cfg, clientcfg, log, metrics, rr, id, region, roleARN,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &manager{rm, f.clients}, nil
+}
+
+// manager passes the clients to the hooks of a resource manager
+type manager struct {
+	acktypes.AWSResourceManager
+	clients *Clients
+}
+
+// ReadOne implements acktypes.AWSResourceManager
+func (rm *manager) ReadOne(ctx context.Context, res acktypes.AWSResource) (acktypes.AWSResource, error) {
+	return rm.AWSResourceManager.ReadOne(NewContext(ctx, rm.clients), res)
+}
+
+// Create implements acktypes.AWSResourceManager
+func (rm *manager) Create(ctx context.Context, res acktypes.AWSResource) (acktypes.AWSResource, error) {
+	return rm.AWSResourceManager.Create(NewContext(ctx, rm.clients), res)
+}
+
+// Update implements acktypes.AWSResourceManager
+func (rm *manager) Update(
+	ctx context.Context,
+	desired acktypes.AWSResource,
+	latest acktypes.AWSResource,
+	delta *ackcompare.Delta,
+) (acktypes.AWSResource, error) {
+	return rm.AWSResourceManager.Update(NewContext(ctx, rm.clients), desired, latest, delta)
+}
+
+// Delete implements acktypes.AWSResourceManager
+func (rm *manager) Delete(ctx context.Context, res acktypes.AWSResource) (acktypes.AWSResource, error) {
+	return rm.AWSResourceManager.Delete(NewContext(ctx, rm.clients), res)
+}
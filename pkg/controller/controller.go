@@ -0,0 +1,52 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+// Package controller wires the resource managers of the controller with the
+// controller manager. The controller binary and the integration tests set up
+// the controller manager with Setup, so they run the same resource managers.
+package controller
+
+import (
+	"fmt"
+
+	acktypes "github.com/aws-controllers-k8s/runtime/pkg/types"
+	ctrlrtmanager "sigs.k8s.io/controller-runtime/pkg/manager"
+
+	"github.com/aws-controllers-k8s/eventbridge-controller/pkg/clients"
+	"github.com/aws-controllers-k8s/eventbridge-controller/pkg/events"
+	"github.com/aws-controllers-k8s/eventbridge-controller/pkg/lifecycle"
+	"github.com/aws-controllers-k8s/eventbridge-controller/pkg/ratelimit"
+	svcresource "github.com/aws-controllers-k8s/eventbridge-controller/pkg/resource"
+
+	_ "github.com/aws-controllers-k8s/eventbridge-controller/pkg/resource/archive"
+	_ "github.com/aws-controllers-k8s/eventbridge-controller/pkg/resource/endpoint"
+	_ "github.com/aws-controllers-k8s/eventbridge-controller/pkg/resource/event_bus"
+	_ "github.com/aws-controllers-k8s/eventbridge-controller/pkg/resource/rule"
+)
+
+// Setup sets up the controller manager for the resource managers and returns
+// the resource manager factories of the service controller. Kubernetes
+// Events are recorded with the recorder of the controller manager, and the
+// lifecycle events are published by a runnable of the controller manager.
+// The resource managers rate limit their API calls and pass the clients of
+// the controller manager to their hooks.
+func Setup(mgr ctrlrtmanager.Manager) ([]acktypes.AWSResourceManagerFactory, error) {
+	events.SetRecorder(mgr.GetEventRecorderFor(events.Component))
+	if err := mgr.Add(ctrlrtmanager.RunnableFunc(lifecycle.Start)); err != nil {
+		return nil, fmt.Errorf("adding the lifecycle events publisher: %w", err)
+	}
+	return ratelimit.WrapManagerFactories(clients.WrapManagerFactories(
+		svcresource.GetManagerFactories(),
+		clients.New(mgr.GetClient(), mgr.GetAPIReader()),
+	)), nil
+}
@@ -0,0 +1,360 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+// Package discovery finds the existing event buses, rules, archives and
+// endpoints of an account and region and turns them into custom resources
+// which are adopted by the controller when applied.
+//
+// Every resource is read through the ReadOne method of the controller's
+// resource managers, so the custom resources carry exactly the state the
+// controller observes, including the tags and targets of rules.
+package discovery
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	ackv1alpha1 "github.com/aws-controllers-k8s/runtime/apis/core/v1alpha1"
+	ackcfg "github.com/aws-controllers-k8s/runtime/pkg/config"
+	ackmetrics "github.com/aws-controllers-k8s/runtime/pkg/metrics"
+	acktypes "github.com/aws-controllers-k8s/runtime/pkg/types"
+	"github.com/aws/aws-sdk-go-v2/aws"
+	svcsdk "github.com/aws/aws-sdk-go-v2/service/eventbridge"
+	svcsdktypes "github.com/aws/aws-sdk-go-v2/service/eventbridge/types"
+	"github.com/go-logr/logr"
+	rtclient "sigs.k8s.io/controller-runtime/pkg/client"
+
+	svcapitypes "github.com/aws-controllers-k8s/eventbridge-controller/apis/v1alpha1"
+	"github.com/aws-controllers-k8s/eventbridge-controller/pkg/arns"
+	svcresource "github.com/aws-controllers-k8s/eventbridge-controller/pkg/resource"
+	pkgtags "github.com/aws-controllers-k8s/eventbridge-controller/pkg/tags"
+
+	_ "github.com/aws-controllers-k8s/eventbridge-controller/pkg/resource/archive"
+	_ "github.com/aws-controllers-k8s/eventbridge-controller/pkg/resource/endpoint"
+	_ "github.com/aws-controllers-k8s/eventbridge-controller/pkg/resource/event_bus"
+	_ "github.com/aws-controllers-k8s/eventbridge-controller/pkg/resource/rule"
+)
+
+const defaultEventBusName = "default"
+
+// Options configure the discovery
+type Options struct {
+	// Namespace is the namespace of the discovered custom resources
+	Namespace string
+	// AccountID is the AWS account the resources are discovered in. It is
+	// only used to construct identifiers and may be empty.
+	AccountID string
+	// Log receives the log messages of the resource managers
+	Log logr.Logger
+	// EventBusPrefix limits the discovery to the event buses whose name
+	// starts with the prefix and to their rules and archives. Endpoints are
+	// discovered if one of their event buses matches.
+	EventBusPrefix string
+	// RuleTags limits the discovered rules to the rules with all of the tags
+	RuleTags map[string]string
+}
+
+// Inventory contains the discovered resources as custom resources with
+// references between them wired up
+type Inventory struct {
+	EventBuses []*svcapitypes.EventBus
+	Rules      []*svcapitypes.Rule
+	Archives   []*svcapitypes.Archive
+	Endpoints  []*svcapitypes.Endpoint
+}
+
+// Objects returns all custom resources of the inventory, event buses first
+// so that they exist before the resources referencing them
+func (inv *Inventory) Objects() []rtclient.Object {
+	var objs []rtclient.Object
+	for _, o := range inv.EventBuses {
+		objs = append(objs, o)
+	}
+	for _, o := range inv.Archives {
+		objs = append(objs, o)
+	}
+	for _, o := range inv.Endpoints {
+		objs = append(objs, o)
+	}
+	for _, o := range inv.Rules {
+		objs = append(objs, o)
+	}
+	return objs
+}
+
+// discoverer lists resources with the EventBridge API and reads them with the
+// resource managers
+type discoverer struct {
+	opts     Options
+	client   *svcsdk.Client
+	managers map[string]acktypes.AWSResourceManager
+	names    *objectNames
+	// busesByARN are the discovered custom event buses, archives of these
+	// buses reference their custom resource
+	busesByARN map[string]*svcapitypes.EventBus
+}
+
+// Discover lists the event buses, the rules and targets of every bus, the
+// archives and the endpoints in the account and region of cfg. The default
+// event bus and rules managed by other AWS services are skipped.
+func Discover(ctx context.Context, cfg aws.Config, opts Options) (*Inventory, error) {
+	if opts.Log.GetSink() == nil {
+		opts.Log = logr.Discard()
+	}
+	d := &discoverer{
+		opts:       opts,
+		client:     svcsdk.NewFromConfig(cfg),
+		managers:   make(map[string]acktypes.AWSResourceManager),
+		names:      newObjectNames(),
+		busesByARN: make(map[string]*svcapitypes.EventBus),
+	}
+
+	metrics := ackmetrics.NewMetrics("eventbridge")
+	for _, f := range svcresource.GetManagerFactories() {
+		rm, err := f.ManagerFor(
+			ackcfg.Config{AccountID: opts.AccountID, Region: cfg.Region},
+			cfg, opts.Log, metrics, nil,
+			ackv1alpha1.AWSAccountID(opts.AccountID), ackv1alpha1.AWSRegion(cfg.Region),
+			"",
+		)
+		if err != nil {
+			return nil, fmt.Errorf("creating %s resource manager: %w", f.ResourceDescriptor().GroupVersionKind().Kind, err)
+		}
+		d.managers[f.ResourceDescriptor().GroupVersionKind().Kind] = rm
+	}
+
+	inv := &Inventory{}
+	if err := d.discoverEventBuses(ctx, inv); err != nil {
+		return nil, err
+	}
+	if err := d.discoverArchives(ctx, inv); err != nil {
+		return nil, err
+	}
+	if err := d.discoverEndpoints(ctx, inv); err != nil {
+		return nil, err
+	}
+	inv.sort()
+	return inv, nil
+}
+
+// readOne reads the resource identified by obj with the resource manager of
+// its kind
+func (d *discoverer) readOne(ctx context.Context, kind string, obj rtclient.Object) (rtclient.Object, error) {
+	rm := d.managers[kind]
+	f := managerFactory(kind)
+	res, err := rm.ReadOne(ctx, f.ResourceDescriptor().ResourceFromRuntimeObject(obj))
+	if err != nil {
+		return nil, fmt.Errorf("reading %s %s: %w", kind, obj.GetName(), err)
+	}
+	return res.RuntimeObject(), nil
+}
+
+func (d *discoverer) discoverEventBuses(ctx context.Context, inv *Inventory) error {
+	var nextToken *string
+	for {
+		resp, err := d.client.ListEventBuses(ctx, &svcsdk.ListEventBusesInput{NextToken: nextToken})
+		if err != nil {
+			return fmt.Errorf("listing event buses: %w", err)
+		}
+		for _, b := range resp.EventBuses {
+			name := aws.ToString(b.Name)
+			if !d.matchesEventBus(name) {
+				continue
+			}
+			var bus *svcapitypes.EventBus
+			if name != defaultEventBusName {
+				obj, err := d.readOne(ctx, "EventBus", &svcapitypes.EventBus{
+					Spec: svcapitypes.EventBusSpec{Name: b.Name},
+				})
+				if err != nil {
+					return err
+				}
+				bus = obj.(*svcapitypes.EventBus)
+				bus.ObjectMeta = d.objectMeta("EventBus", name)
+				bus.TypeMeta = typeMeta("EventBus")
+				bus.Spec.Tags = pkgtags.WithoutSystemTags(bus.Spec.Tags)
+				bus.Status = svcapitypes.EventBusStatus{}
+				d.busesByARN[aws.ToString(b.Arn)] = bus
+				inv.EventBuses = append(inv.EventBuses, bus)
+			}
+			if err := d.discoverRules(ctx, inv, name, bus); err != nil {
+				return err
+			}
+		}
+		if nextToken = resp.NextToken; nextToken == nil {
+			return nil
+		}
+	}
+}
+
+// discoverRules discovers the rules of the event bus with the given name.
+// Rules on a custom event bus reference its custom resource bus.
+func (d *discoverer) discoverRules(
+	ctx context.Context,
+	inv *Inventory,
+	busName string,
+	bus *svcapitypes.EventBus,
+) error {
+	var nextToken *string
+	for {
+		resp, err := d.client.ListRules(ctx, &svcsdk.ListRulesInput{
+			EventBusName: aws.String(busName),
+			NextToken:    nextToken,
+		})
+		if err != nil {
+			return fmt.Errorf("listing rules of event bus %s: %w", busName, err)
+		}
+		for _, r := range resp.Rules {
+			// managed rules can only be modified by the service that created
+			// them
+			if r.ManagedBy != nil {
+				continue
+			}
+			obj, err := d.readOne(ctx, "Rule", &svcapitypes.Rule{
+				Spec: svcapitypes.RuleSpec{Name: r.Name, EventBusName: aws.String(busName)},
+			})
+			if err != nil {
+				return err
+			}
+			rule := obj.(*svcapitypes.Rule)
+			if !hasTags(rule.Spec.Tags, d.opts.RuleTags) {
+				continue
+			}
+			name := aws.ToString(r.Name)
+			objName := name
+			if bus != nil {
+				objName = busName + "-" + name
+			}
+			rule.ObjectMeta = d.objectMeta("Rule", objName)
+			rule.TypeMeta = typeMeta("Rule")
+			rule.Spec.Tags = pkgtags.WithoutSystemTags(rule.Spec.Tags)
+			if bus != nil {
+				rule.Spec.EventBusName = nil
+				rule.Spec.EventBusRef = reference(bus.Name)
+			}
+			rule.Status = svcapitypes.RuleStatus{}
+			inv.Rules = append(inv.Rules, rule)
+		}
+		if nextToken = resp.NextToken; nextToken == nil {
+			return nil
+		}
+	}
+}
+
+func (d *discoverer) discoverArchives(ctx context.Context, inv *Inventory) error {
+	var nextToken *string
+	for {
+		resp, err := d.client.ListArchives(ctx, &svcsdk.ListArchivesInput{NextToken: nextToken})
+		if err != nil {
+			return fmt.Errorf("listing archives: %w", err)
+		}
+		for _, a := range resp.Archives {
+			if !d.matchesEventBus(eventBusName(aws.ToString(a.EventSourceArn))) {
+				continue
+			}
+			obj, err := d.readOne(ctx, "Archive", &svcapitypes.Archive{
+				Spec: svcapitypes.ArchiveSpec{Name: a.ArchiveName},
+			})
+			if err != nil {
+				return err
+			}
+			archive := obj.(*svcapitypes.Archive)
+			name := aws.ToString(a.ArchiveName)
+			archive.ObjectMeta = d.objectMeta("Archive", name)
+			archive.TypeMeta = typeMeta("Archive")
+			if bus, ok := d.busesByARN[aws.ToString(archive.Spec.EventSourceARN)]; ok {
+				archive.Spec.EventSourceARN = nil
+				archive.Spec.EventSourceRef = reference(bus.Name)
+			}
+			archive.Status = svcapitypes.ArchiveStatus{}
+			inv.Archives = append(inv.Archives, archive)
+		}
+		if nextToken = resp.NextToken; nextToken == nil {
+			return nil
+		}
+	}
+}
+
+func (d *discoverer) discoverEndpoints(ctx context.Context, inv *Inventory) error {
+	var nextToken *string
+	for {
+		resp, err := d.client.ListEndpoints(ctx, &svcsdk.ListEndpointsInput{NextToken: nextToken})
+		if err != nil {
+			return fmt.Errorf("listing endpoints: %w", err)
+		}
+		for _, e := range resp.Endpoints {
+			if !d.matchesAnyEventBus(e.EventBuses) {
+				continue
+			}
+			obj, err := d.readOne(ctx, "Endpoint", &svcapitypes.Endpoint{
+				Spec: svcapitypes.EndpointSpec{Name: e.Name},
+			})
+			if err != nil {
+				return err
+			}
+			endpoint := obj.(*svcapitypes.Endpoint)
+			name := aws.ToString(e.Name)
+			endpoint.ObjectMeta = d.objectMeta("Endpoint", name)
+			endpoint.TypeMeta = typeMeta("Endpoint")
+			endpoint.Status = svcapitypes.EndpointStatus{}
+			inv.Endpoints = append(inv.Endpoints, endpoint)
+		}
+		if nextToken = resp.NextToken; nextToken == nil {
+			return nil
+		}
+	}
+}
+
+// matchesEventBus returns true if the event bus with the given name matches
+// the EventBusPrefix filter
+func (d *discoverer) matchesEventBus(name string) bool {
+	return strings.HasPrefix(name, d.opts.EventBusPrefix)
+}
+
+// matchesAnyEventBus returns true if one of the endpoint event buses matches
+// the EventBusPrefix filter
+func (d *discoverer) matchesAnyEventBus(buses []svcsdktypes.EndpointEventBus) bool {
+	for _, b := range buses {
+		if d.matchesEventBus(eventBusName(aws.ToString(b.EventBusArn))) {
+			return true
+		}
+	}
+	return false
+}
+
+// eventBusName returns the name of the event bus with the given ARN
+func eventBusName(arn string) string {
+	parsed, err := arns.Parse(arn)
+	if err != nil || parsed.Kind != arns.KindEventBus {
+		return ""
+	}
+	return parsed.Name
+}
+
+// hasTags returns true if tags contain all of the wanted tags
+func hasTags(tags []*svcapitypes.Tag, wanted map[string]string) bool {
+	for k, v := range wanted {
+		found := false
+		for _, t := range tags {
+			if aws.ToString(t.Key) == k && aws.ToString(t.Value) == v {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,175 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package discovery
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	ackv1alpha1 "github.com/aws-controllers-k8s/runtime/apis/core/v1alpha1"
+	"github.com/aws/aws-sdk-go-v2/aws"
+	svcsdk "github.com/aws/aws-sdk-go-v2/service/eventbridge"
+	svcsdktypes "github.com/aws/aws-sdk-go-v2/service/eventbridge/types"
+	"gotest.tools/v3/assert"
+	"k8s.io/apimachinery/pkg/runtime"
+	ctrlrtfake "sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/yaml"
+
+	svcapitypes "github.com/aws-controllers-k8s/eventbridge-controller/apis/v1alpha1"
+	"github.com/aws-controllers-k8s/eventbridge-controller/pkg/testutil"
+)
+
+// seed creates a custom event bus with a rule, target and archive, a rule on
+// the default event bus and an endpoint
+func seed(t *testing.T, fake *testutil.EventBridge) {
+	t.Helper()
+	ctx := context.Background()
+	client := svcsdk.NewFromConfig(fake.Config())
+
+	bus, err := client.CreateEventBus(ctx, &svcsdk.CreateEventBusInput{
+		Name: aws.String("orders"),
+		Tags: []svcsdktypes.Tag{{Key: aws.String("team"), Value: aws.String("a")}},
+	})
+	assert.NilError(t, err)
+	fake.SetTags(aws.ToString(bus.EventBusArn), map[string]string{
+		"team":                          "a",
+		"aws:cloudformation:stack-name": "orders-stack",
+	})
+
+	_, err = client.PutRule(ctx, &svcsdk.PutRuleInput{
+		Name:         aws.String("created"),
+		EventBusName: aws.String("orders"),
+		EventPattern: aws.String(`{"detail-type":["OrderCreated"]}`),
+	})
+	assert.NilError(t, err)
+	_, err = client.PutTargets(ctx, &svcsdk.PutTargetsInput{
+		Rule:         aws.String("created"),
+		EventBusName: aws.String("orders"),
+		Targets: []svcsdktypes.Target{{
+			Id:  aws.String("queue"),
+			Arn: aws.String("arn:aws:sqs:us-west-2:123456789012:orders"),
+		}},
+	})
+	assert.NilError(t, err)
+
+	_, err = client.PutRule(ctx, &svcsdk.PutRuleInput{
+		Name:               aws.String("Nightly_Report"),
+		ScheduleExpression: aws.String("rate(1 day)"),
+	})
+	assert.NilError(t, err)
+
+	_, err = client.CreateArchive(ctx, &svcsdk.CreateArchiveInput{
+		ArchiveName:    aws.String("orders-archive"),
+		EventSourceArn: bus.EventBusArn,
+	})
+	assert.NilError(t, err)
+
+	_, err = client.CreateEndpoint(ctx, &svcsdk.CreateEndpointInput{
+		Name: aws.String("orders-endpoint"),
+		EventBuses: []svcsdktypes.EndpointEventBus{
+			{EventBusArn: bus.EventBusArn},
+			{EventBusArn: aws.String("arn:aws:events:us-east-1:123456789012:event-bus/orders")},
+		},
+		RoutingConfig: &svcsdktypes.RoutingConfig{
+			FailoverConfig: &svcsdktypes.FailoverConfig{
+				Primary:   &svcsdktypes.Primary{HealthCheck: aws.String("arn:aws:route53:::healthcheck/orders")},
+				Secondary: &svcsdktypes.Secondary{Route: aws.String("us-east-1")},
+			},
+		},
+	})
+	assert.NilError(t, err)
+}
+
+func TestDiscover(t *testing.T) {
+	fake := testutil.NewEventBridge(testutil.WithTransitionReads(0))
+	seed(t, fake)
+
+	inv, err := Discover(context.Background(), fake.Config(), Options{
+		Namespace: "migration",
+		AccountID: testutil.DefaultAccountID,
+	})
+	assert.NilError(t, err)
+
+	assert.Equal(t, len(inv.EventBuses), 1)
+	bus := inv.EventBuses[0]
+	assert.Equal(t, bus.Name, "orders")
+	assert.Equal(t, bus.Namespace, "migration")
+	assert.Equal(t, bus.Kind, "EventBus")
+	assert.Equal(t, bus.Annotations[ackv1alpha1.AnnotationAdoptionPolicy], "adopt-or-create")
+	assert.DeepEqual(t, bus.Spec.Tags, []*svcapitypes.Tag{
+		{Key: aws.String("team"), Value: aws.String("a")},
+	})
+
+	assert.Equal(t, len(inv.Rules), 2)
+	report, created := inv.Rules[0], inv.Rules[1]
+	assert.Equal(t, report.Name, "nightly-report")
+	assert.Equal(t, aws.ToString(report.Spec.Name), "Nightly_Report")
+	assert.Equal(t, aws.ToString(report.Spec.EventBusName), "default")
+	assert.Assert(t, report.Spec.EventBusRef == nil)
+
+	assert.Equal(t, created.Name, "orders-created")
+	assert.Assert(t, created.Spec.EventBusName == nil)
+	assert.Equal(t, aws.ToString(created.Spec.EventBusRef.From.Name), "orders")
+	assert.Equal(t, len(created.Spec.Targets), 1)
+	assert.Equal(t, aws.ToString(created.Spec.Targets[0].ID), "queue")
+	assert.Assert(t, created.Status.ACKResourceMetadata == nil)
+
+	assert.Equal(t, len(inv.Archives), 1)
+	assert.Assert(t, inv.Archives[0].Spec.EventSourceARN == nil)
+	assert.Equal(t, aws.ToString(inv.Archives[0].Spec.EventSourceRef.From.Name), "orders")
+
+	assert.Equal(t, len(inv.Endpoints), 1)
+	assert.Equal(t, len(inv.Endpoints[0].Spec.EventBuses), 2)
+}
+
+func TestWriteManifests(t *testing.T) {
+	fake := testutil.NewEventBridge(testutil.WithTransitionReads(0))
+	seed(t, fake)
+	inv, err := Discover(context.Background(), fake.Config(), Options{})
+	assert.NilError(t, err)
+
+	var buf bytes.Buffer
+	assert.NilError(t, WriteManifests(&buf, inv.Objects()))
+
+	docs := bytes.Split(buf.Bytes(), []byte("---\n"))
+	assert.Equal(t, len(docs), 5)
+	var rule map[string]interface{}
+	assert.NilError(t, yaml.Unmarshal(docs[4], &rule))
+	assert.Equal(t, rule["kind"], "Rule")
+	_, hasStatus := rule["status"]
+	assert.Assert(t, !hasStatus)
+	assert.Assert(t, !bytes.Contains(buf.Bytes(), []byte("creationTimestamp")))
+}
+
+func TestAdopt(t *testing.T) {
+	fake := testutil.NewEventBridge(testutil.WithTransitionReads(0))
+	seed(t, fake)
+	inv, err := Discover(context.Background(), fake.Config(), Options{Namespace: "default"})
+	assert.NilError(t, err)
+
+	scheme := runtime.NewScheme()
+	assert.NilError(t, svcapitypes.AddToScheme(scheme))
+	k8sClient := ctrlrtfake.NewClientBuilder().WithScheme(scheme).WithObjects(
+		// already adopted resources are skipped
+		inv.EventBuses[0].DeepCopy(),
+	).Build()
+
+	ctx := context.Background()
+	assert.NilError(t, Adopt(ctx, k8sClient, inv.Objects()))
+
+	var rules svcapitypes.RuleList
+	assert.NilError(t, k8sClient.List(ctx, &rules))
+	assert.Equal(t, len(rules.Items), 2)
+}
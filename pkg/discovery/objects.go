@@ -0,0 +1,127 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package discovery
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	ackv1alpha1 "github.com/aws-controllers-k8s/runtime/apis/core/v1alpha1"
+	ackrt "github.com/aws-controllers-k8s/runtime/pkg/runtime"
+	acktypes "github.com/aws-controllers-k8s/runtime/pkg/types"
+	"github.com/aws/aws-sdk-go-v2/aws"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/validation"
+
+	svcapitypes "github.com/aws-controllers-k8s/eventbridge-controller/apis/v1alpha1"
+	svcresource "github.com/aws-controllers-k8s/eventbridge-controller/pkg/resource"
+)
+
+// managerFactory returns the registered resource manager factory of the given
+// kind
+func managerFactory(kind string) acktypes.AWSResourceManagerFactory {
+	for _, f := range svcresource.GetManagerFactories() {
+		if f.ResourceDescriptor().GroupVersionKind().Kind == kind {
+			return f
+		}
+	}
+	panic(fmt.Sprintf("no resource manager factory registered for %s", kind))
+}
+
+// typeMeta returns the TypeMeta of the custom resource kind
+func typeMeta(kind string) metav1.TypeMeta {
+	return metav1.TypeMeta{
+		APIVersion: svcapitypes.GroupVersion.String(),
+		Kind:       kind,
+	}
+}
+
+// objectMeta returns the ObjectMeta of a discovered resource. The resources
+// are adopted with the adopt-or-create policy, so the controller takes the
+// spec of the custom resource, including references, as the desired state.
+func (d *discoverer) objectMeta(kind, name string) metav1.ObjectMeta {
+	return metav1.ObjectMeta{
+		Name:      d.names.next(kind, name),
+		Namespace: d.opts.Namespace,
+		Annotations: map[string]string{
+			ackv1alpha1.AnnotationAdoptionPolicy: string(ackrt.AdoptionPolicy_AdoptOrCreate),
+		},
+	}
+}
+
+// reference returns a reference to the custom resource with the given name in
+// the same namespace
+func reference(name string) *ackv1alpha1.AWSResourceReferenceWrapper {
+	return &ackv1alpha1.AWSResourceReferenceWrapper{
+		From: &ackv1alpha1.AWSResourceReference{Name: aws.String(name)},
+	}
+}
+
+// objectNames hands out unique Kubernetes object names per kind
+type objectNames struct {
+	used map[string]bool
+}
+
+func newObjectNames() *objectNames {
+	return &objectNames{used: make(map[string]bool)}
+}
+
+// next returns a valid object name for the AWS resource name, with a numeric
+// suffix if the name is already used by another resource of the same kind
+func (n *objectNames) next(kind, name string) string {
+	base := objectName(name)
+	candidate := base
+	for i := 2; n.used[kind+"/"+candidate]; i++ {
+		suffix := fmt.Sprintf("-%d", i)
+		candidate = strings.TrimRight(truncate(base, validation.DNS1123SubdomainMaxLength-len(suffix)), "-.") + suffix
+	}
+	n.used[kind+"/"+candidate] = true
+	return candidate
+}
+
+// objectName converts an AWS resource name, which may contain upper case
+// letters and characters like '_', to a DNS subdomain name
+func objectName(name string) string {
+	var b strings.Builder
+	for _, r := range strings.ToLower(name) {
+		switch {
+		case r >= 'a' && r <= 'z', r >= '0' && r <= '9', r == '-', r == '.':
+			b.WriteRune(r)
+		default:
+			b.WriteRune('-')
+		}
+	}
+	res := strings.Trim(truncate(b.String(), validation.DNS1123SubdomainMaxLength), "-.")
+	if res == "" {
+		return "unnamed"
+	}
+	return res
+}
+
+func truncate(s string, n int) string {
+	if len(s) > n {
+		return s[:n]
+	}
+	return s
+}
+
+// sort orders the resources of every kind by object name so that the output
+// is deterministic
+func (inv *Inventory) sort() {
+	sort.Slice(inv.EventBuses, func(i, j int) bool { return inv.EventBuses[i].Name < inv.EventBuses[j].Name })
+	sort.Slice(inv.Rules, func(i, j int) bool { return inv.Rules[i].Name < inv.Rules[j].Name })
+	sort.Slice(inv.Archives, func(i, j int) bool { return inv.Archives[i].Name < inv.Archives[j].Name })
+	sort.Slice(inv.Endpoints, func(i, j int) bool { return inv.Endpoints[i].Name < inv.Endpoints[j].Name })
+}
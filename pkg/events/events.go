@@ -0,0 +1,52 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+// Package events records Kubernetes Events for the resources managed by the
+// controller. The ACK runtime doesn't expose an event recorder to resource
+// managers, so the controller sets the recorder of its controller manager with
+// SetRecorder.
+package events
+
+import (
+	"sync"
+
+	"k8s.io/client-go/tools/record"
+)
+
+// Component is the source component of the recorded events
+const Component = "eventbridge-controller"
+
+var (
+	mu       sync.Mutex
+	recorder record.EventRecorder = &record.FakeRecorder{}
+)
+
+// Recorder returns the EventRecorder for the resources of this controller.
+// Events are discarded until a recorder is set with SetRecorder.
+func Recorder() record.EventRecorder {
+	mu.Lock()
+	defer mu.Unlock()
+	return recorder
+}
+
+// SetRecorder replaces the EventRecorder returned by Recorder, e.g. with the
+// recorder of the controller manager, or a record.FakeRecorder in tests. A
+// nil recorder discards the events.
+func SetRecorder(r record.EventRecorder) {
+	mu.Lock()
+	defer mu.Unlock()
+	if r == nil {
+		r = &record.FakeRecorder{}
+	}
+	recorder = r
+}
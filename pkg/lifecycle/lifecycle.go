@@ -0,0 +1,369 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+// Package lifecycle publishes the outcomes of reconciliations to an
+// EventBridge event bus, so that they can be routed like any other
+// operational event. It is disabled unless the controller is started with
+// the --lifecycle-events-bus flag.
+//
+// Events have the source "ack.eventbridge" and the detail type
+// "ACK Resource <Outcome>", e.g. "ACK Resource TerminalError". The detail is
+// a Detail:
+//
+//	{
+//	  "kind": "Rule",
+//	  "namespace": "orders",
+//	  "name": "order-created",
+//	  "arn": "arn:aws:events:us-west-2:111122223333:rule/orders/order-created",
+//	  "generation": 3,
+//	  "outcome": "TerminalError",
+//	  "message": "invalid Spec: ..."
+//	}
+//
+// Events are sent in batches with PutEvents, using the credentials and Region
+// of the controller, by the runnable Start, which the controller manager
+// runs.
+package lifecycle
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	svcsdk "github.com/aws/aws-sdk-go-v2/service/eventbridge"
+	svcsdktypes "github.com/aws/aws-sdk-go-v2/service/eventbridge/types"
+	"github.com/go-logr/logr"
+	flag "github.com/spf13/pflag"
+	ctrlrt "sigs.k8s.io/controller-runtime"
+)
+
+const (
+	flagEventBus      = "lifecycle-events-bus"
+	flagDetailTypes   = "lifecycle-events-detail-types"
+	flagBatchSize     = "lifecycle-events-batch-size"
+	flagFlushInterval = "lifecycle-events-flush-interval"
+
+	// Source is the source of the published events
+	Source = "ack.eventbridge"
+	// detailTypePrefix prefixes the outcome in the detail type of the events
+	detailTypePrefix = "ACK Resource "
+
+	// maxBatchSize is the maximum number of entries of a PutEvents call
+	maxBatchSize = 10
+	// flushTimeout bounds the flushes which don't run on the flush interval
+	flushTimeout = 10 * time.Second
+)
+
+// Outcome is the outcome of a reconciliation
+type Outcome string
+
+const (
+	OutcomeCreated        Outcome = "Created"
+	OutcomeUpdated        Outcome = "Updated"
+	OutcomeDriftCorrected Outcome = "DriftCorrected"
+	OutcomeTerminalError  Outcome = "TerminalError"
+	OutcomeDeleted        Outcome = "Deleted"
+)
+
+var outcomes = []Outcome{
+	OutcomeCreated, OutcomeUpdated, OutcomeDriftCorrected, OutcomeTerminalError, OutcomeDeleted,
+}
+
+// Operation is the operation of the resource manager which was reconciled
+type Operation int
+
+const (
+	OperationCreate Operation = iota
+	OperationUpdate
+	OperationDelete
+)
+
+// Resource identifies the reconciled resource
+type Resource struct {
+	Kind       string
+	Namespace  string
+	Name       string
+	ARN        string
+	Generation int64
+	// ObservedGeneration is the generation which was last created or updated
+	// before the operation, zero if unknown. Updates of the observed
+	// generation are drift corrections.
+	ObservedGeneration int64
+}
+
+// Detail is the detail of the published events
+type Detail struct {
+	Kind       string  `json:"kind"`
+	Namespace  string  `json:"namespace"`
+	Name       string  `json:"name"`
+	ARN        string  `json:"arn,omitempty"`
+	Generation int64   `json:"generation"`
+	Outcome    Outcome `json:"outcome"`
+	Message    string  `json:"message,omitempty"`
+}
+
+// config is set by the controller flags
+type config struct {
+	eventBus      string
+	detailTypes   []string
+	batchSize     int
+	flushInterval time.Duration
+}
+
+// defaultFlushInterval is the default of the flush interval flag
+const defaultFlushInterval = 5 * time.Second
+
+var cfg = config{batchSize: maxBatchSize, flushInterval: defaultFlushInterval}
+
+func init() {
+	flag.StringVar(
+		&cfg.eventBus, flagEventBus, "",
+		"The name or ARN of the event bus the outcomes of reconciliations are published to. "+
+			"Requires the events:PutEvents permission.",
+	)
+	flag.StringSliceVar(
+		&cfg.detailTypes, flagDetailTypes, nil,
+		"The outcomes which are published, any of Created, Updated, DriftCorrected, TerminalError and Deleted. "+
+			"Defaults to all.",
+	)
+	flag.IntVar(
+		&cfg.batchSize, flagBatchSize, maxBatchSize,
+		"The number of events sent with each PutEvents call, between 1 and 10.",
+	)
+	flag.DurationVar(
+		&cfg.flushInterval, flagFlushInterval, cfg.flushInterval,
+		"The maximum time an event waits for its batch to fill before it is sent.",
+	)
+}
+
+// putEventsAPI is the EventBridge API used to publish events
+type putEventsAPI interface {
+	PutEvents(
+		ctx context.Context,
+		input *svcsdk.PutEventsInput,
+		opts ...func(*svcsdk.Options),
+	) (*svcsdk.PutEventsOutput, error)
+}
+
+var newPutEventsAPI = func(cfg aws.Config) putEventsAPI {
+	return svcsdk.NewFromConfig(cfg)
+}
+
+var (
+	mu     sync.Mutex
+	global *publisher
+)
+
+// defaultPublisher returns the publisher of Record and Start
+func defaultPublisher() *publisher {
+	mu.Lock()
+	defer mu.Unlock()
+	if global == nil {
+		global = newPublisher(cfg, nil, ctrlrt.Log.WithName("lifecycle-events"))
+	}
+	return global
+}
+
+// Record publishes the outcome of an operation of a resource manager on the
+// resource. err is the terminal error the operation failed with, or nil if
+// it succeeded; errors which are retried have no outcome and aren't
+// recorded. The client configuration of the first resource manager recording
+// an outcome is used to publish the events.
+func Record(ctx context.Context, clientcfg aws.Config, r Resource, op Operation, err error) {
+	if cfg.eventBus == "" {
+		return
+	}
+	p := defaultPublisher()
+	p.setClient(clientcfg)
+	p.record(ctx, r, op, err)
+}
+
+// Start sends the recorded events every flush interval until the context is
+// done, then sends the pending events. It is added to the controller manager
+// as a runnable, so that the events aren't lost when the controller shuts
+// down.
+func Start(ctx context.Context) error {
+	if cfg.eventBus == "" {
+		return nil
+	}
+	defaultPublisher().run(ctx)
+	return nil
+}
+
+// publisher batches the events and sends them with PutEvents
+type publisher struct {
+	cfg config
+	log logr.Logger
+
+	mu      sync.Mutex
+	client  putEventsAPI
+	enabled map[Outcome]bool
+	entries []svcsdktypes.PutEventsRequestEntry
+	// stopped is set once run returned, after which the events are sent as
+	// they are recorded
+	stopped bool
+}
+
+func newPublisher(cfg config, client putEventsAPI, log logr.Logger) *publisher {
+	if cfg.batchSize < 1 || cfg.batchSize > maxBatchSize {
+		log.Info("invalid batch size, using the maximum", "batchSize", cfg.batchSize, "maximum", maxBatchSize)
+		cfg.batchSize = maxBatchSize
+	}
+	if cfg.flushInterval <= 0 {
+		log.Info("invalid flush interval, using the default", "flushInterval", cfg.flushInterval)
+		cfg.flushInterval = defaultFlushInterval
+	}
+	enabled := make(map[Outcome]bool)
+	for _, o := range outcomes {
+		enabled[o] = len(cfg.detailTypes) == 0
+	}
+	for _, t := range cfg.detailTypes {
+		enabled[Outcome(strings.TrimPrefix(strings.TrimSpace(t), detailTypePrefix))] = true
+	}
+	return &publisher{
+		cfg:     cfg,
+		client:  client,
+		log:     log,
+		enabled: enabled,
+	}
+}
+
+// setClient sets the client the events are sent with, unless it is set
+func (p *publisher) setClient(clientcfg aws.Config) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.client == nil {
+		p.client = newPutEventsAPI(clientcfg)
+	}
+}
+
+// run sends the pending events every flush interval until the context is
+// done, and then once more
+func (p *publisher) run(ctx context.Context) {
+	ticker := time.NewTicker(p.cfg.flushInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			p.mu.Lock()
+			p.stopped = true
+			p.mu.Unlock()
+			p.flushWithTimeout(ctx)
+			return
+		case <-ticker.C:
+			p.flush(ctx)
+		}
+	}
+}
+
+func (p *publisher) record(ctx context.Context, r Resource, op Operation, err error) {
+	detail := Detail{
+		Kind:       r.Kind,
+		Namespace:  r.Namespace,
+		Name:       r.Name,
+		ARN:        r.ARN,
+		Generation: r.Generation,
+	}
+	switch {
+	case err != nil:
+		detail.Outcome = OutcomeTerminalError
+		detail.Message = err.Error()
+	case op == OperationDelete:
+		detail.Outcome = OutcomeDeleted
+	case op == OperationCreate:
+		detail.Outcome = OutcomeCreated
+	case r.ObservedGeneration != 0 && r.ObservedGeneration == r.Generation:
+		detail.Outcome = OutcomeDriftCorrected
+	default:
+		detail.Outcome = OutcomeUpdated
+	}
+	if !p.enabled[detail.Outcome] {
+		return
+	}
+
+	entry, err := p.entry(detail)
+	if err != nil {
+		p.log.Error(err, "unable to encode lifecycle event")
+		return
+	}
+	p.mu.Lock()
+	p.entries = append(p.entries, entry)
+	full := len(p.entries) >= p.cfg.batchSize || p.stopped
+	p.mu.Unlock()
+
+	if full {
+		p.flushWithTimeout(ctx)
+	}
+}
+
+func (p *publisher) entry(detail Detail) (svcsdktypes.PutEventsRequestEntry, error) {
+	b, err := json.Marshal(detail)
+	if err != nil {
+		return svcsdktypes.PutEventsRequestEntry{}, err
+	}
+	entry := svcsdktypes.PutEventsRequestEntry{
+		EventBusName: aws.String(p.cfg.eventBus),
+		Source:       aws.String(Source),
+		DetailType:   aws.String(detailTypePrefix + string(detail.Outcome)),
+		Detail:       aws.String(string(b)),
+	}
+	if detail.ARN != "" {
+		entry.Resources = []string{detail.ARN}
+	}
+	return entry, nil
+}
+
+// flushWithTimeout flushes the pending events outside of the flush interval.
+// The flush isn't canceled with the context, which is canceled when the
+// controller shuts down, but bounded by the flush timeout.
+func (p *publisher) flushWithTimeout(ctx context.Context) {
+	ctx, cancel := context.WithTimeout(context.WithoutCancel(ctx), flushTimeout)
+	defer cancel()
+	p.flush(ctx)
+}
+
+// flush sends the pending events in batches. Events which can't be sent are
+// logged and dropped, so that a misconfigured bus doesn't hold the events
+// in memory.
+func (p *publisher) flush(ctx context.Context) {
+	p.mu.Lock()
+	client := p.client
+	entries := p.entries
+	p.entries = nil
+	p.mu.Unlock()
+
+	for len(entries) > 0 {
+		n := min(len(entries), p.cfg.batchSize)
+		batch := entries[:n]
+		entries = entries[n:]
+
+		resp, err := client.PutEvents(ctx, &svcsdk.PutEventsInput{Entries: batch})
+		if err != nil {
+			p.log.Error(err, "unable to publish lifecycle events", "eventBus", p.cfg.eventBus, "count", len(batch))
+			continue
+		}
+		for i, e := range resp.Entries {
+			if e.ErrorCode != nil && i < len(batch) {
+				p.log.Error(
+					fmt.Errorf("%s: %s", aws.ToString(e.ErrorCode), aws.ToString(e.ErrorMessage)),
+					"unable to publish lifecycle event",
+					"eventBus", p.cfg.eventBus, "detailType", aws.ToString(batch[i].DetailType),
+				)
+			}
+		}
+	}
+}
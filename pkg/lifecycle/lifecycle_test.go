@@ -0,0 +1,168 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package lifecycle
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	ackerr "github.com/aws-controllers-k8s/runtime/pkg/errors"
+	"github.com/aws/aws-sdk-go-v2/aws"
+	svcsdk "github.com/aws/aws-sdk-go-v2/service/eventbridge"
+	"github.com/go-logr/logr/testr"
+	"gotest.tools/v3/assert"
+
+	"github.com/aws-controllers-k8s/eventbridge-controller/pkg/testutil"
+)
+
+func testPublisher(t *testing.T, cfg config) (*publisher, *testutil.EventBridge) {
+	t.Helper()
+	eb := testutil.NewEventBridge()
+	return newPublisher(cfg, svcsdk.NewFromConfig(eb.Config()), testr.New(t)), eb
+}
+
+func details(t *testing.T, eb *testutil.EventBridge, bus string) []Detail {
+	t.Helper()
+	var got []Detail
+	for _, e := range eb.Events(bus) {
+		assert.Equal(t, aws.ToString(e.Source), Source)
+		var d Detail
+		assert.NilError(t, json.Unmarshal([]byte(aws.ToString(e.Detail)), &d))
+		assert.Equal(t, aws.ToString(e.DetailType), detailTypePrefix+string(d.Outcome))
+		got = append(got, d)
+	}
+	return got
+}
+
+func Test_publisher_record(t *testing.T) {
+	rule := Resource{Kind: "Rule", Namespace: "ns", Name: "rule", ARN: "arn:aws:events:us-west-2:123456789012:rule/rule"}
+	gen := func(g, observed int64) Resource {
+		r := rule
+		r.Generation = g
+		r.ObservedGeneration = observed
+		return r
+	}
+	type record struct {
+		r   Resource
+		op  Operation
+		err error
+	}
+	tests := []struct {
+		name        string
+		detailTypes []string
+		records     []record
+		want        []Outcome
+	}{
+		{
+			name: "lifecycle",
+			records: []record{
+				{gen(1, 0), OperationCreate, nil},
+				{gen(2, 1), OperationUpdate, nil},
+				{gen(2, 2), OperationUpdate, nil},
+				{gen(2, 2), OperationDelete, nil},
+			},
+			want: []Outcome{OutcomeCreated, OutcomeUpdated, OutcomeDriftCorrected, OutcomeDeleted},
+		},
+		{
+			// e.g. rules created before the generation was observed
+			name:    "unknown observed generation",
+			records: []record{{gen(2, 0), OperationUpdate, nil}},
+			want:    []Outcome{OutcomeUpdated},
+		},
+		{
+			name: "errors",
+			records: []record{
+				{gen(1, 0), OperationCreate, ackerr.NewTerminalError(errors.New("invalid"))},
+				{gen(2, 1), OperationUpdate, errors.New("ValidationException: invalid")},
+			},
+			want: []Outcome{OutcomeTerminalError, OutcomeTerminalError},
+		},
+		{
+			name:        "filtered",
+			detailTypes: []string{"TerminalError", "ACK Resource Deleted"},
+			records: []record{
+				{gen(1, 0), OperationCreate, nil},
+				{gen(1, 1), OperationUpdate, ackerr.NewTerminalError(errors.New("invalid"))},
+				{gen(1, 1), OperationDelete, nil},
+			},
+			want: []Outcome{OutcomeTerminalError, OutcomeDeleted},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p, eb := testPublisher(t, config{
+				eventBus:    "default",
+				detailTypes: tt.detailTypes,
+				batchSize:   maxBatchSize,
+			})
+			for _, r := range tt.records {
+				p.record(context.Background(), r.r, r.op, r.err)
+			}
+			p.flush(context.Background())
+
+			got := details(t, eb, "default")
+			assert.Equal(t, len(got), len(tt.want))
+			for i, d := range got {
+				assert.Equal(t, d.Outcome, tt.want[i])
+				assert.Equal(t, d.ARN, rule.ARN)
+			}
+		})
+	}
+}
+
+func Test_publisher_batches(t *testing.T) {
+	p, eb := testPublisher(t, config{eventBus: "default", batchSize: 2, flushInterval: time.Hour})
+	for i := 0; i < 5; i++ {
+		p.record(context.Background(), Resource{Kind: "Rule", Name: "rule"}, OperationCreate, nil)
+	}
+	// full batches are sent right away
+	assert.Equal(t, len(eb.Events("default")), 4)
+	p.flush(context.Background())
+	assert.Equal(t, len(eb.Events("default")), 5)
+	assert.DeepEqual(t, eb.Calls(), []string{"PutEvents", "PutEvents", "PutEvents"})
+}
+
+func Test_publisher_failedEntries(t *testing.T) {
+	p, eb := testPublisher(t, config{eventBus: "missing", batchSize: 20})
+	assert.Equal(t, p.cfg.batchSize, maxBatchSize)
+
+	p.record(context.Background(), Resource{Kind: "Rule", Name: "rule"}, OperationDelete, nil)
+	p.flush(context.Background())
+	// failed entries are dropped
+	assert.Equal(t, len(p.entries), 0)
+	assert.DeepEqual(t, eb.Calls(), []string{"PutEvents"})
+}
+
+func Test_publisher_run(t *testing.T) {
+	p, eb := testPublisher(t, config{eventBus: "default", batchSize: maxBatchSize, flushInterval: time.Hour})
+	p.record(context.Background(), Resource{Kind: "Rule", Name: "rule"}, OperationCreate, nil)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		p.run(ctx)
+		close(done)
+	}()
+	cancel()
+	<-done
+	// the pending events are sent on shutdown, although the context is canceled
+	assert.Equal(t, len(eb.Events("default")), 1)
+
+	// and the events recorded later right away
+	p.record(ctx, Resource{Kind: "Rule", Name: "rule"}, OperationDelete, nil)
+	assert.Equal(t, len(eb.Events("default")), 2)
+}
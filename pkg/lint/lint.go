@@ -0,0 +1,260 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+// Package lint checks custom resource manifests of the controller without
+// AWS access. It runs the validations the controller applies before creating
+// resources, and checks for mistakes which AWS would only reject, or silently
+// accept, at runtime.
+package lint
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/aws/arn"
+	rtclient "sigs.k8s.io/controller-runtime/pkg/client"
+
+	svcapitypes "github.com/aws-controllers-k8s/eventbridge-controller/apis/v1alpha1"
+	"github.com/aws-controllers-k8s/eventbridge-controller/pkg/arns"
+	"github.com/aws-controllers-k8s/eventbridge-controller/pkg/resource/archive"
+	"github.com/aws-controllers-k8s/eventbridge-controller/pkg/resource/endpoint"
+	"github.com/aws-controllers-k8s/eventbridge-controller/pkg/resource/event_bus"
+	"github.com/aws-controllers-k8s/eventbridge-controller/pkg/resource/rule"
+	"github.com/aws-controllers-k8s/eventbridge-controller/pkg/transformer"
+)
+
+// Checks reported in findings
+const (
+	// CheckDecode reports documents which can't be decoded
+	CheckDecode = "decode"
+	// CheckValidation reports errors of the controller validations
+	CheckValidation = "validation"
+	// CheckTargetARN reports malformed target ARNs
+	CheckTargetARN = "target-arn"
+	// CheckTargetInput reports targets with more than one of Input,
+	// InputPath and InputTransformer
+	CheckTargetInput = "target-input"
+	// CheckInputTemplate reports invalid InputTransformer templates and input
+	// paths, e.g. placeholders which aren't declared in InputPathsMap
+	CheckInputTemplate = "input-template"
+	// CheckDuplicateRule reports rules with the same name on the same event
+	// bus
+	CheckDuplicateRule = "duplicate-rule"
+	// CheckScheduleBus reports scheduled rules on other than the default
+	// event bus
+	CheckScheduleBus = "schedule-bus"
+)
+
+const defaultEventBus = "default"
+
+// Finding is a problem found in a manifest
+type Finding struct {
+	File      string `json:"file"`
+	Document  int    `json:"document"`
+	Kind      string `json:"kind,omitempty"`
+	Namespace string `json:"namespace,omitempty"`
+	Name      string `json:"name,omitempty"`
+	// Field is the path of the offending field, e.g. spec.targets[0].arn
+	Field   string `json:"field,omitempty"`
+	Check   string `json:"check"`
+	Message string `json:"message"`
+}
+
+func (f Finding) String() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "%s[%d]", f.File, f.Document)
+	switch {
+	case f.Kind == "":
+	case f.Namespace == "":
+		fmt.Fprintf(&b, " %s %s", f.Kind, f.Name)
+	default:
+		fmt.Fprintf(&b, " %s %s/%s", f.Kind, f.Namespace, f.Name)
+	}
+	if f.Field != "" {
+		fmt.Fprintf(&b, " %s", f.Field)
+	}
+	fmt.Fprintf(&b, ": %s (%s)", f.Message, f.Check)
+	return b.String()
+}
+
+type linter struct {
+	manifests []Manifest
+	findings  []Finding
+	// buses are the event bus names by namespace/name of the EventBus
+	// resources, to resolve EventBusRef references
+	buses map[rtclient.ObjectKey]string
+}
+
+// Lint checks the manifests and returns the findings, ordered by file and
+// document
+func Lint(manifests []Manifest) []Finding {
+	l := &linter{
+		manifests: manifests,
+		buses:     make(map[rtclient.ObjectKey]string),
+	}
+	for _, m := range manifests {
+		if bus, ok := m.Object.(*svcapitypes.EventBus); ok {
+			l.buses[rtclient.ObjectKeyFromObject(bus)] = aws.ToString(bus.Spec.Name)
+		}
+	}
+
+	for _, m := range manifests {
+		switch obj := m.Object.(type) {
+		case *svcapitypes.EventBus:
+			l.validate(m, event_bus.Validate(obj))
+		case *svcapitypes.Rule:
+			l.validate(m, rule.Validate(withoutInputTransformers(obj)))
+			l.checkTargets(m, obj.Spec.Targets)
+			l.checkSchedule(m, obj)
+		case *svcapitypes.Archive:
+			l.validate(m, archive.Validate(obj))
+		case *svcapitypes.Endpoint:
+			l.validate(m, endpoint.Validate(obj))
+		}
+	}
+	l.checkDuplicateRules()
+
+	sort.SliceStable(l.findings, func(i, j int) bool {
+		a, b := l.findings[i], l.findings[j]
+		if a.File != b.File {
+			return a.File < b.File
+		}
+		return a.Document < b.Document
+	})
+	return l.findings
+}
+
+func (l *linter) report(m Manifest, field, check, format string, args ...interface{}) {
+	l.findings = append(l.findings, Finding{
+		File:      m.File,
+		Document:  m.Document,
+		Kind:      m.Object.GetObjectKind().GroupVersionKind().Kind,
+		Namespace: m.Object.GetNamespace(),
+		Name:      m.Object.GetName(),
+		Field:     field,
+		Check:     check,
+		Message:   fmt.Sprintf(format, args...),
+	})
+}
+
+func (l *linter) validate(m Manifest, err error) {
+	if err != nil {
+		l.report(m, "", CheckValidation, "%v", err)
+	}
+}
+
+func (l *linter) checkTargets(m Manifest, targets []*svcapitypes.Target) {
+	for i, t := range targets {
+		field := fmt.Sprintf("spec.targets[%d]", i)
+		l.checkARN(m, field+".arn", t.ARN)
+		l.checkARN(m, field+".roleARN", t.RoleARN)
+		if t.DeadLetterConfig != nil {
+			l.checkARN(m, field+".deadLetterConfig.arn", t.DeadLetterConfig.ARN)
+		}
+
+		var inputs []string
+		if t.Input != nil {
+			inputs = append(inputs, "input")
+		}
+		if t.InputPath != nil {
+			inputs = append(inputs, "inputPath")
+		}
+		if t.InputTransformer != nil {
+			inputs = append(inputs, "inputTransformer")
+		}
+		if len(inputs) > 1 {
+			l.report(m, field, CheckTargetInput, "only one of %s can be set", strings.Join(inputs, ", "))
+		}
+
+		if err := transformer.Validate(t.InputTransformer); err != nil {
+			// the errors of the transformer start with the name of the field
+			name, _, _ := strings.Cut(err.Error(), " ")
+			l.report(m, field+".inputTransformer."+name, CheckInputTemplate, "%v", err)
+		}
+	}
+}
+
+// withoutInputTransformers returns a copy of the rule without the input
+// transformers of its targets. checkTargets validates them with
+// transformer.Validate and reports their errors with their fields, so they
+// aren't reported again with the validation of the rule.
+func withoutInputTransformers(r *svcapitypes.Rule) *svcapitypes.Rule {
+	r = r.DeepCopy()
+	for _, t := range r.Spec.Targets {
+		if t != nil {
+			t.InputTransformer = nil
+		}
+	}
+	return r
+}
+
+func (l *linter) checkARN(m Manifest, field string, s *string) {
+	if s == nil {
+		return
+	}
+	if _, err := arn.Parse(*s); err != nil {
+		l.report(m, field, CheckTargetARN, "malformed ARN %q", *s)
+	}
+}
+
+func (l *linter) checkSchedule(m Manifest, r *svcapitypes.Rule) {
+	if aws.ToString(r.Spec.ScheduleExpression) == "" {
+		return
+	}
+	if bus := l.eventBus(r); bus != defaultEventBus {
+		l.report(m, "spec.scheduleExpression", CheckScheduleBus,
+			"scheduled rules are only supported on the default event bus, not %q", bus)
+	}
+}
+
+// checkDuplicateRules reports rules with the name of another rule on the same
+// event bus, in any file
+func (l *linter) checkDuplicateRules() {
+	type key struct{ bus, name string }
+	first := make(map[key]Manifest)
+	for _, m := range l.manifests {
+		r, ok := m.Object.(*svcapitypes.Rule)
+		if !ok || aws.ToString(r.Spec.Name) == "" {
+			continue
+		}
+		k := key{bus: l.eventBus(r), name: aws.ToString(r.Spec.Name)}
+		if prev, ok := first[k]; ok {
+			l.report(m, "spec.name", CheckDuplicateRule,
+				"rule %q on event bus %q is also declared in %s[%d]", k.name, k.bus, prev.File, prev.Document)
+			continue
+		}
+		first[k] = m
+	}
+}
+
+// eventBus returns the name of the event bus of the rule. Rules referencing
+// an EventBus resource which isn't part of the manifests get the name of the
+// reference.
+func (l *linter) eventBus(r *svcapitypes.Rule) string {
+	if ref := r.Spec.EventBusRef; ref != nil && ref.From != nil {
+		key := rtclient.ObjectKey{
+			Namespace: r.Namespace,
+			Name:      aws.ToString(ref.From.Name),
+		}
+		if ref.From.Namespace != nil {
+			key.Namespace = *ref.From.Namespace
+		}
+		if name, ok := l.buses[key]; ok {
+			return name
+		}
+		return "ref:" + key.String()
+	}
+	return arns.EventBusName(aws.ToString(r.Spec.EventBusName))
+}
@@ -0,0 +1,247 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package lint
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"gotest.tools/v3/assert"
+)
+
+// writeFiles writes the files to a temporary directory and returns it
+func writeFiles(t *testing.T, files map[string]string) string {
+	t.Helper()
+	dir := t.TempDir()
+	for name, content := range files {
+		path := filepath.Join(dir, name)
+		assert.NilError(t, os.MkdirAll(filepath.Dir(path), 0o755))
+		assert.NilError(t, os.WriteFile(path, []byte(content), 0o600))
+	}
+	return dir
+}
+
+func TestLoad(t *testing.T) {
+	dir := writeFiles(t, map[string]string{
+		"bus.yaml": `
+apiVersion: eventbridge.services.k8s.aws/v1alpha1
+kind: EventBus
+metadata:
+  name: orders
+spec:
+  name: orders
+---
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: ignored
+---
+apiVersion: eventbridge.services.k8s.aws/v1alpha1
+kind: Rule
+metadata:
+  name: typo
+spec:
+  name: typo
+  evenPattern: "{}"
+`,
+		"nested/rule.json": `{"apiVersion": "eventbridge.services.k8s.aws/v1alpha1", "kind": "Rule", "metadata": {"name": "r"}, "spec": {"name": "r"}}`,
+		"README.md":        "not a manifest",
+	})
+
+	manifests, findings, err := Load([]string{dir})
+	assert.NilError(t, err)
+	assert.Equal(t, len(manifests), 2)
+	assert.Equal(t, manifests[0].Object.GetName(), "orders")
+	assert.Equal(t, manifests[0].Document, 0)
+	assert.Equal(t, manifests[1].File, filepath.Join(dir, "nested/rule.json"))
+
+	assert.Equal(t, len(findings), 1)
+	assert.Equal(t, findings[0].Check, CheckDecode)
+	assert.Equal(t, findings[0].Document, 2)
+	assert.Assert(t, strings.Contains(findings[0].Message, `unknown field "evenPattern"`), findings[0].Message)
+
+	_, _, err = Load([]string{filepath.Join(dir, "missing.yaml")})
+	assert.ErrorContains(t, err, "no such file")
+}
+
+func TestLint(t *testing.T) {
+	const header = "apiVersion: eventbridge.services.k8s.aws/v1alpha1\n"
+	tests := []struct {
+		name  string
+		files map[string]string
+		want  []Finding
+	}{
+		{
+			name: "valid",
+			files: map[string]string{"rules.yaml": header + `kind: Rule
+metadata:
+  name: created
+spec:
+  name: created
+  eventBusRef:
+    from:
+      name: orders
+  eventPattern: '{"source":["orders"]}'
+  targets:
+  - id: queue
+    arn: arn:aws:sqs:us-west-2:123456789012:orders
+    inputTransformer:
+      inputPathsMap:
+        id: $.detail.id
+      inputTemplate: '{"id": <id>, "rule": <aws.events.rule-name>}'
+`},
+		},
+		{
+			name: "controller validation",
+			files: map[string]string{"rule.yaml": header + `kind: Rule
+metadata:
+  name: empty
+spec:
+  name: empty
+`},
+			want: []Finding{{
+				File: "rule.yaml", Kind: "Rule", Name: "empty", Check: CheckValidation,
+				Message: `invalid Spec: "spec": at least one of "spec.eventPattern" or "spec.scheduleExpression" must be specified`,
+			}},
+		},
+		{
+			name: "targets",
+			files: map[string]string{"rule.yaml": header + `kind: Rule
+metadata:
+  name: targets
+  namespace: orders
+spec:
+  name: targets
+  eventPattern: '{"source":["orders"]}'
+  targets:
+  - id: queue
+    arn: orders-queue
+    input: '{}'
+    inputPath: $.detail
+    deadLetterConfig:
+      arn: arn:aws:sqs:us-west-2:123456789012:dlq
+  - id: lambda
+    arn: arn:aws:lambda:us-west-2:123456789012:function:f
+    roleARN: role
+    inputTransformer:
+      inputPathsMap:
+        id: $.detail.id
+      inputTemplate: '{"id": <id>, "name": "<name>", "again": "<name>"}'
+`},
+			want: []Finding{
+				{File: "rule.yaml", Kind: "Rule", Namespace: "orders", Name: "targets", Field: "spec.targets[0].arn", Check: CheckTargetARN, Message: `malformed ARN "orders-queue"`},
+				{File: "rule.yaml", Kind: "Rule", Namespace: "orders", Name: "targets", Field: "spec.targets[0]", Check: CheckTargetInput, Message: "only one of input, inputPath can be set"},
+				{File: "rule.yaml", Kind: "Rule", Namespace: "orders", Name: "targets", Field: "spec.targets[1].roleARN", Check: CheckTargetARN, Message: `malformed ARN "role"`},
+				{File: "rule.yaml", Kind: "Rule", Namespace: "orders", Name: "targets", Field: "spec.targets[1].inputTransformer.inputTemplate", Check: CheckInputTemplate, Message: "inputTemplate placeholder <name> is not declared in inputPathsMap"},
+			},
+		},
+		{
+			name: "duplicate rules across files",
+			files: map[string]string{
+				"a.yaml": header + `kind: EventBus
+metadata:
+  name: orders-bus
+spec:
+  name: orders
+---
+` + header + `kind: Rule
+metadata:
+  name: created
+spec:
+  name: created
+  eventBusName: orders
+  eventPattern: '{}'
+`,
+				"b.yaml": header + `kind: Rule
+metadata:
+  name: created-again
+spec:
+  name: created
+  eventBusRef:
+    from:
+      name: orders-bus
+  eventPattern: '{}'
+---
+` + header + `kind: Rule
+metadata:
+  name: created-default
+spec:
+  name: created
+  eventPattern: '{}'
+`,
+			},
+			want: []Finding{{
+				File: "b.yaml", Kind: "Rule", Name: "created-again", Field: "spec.name", Check: CheckDuplicateRule,
+				Message: `rule "created" on event bus "orders" is also declared in a.yaml[1]`,
+			}},
+		},
+		{
+			name: "schedule on custom bus",
+			files: map[string]string{"rule.yaml": header + `kind: Rule
+metadata:
+  name: nightly
+spec:
+  name: nightly
+  eventBusName: arn:aws:events:us-west-2:123456789012:event-bus/orders
+  scheduleExpression: rate(1 day)
+---
+` + header + `kind: Rule
+metadata:
+  name: nightly-default
+spec:
+  name: nightly-default
+  eventBusName: default
+  scheduleExpression: rate(1 day)
+`},
+			want: []Finding{{
+				File: "rule.yaml", Kind: "Rule", Name: "nightly", Field: "spec.scheduleExpression", Check: CheckScheduleBus,
+				Message: `scheduled rules are only supported on the default event bus, not "orders"`,
+			}},
+		},
+		{
+			name: "endpoint validation",
+			files: map[string]string{"endpoint.yaml": header + `kind: Endpoint
+metadata:
+  name: orders
+spec:
+  name: orders
+  eventBuses:
+  - eventBusARN: arn:aws:events:us-west-2:123456789012:event-bus/orders
+  routingConfig:
+    failoverConfig: {}
+`},
+			want: []Finding{{
+				File: "endpoint.yaml", Kind: "Endpoint", Name: "orders", Check: CheckValidation,
+				Message: `invalid Spec: "spec.eventBuses": must contain exactly two event buses`,
+			}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := writeFiles(t, tt.files)
+			manifests, findings, err := Load([]string{dir})
+			assert.NilError(t, err)
+			assert.Equal(t, len(findings), 0)
+
+			got := Lint(manifests)
+			for i := range got {
+				got[i].File = strings.TrimPrefix(got[i].File, dir+"/")
+				got[i].Message = strings.ReplaceAll(got[i].Message, dir+"/", "")
+			}
+			assert.DeepEqual(t, got, tt.want)
+		})
+	}
+}
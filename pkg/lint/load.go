@@ -0,0 +1,155 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package lint
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	utilyaml "k8s.io/apimachinery/pkg/util/yaml"
+	rtclient "sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/yaml"
+
+	svcapitypes "github.com/aws-controllers-k8s/eventbridge-controller/apis/v1alpha1"
+)
+
+// Manifest is a custom resource of the controller loaded from a file
+type Manifest struct {
+	File string
+	// Document is the index of the YAML document in the file
+	Document int
+	Object   rtclient.Object
+}
+
+// Load reads the custom resources of the controller from the files and,
+// recursively, the .yaml, .yml and .json files of the directories. Documents
+// of other API groups are skipped. Documents which can't be decoded, e.g.
+// because of unknown fields, are returned as findings.
+func Load(paths []string) ([]Manifest, []Finding, error) {
+	var files []string
+	for _, p := range paths {
+		info, err := os.Stat(p)
+		if err != nil {
+			return nil, nil, err
+		}
+		if !info.IsDir() {
+			files = append(files, p)
+			continue
+		}
+		err = filepath.WalkDir(p, func(path string, d fs.DirEntry, err error) error {
+			if err != nil {
+				return err
+			}
+			switch strings.ToLower(filepath.Ext(path)) {
+			case ".yaml", ".yml", ".json":
+				if !d.IsDir() {
+					files = append(files, path)
+				}
+			}
+			return nil
+		})
+		if err != nil {
+			return nil, nil, err
+		}
+	}
+	sort.Strings(files)
+
+	var (
+		manifests []Manifest
+		findings  []Finding
+	)
+	for _, file := range files {
+		data, err := os.ReadFile(file)
+		if err != nil {
+			return nil, nil, err
+		}
+		m, f, err := decodeFile(file, data)
+		if err != nil {
+			return nil, nil, err
+		}
+		manifests = append(manifests, m...)
+		findings = append(findings, f...)
+	}
+	return manifests, findings, nil
+}
+
+// decodeFile decodes the custom resources of the controller in the YAML or
+// JSON documents of a file
+func decodeFile(file string, data []byte) ([]Manifest, []Finding, error) {
+	var (
+		manifests []Manifest
+		findings  []Finding
+	)
+	reader := utilyaml.NewYAMLReader(bufio.NewReader(bytes.NewReader(data)))
+	for i := 0; ; i++ {
+		doc, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			return manifests, findings, nil
+		}
+		if err != nil {
+			return nil, nil, err
+		}
+		if len(bytes.TrimSpace(doc)) == 0 {
+			continue
+		}
+
+		var typeMeta metav1.TypeMeta
+		if err := yaml.Unmarshal(doc, &typeMeta); err != nil {
+			findings = append(findings, Finding{File: file, Document: i, Check: CheckDecode, Message: err.Error()})
+			continue
+		}
+		if typeMeta.APIVersion != svcapitypes.GroupVersion.String() {
+			continue
+		}
+		obj := newObject(typeMeta.Kind)
+		if obj == nil {
+			continue
+		}
+		if err := yaml.UnmarshalStrict(doc, obj); err != nil {
+			findings = append(findings, Finding{
+				File:     file,
+				Document: i,
+				Kind:     typeMeta.Kind,
+				Check:    CheckDecode,
+				Message:  err.Error(),
+			})
+			continue
+		}
+		manifests = append(manifests, Manifest{File: file, Document: i, Object: obj})
+	}
+}
+
+// newObject returns an empty custom resource of the kind, or nil for kinds
+// which aren't linted
+func newObject(kind string) rtclient.Object {
+	switch kind {
+	case "EventBus":
+		return &svcapitypes.EventBus{}
+	case "Rule":
+		return &svcapitypes.Rule{}
+	case "Archive":
+		return &svcapitypes.Archive{}
+	case "Endpoint":
+		return &svcapitypes.Endpoint{}
+	}
+	return nil
+}
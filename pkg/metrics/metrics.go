@@ -0,0 +1,289 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+// Package metrics exports Prometheus metrics about the resources managed by
+// the controller, in addition to the API call metrics of the ACK runtime.
+// They are registered with the controller-runtime registry, which is served
+// by the metrics server of the controller.
+package metrics
+
+import (
+	"sync"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws/arn"
+	"github.com/prometheus/client_golang/prometheus"
+	ctrlrtmetrics "sigs.k8s.io/controller-runtime/pkg/metrics"
+
+	"github.com/aws-controllers-k8s/eventbridge-controller/pkg/arns"
+)
+
+// Kinds of the resources reported in metrics
+const (
+	KindRule     = "Rule"
+	KindArchive  = "Archive"
+	KindEndpoint = "Endpoint"
+)
+
+const (
+	namespace = "ack_eventbridge"
+
+	defaultEventBus = "default"
+	unknown         = "unknown"
+)
+
+var (
+	rulesDesc = prometheus.NewDesc(
+		namespace+"_rules",
+		"Number of managed rules by event bus and state.",
+		[]string{"event_bus", "state"}, nil,
+	)
+	ruleTargetsDesc = prometheus.NewDesc(
+		namespace+"_rule_targets",
+		"Number of targets of managed rules by namespace and target service.",
+		[]string{"namespace", "service"}, nil,
+	)
+	archiveStateDesc = prometheus.NewDesc(
+		namespace+"_archive_state",
+		"State of managed archives, the value is always 1.",
+		[]string{"namespace", "archive", "state"}, nil,
+	)
+	endpointStateDesc = prometheus.NewDesc(
+		namespace+"_endpoint_state",
+		"State of managed endpoints, the value is always 1.",
+		[]string{"namespace", "endpoint", "state"}, nil,
+	)
+
+	syncDuration = prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Name: namespace + "_sync_duration_seconds",
+			Help: "Time from the first reconciliation of a new generation of a resource, " +
+				"or its creation, until it is synced with AWS.",
+			Buckets: prometheus.ExponentialBuckets(0.5, 2, 12),
+		},
+		[]string{"kind"},
+	)
+	failedTargetEntries = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: namespace + "_failed_target_entries_total",
+			Help: "Number of target entries rejected by PutTargets or RemoveTargets.",
+		},
+		[]string{"operation", "error_code"},
+	)
+	throttledRequests = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: namespace + "_throttled_requests_total",
+			Help: "Number of EventBridge API requests throttled, by operation.",
+		},
+		[]string{"operation"},
+	)
+	cacheLookups = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: namespace + "_read_cache_lookups_total",
+			Help: "Number of lookups of cached tags, targets and log deliveries reads, by cache and result.",
+		},
+		[]string{"cache", "result"},
+	)
+
+	resources = newResourceCollector()
+)
+
+func init() {
+	ctrlrtmetrics.Registry.MustRegister(resources, syncDuration, failedTargetEntries, throttledRequests, cacheLookups)
+}
+
+type resourceKey struct {
+	kind, namespace, name string
+}
+
+type ruleObservation struct {
+	eventBus string
+	state    string
+	// targets are the number of targets by service
+	targets map[string]int
+}
+
+type generationStart struct {
+	generation int64
+	start      time.Time
+	synced     bool
+}
+
+// resourceCollector collects the metrics derived from the last observed state
+// of each resource, so that resources which are deleted are no longer
+// reported
+type resourceCollector struct {
+	mu     sync.Mutex
+	rules  map[resourceKey]ruleObservation
+	states map[resourceKey]string
+	starts map[resourceKey]generationStart
+	now    func() time.Time
+}
+
+func newResourceCollector() *resourceCollector {
+	return &resourceCollector{
+		rules:  make(map[resourceKey]ruleObservation),
+		states: make(map[resourceKey]string),
+		starts: make(map[resourceKey]generationStart),
+		now:    time.Now,
+	}
+}
+
+// Describe implements prometheus.Collector
+func (c *resourceCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- rulesDesc
+	ch <- ruleTargetsDesc
+	ch <- archiveStateDesc
+	ch <- endpointStateDesc
+}
+
+// Collect implements prometheus.Collector
+func (c *resourceCollector) Collect(ch chan<- prometheus.Metric) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	// the targets aren't reported by rule, so the number of series doesn't
+	// grow with the number of rules
+	type busState struct{ bus, state string }
+	type namespaceService struct{ namespace, service string }
+	rules := make(map[busState]int)
+	targets := make(map[namespaceService]int)
+	for key, r := range c.rules {
+		rules[busState{r.eventBus, r.state}]++
+		for service, n := range r.targets {
+			targets[namespaceService{key.namespace, service}] += n
+		}
+	}
+	for k, n := range rules {
+		ch <- prometheus.MustNewConstMetric(rulesDesc, prometheus.GaugeValue, float64(n), k.bus, k.state)
+	}
+	for k, n := range targets {
+		ch <- prometheus.MustNewConstMetric(ruleTargetsDesc, prometheus.GaugeValue, float64(n), k.namespace, k.service)
+	}
+
+	for key, state := range c.states {
+		desc := archiveStateDesc
+		if key.kind == KindEndpoint {
+			desc = endpointStateDesc
+		}
+		ch <- prometheus.MustNewConstMetric(desc, prometheus.GaugeValue, 1, key.namespace, key.name, state)
+	}
+}
+
+// ObserveRule records the event bus, state and targets of a rule
+func ObserveRule(namespace, name, eventBus, state string, targetARNs []string) {
+	targets := make(map[string]int)
+	for _, t := range targetARNs {
+		targets[targetService(t)]++
+	}
+	resources.mu.Lock()
+	defer resources.mu.Unlock()
+	resources.rules[resourceKey{KindRule, namespace, name}] = ruleObservation{
+		eventBus: eventBusName(eventBus),
+		state:    valueOrUnknown(state),
+		targets:  targets,
+	}
+}
+
+// ObserveState records the state of an archive or an endpoint
+func ObserveState(kind, namespace, name, state string) {
+	resources.mu.Lock()
+	defer resources.mu.Unlock()
+	resources.states[resourceKey{kind, namespace, name}] = valueOrUnknown(state)
+}
+
+// Forget stops reporting a deleted resource
+func Forget(kind, namespace, name string) {
+	key := resourceKey{kind, namespace, name}
+	resources.mu.Lock()
+	defer resources.mu.Unlock()
+	delete(resources.rules, key)
+	delete(resources.states, key)
+	delete(resources.starts, key)
+}
+
+// SyncStarted records the first time a generation of a resource is
+// reconciled. Later calls for the same generation are ignored.
+func SyncStarted(kind, namespace, name string, generation int64) {
+	key := resourceKey{kind, namespace, name}
+	resources.mu.Lock()
+	defer resources.mu.Unlock()
+	if s, ok := resources.starts[key]; ok && s.generation == generation {
+		return
+	}
+	resources.starts[key] = generationStart{generation: generation, start: resources.now()}
+}
+
+// Synced observes the sync duration of a generation of a resource which was
+// synced with AWS, once per generation. Newly created resources, which were
+// never reconciled before, are measured from their creation.
+func Synced(kind, namespace, name string, generation int64, created time.Time) {
+	key := resourceKey{kind, namespace, name}
+	resources.mu.Lock()
+	defer resources.mu.Unlock()
+	s, ok := resources.starts[key]
+	if !ok || s.generation != generation {
+		s = generationStart{generation: generation, start: created}
+	}
+	if s.synced {
+		return
+	}
+	s.synced = true
+	resources.starts[key] = s
+	if !s.start.IsZero() {
+		syncDuration.WithLabelValues(kind).Observe(resources.now().Sub(s.start).Seconds())
+	}
+}
+
+// RecordFailedTargetEntries counts the target entries rejected by the
+// operation, by error code
+func RecordFailedTargetEntries(operation string, errorCodes []string) {
+	for _, code := range errorCodes {
+		failedTargetEntries.WithLabelValues(operation, valueOrUnknown(code)).Inc()
+	}
+}
+
+// RecordThrottledRequest counts a throttled API request of the operation
+func RecordThrottledRequest(operation string) {
+	throttledRequests.WithLabelValues(valueOrUnknown(operation)).Inc()
+}
+
+// RecordCacheLookup counts a lookup of a read cache as a hit or a miss
+func RecordCacheLookup(cache string, hit bool) {
+	result := "miss"
+	if hit {
+		result = "hit"
+	}
+	cacheLookups.WithLabelValues(cache, result).Inc()
+}
+
+// targetService returns the service of a target ARN, e.g. sqs
+func targetService(targetARN string) string {
+	parsed, err := arn.Parse(targetARN)
+	if err != nil {
+		return unknown
+	}
+	return parsed.Service
+}
+
+// eventBusName returns the name of the event bus given by name or ARN
+func eventBusName(nameOrARN string) string {
+	return arns.EventBusName(nameOrARN)
+}
+
+func valueOrUnknown(s string) string {
+	if s == "" {
+		return unknown
+	}
+	return s
+}
@@ -0,0 +1,153 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package metrics
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	dto "github.com/prometheus/client_model/go"
+	"gotest.tools/v3/assert"
+)
+
+// useCollector replaces the resource collector for the duration of the test
+func useCollector(t *testing.T) *resourceCollector {
+	t.Helper()
+	prev := resources
+	resources = newResourceCollector()
+	t.Cleanup(func() { resources = prev })
+	return resources
+}
+
+func TestResourceCollector(t *testing.T) {
+	c := useCollector(t)
+
+	ObserveRule("orders", "created", "arn:aws:events:us-west-2:123456789012:event-bus/orders", "ENABLED", []string{
+		"arn:aws:sqs:us-west-2:123456789012:orders",
+		"arn:aws:sqs:us-west-2:123456789012:audit",
+		"arn:aws:lambda:us-west-2:123456789012:function:notify",
+	})
+	ObserveRule("orders", "nightly", "", "DISABLED", nil)
+	ObserveRule("orders", "shipped", "orders", "ENABLED", []string{"arn:aws:sqs:us-west-2:123456789012:shipping"})
+	ObserveRule("billing", "paid", "orders", "ENABLED", []string{"queue"})
+	ObserveState(KindArchive, "orders", "all", "ENABLED")
+	ObserveState(KindEndpoint, "orders", "global", "")
+	ObserveState(KindEndpoint, "orders", "deleted", "ACTIVE")
+	Forget(KindEndpoint, "orders", "deleted")
+
+	want := `
+# HELP ack_eventbridge_archive_state State of managed archives, the value is always 1.
+# TYPE ack_eventbridge_archive_state gauge
+ack_eventbridge_archive_state{archive="all",namespace="orders",state="ENABLED"} 1
+# HELP ack_eventbridge_endpoint_state State of managed endpoints, the value is always 1.
+# TYPE ack_eventbridge_endpoint_state gauge
+ack_eventbridge_endpoint_state{endpoint="global",namespace="orders",state="unknown"} 1
+# HELP ack_eventbridge_rule_targets Number of targets of managed rules by namespace and target service.
+# TYPE ack_eventbridge_rule_targets gauge
+ack_eventbridge_rule_targets{namespace="billing",service="unknown"} 1
+ack_eventbridge_rule_targets{namespace="orders",service="lambda"} 1
+ack_eventbridge_rule_targets{namespace="orders",service="sqs"} 3
+# HELP ack_eventbridge_rules Number of managed rules by event bus and state.
+# TYPE ack_eventbridge_rules gauge
+ack_eventbridge_rules{event_bus="default",state="DISABLED"} 1
+ack_eventbridge_rules{event_bus="orders",state="ENABLED"} 3
+`
+	assert.NilError(t, testutil.CollectAndCompare(c, strings.NewReader(want)))
+}
+
+func TestSynced(t *testing.T) {
+	c := useCollector(t)
+	syncDuration.Reset()
+	defer syncDuration.Reset()
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	c.now = func() time.Time { return now }
+
+	// created rules are measured from their creation
+	Synced(KindRule, "orders", "created", 1, now.Add(-3*time.Second))
+	// further reconciliations of the same generation aren't measured
+	SyncStarted(KindRule, "orders", "created", 1)
+	now = now.Add(time.Hour)
+	Synced(KindRule, "orders", "created", 1, time.Time{})
+
+	SyncStarted(KindRule, "orders", "created", 2)
+	now = now.Add(20 * time.Second)
+	SyncStarted(KindRule, "orders", "created", 2)
+	now = now.Add(10 * time.Second)
+	Synced(KindRule, "orders", "created", 2, time.Time{})
+
+	// generations which were not seen starting aren't measured
+	Synced(KindRule, "orders", "created", 3, time.Time{})
+
+	assert.Equal(t, testutil.CollectAndCount(syncDuration), 1)
+	assert.Equal(t, sampleCount(t, KindRule), uint64(2))
+	assert.Equal(t, sampleSum(t, KindRule), 33.0)
+}
+
+func sampleCount(t *testing.T, kind string) uint64 {
+	t.Helper()
+	return histogram(t, kind).GetSampleCount()
+}
+
+func sampleSum(t *testing.T, kind string) float64 {
+	t.Helper()
+	return histogram(t, kind).GetSampleSum()
+}
+
+func histogram(t *testing.T, kind string) *dto.Histogram {
+	t.Helper()
+	m := &dto.Metric{}
+	observer, err := syncDuration.GetMetricWithLabelValues(kind)
+	assert.NilError(t, err)
+	assert.NilError(t, observer.(prometheus.Metric).Write(m))
+	return m.GetHistogram()
+}
+
+func TestRecordFailedTargetEntries(t *testing.T) {
+	failedTargetEntries.Reset()
+	defer failedTargetEntries.Reset()
+	RecordFailedTargetEntries("PutTargets", []string{"ConcurrentModificationException", "", "ConcurrentModificationException"})
+
+	assert.Equal(t, testutil.ToFloat64(failedTargetEntries.WithLabelValues("PutTargets", "ConcurrentModificationException")), 2.0)
+	assert.Equal(t, testutil.ToFloat64(failedTargetEntries.WithLabelValues("PutTargets", "unknown")), 1.0)
+}
+
+func TestRecordThrottledRequest(t *testing.T) {
+	throttledRequests.Reset()
+	defer throttledRequests.Reset()
+	RecordThrottledRequest("PutTargets")
+	RecordThrottledRequest("")
+
+	assert.Equal(t, testutil.ToFloat64(throttledRequests.WithLabelValues("PutTargets")), 1.0)
+	assert.Equal(t, testutil.ToFloat64(throttledRequests.WithLabelValues("unknown")), 1.0)
+}
+
+func TestRecordCacheLookup(t *testing.T) {
+	cacheLookups.Reset()
+	defer cacheLookups.Reset()
+	RecordCacheLookup("rule_tags", true)
+	RecordCacheLookup("rule_tags", true)
+	RecordCacheLookup("rule_tags", false)
+
+	assert.Equal(t, testutil.ToFloat64(cacheLookups.WithLabelValues("rule_tags", "hit")), 2.0)
+	assert.Equal(t, testutil.ToFloat64(cacheLookups.WithLabelValues("rule_tags", "miss")), 1.0)
+}
+
+func TestEventBusName(t *testing.T) {
+	assert.Equal(t, eventBusName(""), "default")
+	assert.Equal(t, eventBusName("orders"), "orders")
+	assert.Equal(t, eventBusName("arn:aws:events:us-west-2:123456789012:event-bus/orders"), "orders")
+}
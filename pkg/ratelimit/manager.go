@@ -0,0 +1,129 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package ratelimit
+
+import (
+	"context"
+	"math/rand/v2"
+	"time"
+
+	ackv1alpha1 "github.com/aws-controllers-k8s/runtime/apis/core/v1alpha1"
+	ackcompare "github.com/aws-controllers-k8s/runtime/pkg/compare"
+	ackcfg "github.com/aws-controllers-k8s/runtime/pkg/config"
+	ackmetrics "github.com/aws-controllers-k8s/runtime/pkg/metrics"
+	ackrequeue "github.com/aws-controllers-k8s/runtime/pkg/requeue"
+	acktypes "github.com/aws-controllers-k8s/runtime/pkg/types"
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/go-logr/logr"
+	flag "github.com/spf13/pflag"
+)
+
+const flagRequeueDelay = "api-throttle-requeue-delay"
+
+// requeueDelay is set by the controller flag
+var requeueDelay = 10 * time.Second
+
+func init() {
+	flag.DurationVar(
+		&requeueDelay, flagRequeueDelay, requeueDelay,
+		"The minimum delay before a throttled reconciliation is retried. A random jitter of up to the same delay is added.",
+	)
+}
+
+// WrapManagerFactory returns a resource manager factory whose resource
+// managers rate limit their API calls, and requeue throttled reconciliations
+func WrapManagerFactory(f acktypes.AWSResourceManagerFactory) acktypes.AWSResourceManagerFactory {
+	return &managerFactory{f}
+}
+
+// WrapManagerFactories wraps each of the resource manager factories with
+// WrapManagerFactory
+func WrapManagerFactories(fs []acktypes.AWSResourceManagerFactory) []acktypes.AWSResourceManagerFactory {
+	res := make([]acktypes.AWSResourceManagerFactory, len(fs))
+	for i, f := range fs {
+		res[i] = WrapManagerFactory(f)
+	}
+	return res
+}
+
+type managerFactory struct {
+	acktypes.AWSResourceManagerFactory
+}
+
+// ManagerFor implements acktypes.AWSResourceManagerFactory
+func (f *managerFactory) ManagerFor(
+	cfg ackcfg.Config,
+	clientcfg aws.Config,
+	log logr.Logger,
+	metrics *ackmetrics.Metrics,
+	rr acktypes.Reconciler,
+	id ackv1alpha1.AWSAccountID,
+	region ackv1alpha1.AWSRegion,
+	roleARN ackv1alpha1.AWSResourceName,
+) (acktypes.AWSResourceManager, error) {
+	rm, err := f.AWSResourceManagerFactory.ManagerFor(
+		cfg, Configure(clientcfg, string(id)), log, metrics, rr, id, region, roleARN,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &manager{rm}, nil
+}
+
+// manager requeues the reconciliations throttled by the API
+type manager struct {
+	acktypes.AWSResourceManager
+}
+
+// ReadOne implements acktypes.AWSResourceManager
+func (rm *manager) ReadOne(ctx context.Context, res acktypes.AWSResource) (acktypes.AWSResource, error) {
+	latest, err := rm.AWSResourceManager.ReadOne(ctx, res)
+	return latest, requeueIfThrottled(err)
+}
+
+// Create implements acktypes.AWSResourceManager
+func (rm *manager) Create(ctx context.Context, res acktypes.AWSResource) (acktypes.AWSResource, error) {
+	created, err := rm.AWSResourceManager.Create(ctx, res)
+	return created, requeueIfThrottled(err)
+}
+
+// Update implements acktypes.AWSResourceManager
+func (rm *manager) Update(
+	ctx context.Context,
+	desired acktypes.AWSResource,
+	latest acktypes.AWSResource,
+	delta *ackcompare.Delta,
+) (acktypes.AWSResource, error) {
+	updated, err := rm.AWSResourceManager.Update(ctx, desired, latest, delta)
+	return updated, requeueIfThrottled(err)
+}
+
+// Delete implements acktypes.AWSResourceManager
+func (rm *manager) Delete(ctx context.Context, res acktypes.AWSResource) (acktypes.AWSResource, error) {
+	latest, err := rm.AWSResourceManager.Delete(ctx, res)
+	return latest, requeueIfThrottled(err)
+}
+
+// requeueIfThrottled returns a requeue with jitter for throttling errors, so
+// that throttled reconciliations are spread out
+func requeueIfThrottled(err error) error {
+	if !IsThrottle(err) {
+		return err
+	}
+	jitter := time.Duration(0)
+	if requeueDelay > 0 {
+		jitter = rand.N(requeueDelay)
+	}
+	return ackrequeue.NeededAfter(err, requeueDelay+jitter)
+}
@@ -0,0 +1,240 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+// Package ratelimit limits the rate of the EventBridge API calls of the
+// resource managers, which share a token bucket per account, Region and API
+// operation.
+// The rate of an operation is lowered when it is throttled, and raised back
+// to its limit as calls succeed. Reconciliations which are throttled anyway
+// are requeued with jitter, instead of failing.
+package ratelimit
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	awsmiddleware "github.com/aws/aws-sdk-go-v2/aws/middleware"
+	"github.com/aws/aws-sdk-go-v2/aws/retry"
+	"github.com/aws/smithy-go/middleware"
+	flag "github.com/spf13/pflag"
+	"golang.org/x/time/rate"
+
+	"github.com/aws-controllers-k8s/eventbridge-controller/pkg/metrics"
+)
+
+const (
+	flagRate       = "api-rate-limit"
+	flagBurst      = "api-rate-limit-burst"
+	flagOperations = "api-rate-limits"
+
+	middlewareID = "EventBridgeControllerRateLimit"
+
+	// decreaseFactor lowers the rate of an operation when it is throttled
+	decreaseFactor = 0.5
+	// increaseFraction of the limit raises the rate of an operation when a
+	// call succeeds
+	increaseFraction = 0.1
+	// minFraction of the limit is the lowest rate of an operation
+	minFraction = 0.05
+)
+
+// operationLimits are the rate limits of API operations, in requests per
+// second, e.g. PutTargets=5
+type operationLimits map[string]float64
+
+func (l operationLimits) String() string {
+	ops := make([]string, 0, len(l))
+	for op, r := range l {
+		ops = append(ops, op+"="+strconv.FormatFloat(r, 'f', -1, 64))
+	}
+	sort.Strings(ops)
+	return strings.Join(ops, ",")
+}
+
+func (l operationLimits) Set(value string) error {
+	for _, kv := range strings.Split(value, ",") {
+		if kv = strings.TrimSpace(kv); kv == "" {
+			continue
+		}
+		op, r, ok := strings.Cut(kv, "=")
+		if !ok {
+			return fmt.Errorf("invalid rate limit %q, must be Operation=rate", kv)
+		}
+		limit, err := strconv.ParseFloat(r, 64)
+		if err != nil || limit < 0 {
+			return fmt.Errorf("invalid rate limit %q, rate must be a number greater than or equal to 0", kv)
+		}
+		l[strings.TrimSpace(op)] = limit
+	}
+	return nil
+}
+
+func (l operationLimits) Type() string {
+	return "Operation=rate,..."
+}
+
+// config is set by the controller flags
+var config = struct {
+	rate       float64
+	burst      int
+	operations operationLimits
+}{
+	rate:       10,
+	burst:      20,
+	operations: operationLimits{},
+}
+
+func init() {
+	flag.Float64Var(
+		&config.rate, flagRate, config.rate,
+		"The maximum rate of each EventBridge API operation in an account and Region, in requests per second. 0 disables the limit.",
+	)
+	flag.IntVar(
+		&config.burst, flagBurst, config.burst,
+		"The number of requests of an API operation which can be sent at once, above its rate.",
+	)
+	flag.Var(
+		config.operations, flagOperations,
+		"The maximum rates of API operations overriding --api-rate-limit, e.g. PutTargets=5,ListTargetsByRule=20.",
+	)
+}
+
+// limiterKey identifies the limiter of an operation. EventBridge applies its
+// quotas per account and Region, so the resource managers of other accounts
+// don't share their limiters.
+type limiterKey struct {
+	accountID, region, operation string
+}
+
+var (
+	limitersMu sync.Mutex
+	limiters   = map[limiterKey]*adaptiveLimiter{}
+)
+
+// limiterFor returns the shared limiter of the operation in the account and
+// Region, or nil if the operation isn't limited
+func limiterFor(accountID, region, operation string) *adaptiveLimiter {
+	limit := config.rate
+	if r, ok := config.operations[operation]; ok {
+		limit = r
+	}
+	if limit <= 0 {
+		return nil
+	}
+
+	limitersMu.Lock()
+	defer limitersMu.Unlock()
+	key := limiterKey{accountID, region, operation}
+	l, ok := limiters[key]
+	if !ok {
+		l = newAdaptiveLimiter(rate.Limit(limit), max(config.burst, 1))
+		limiters[key] = l
+	}
+	return l
+}
+
+// adaptiveLimiter is a token bucket whose rate is lowered when requests are
+// throttled, and raised back to its limit as requests succeed
+type adaptiveLimiter struct {
+	limit rate.Limit
+
+	mu      sync.Mutex
+	limiter *rate.Limiter
+}
+
+func newAdaptiveLimiter(limit rate.Limit, burst int) *adaptiveLimiter {
+	return &adaptiveLimiter{
+		limit:   limit,
+		limiter: rate.NewLimiter(limit, burst),
+	}
+}
+
+func (l *adaptiveLimiter) wait(ctx context.Context) error {
+	return l.limiter.Wait(ctx)
+}
+
+func (l *adaptiveLimiter) throttled() {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.limiter.SetLimit(max(l.limiter.Limit()*decreaseFactor, l.limit*minFraction))
+}
+
+func (l *adaptiveLimiter) succeeded() {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if current := l.limiter.Limit(); current < l.limit {
+		l.limiter.SetLimit(min(current+l.limit*increaseFraction, l.limit))
+	}
+}
+
+// Configure returns a copy of the client configuration of the account whose
+// API calls are rate limited
+func Configure(cfg aws.Config, accountID string) aws.Config {
+	cfg.APIOptions = append(append([]func(*middleware.Stack) error(nil), cfg.APIOptions...), addMiddleware(accountID))
+	return cfg
+}
+
+// addMiddleware returns a function which adds the rate limit of the account
+// to each attempt of an API call, after it is retried but before it is
+// signed
+func addMiddleware(accountID string) func(*middleware.Stack) error {
+	return func(stack *middleware.Stack) error {
+		mw := middleware.FinalizeMiddlewareFunc(middlewareID, func(
+			ctx context.Context,
+			in middleware.FinalizeInput,
+			next middleware.FinalizeHandler,
+		) (middleware.FinalizeOutput, middleware.Metadata, error) {
+			return handleFinalize(ctx, accountID, in, next)
+		})
+		if _, ok := stack.Finalize.Get("Retry"); ok {
+			return stack.Finalize.Insert(mw, "Retry", middleware.After)
+		}
+		return stack.Finalize.Add(mw, middleware.Before)
+	}
+}
+
+func handleFinalize(
+	ctx context.Context,
+	accountID string,
+	in middleware.FinalizeInput,
+	next middleware.FinalizeHandler,
+) (middleware.FinalizeOutput, middleware.Metadata, error) {
+	operation := awsmiddleware.GetOperationName(ctx)
+	l := limiterFor(accountID, awsmiddleware.GetRegion(ctx), operation)
+	if l == nil {
+		return next.HandleFinalize(ctx, in)
+	}
+	if err := l.wait(ctx); err != nil {
+		return middleware.FinalizeOutput{}, middleware.Metadata{}, err
+	}
+	out, md, err := next.HandleFinalize(ctx, in)
+	switch {
+	case IsThrottle(err):
+		metrics.RecordThrottledRequest(operation)
+		l.throttled()
+	case err == nil:
+		l.succeeded()
+	}
+	return out, md, err
+}
+
+// IsThrottle returns true if the error is an API throttling error, e.g. a
+// ThrottlingException
+func IsThrottle(err error) bool {
+	return err != nil && retry.IsErrorThrottles(retry.DefaultThrottles).IsErrorThrottle(err) == aws.TrueTernary
+}
@@ -0,0 +1,139 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package ratelimit
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	ackrequeue "github.com/aws-controllers-k8s/runtime/pkg/requeue"
+	"github.com/aws/aws-sdk-go-v2/aws"
+	svcsdk "github.com/aws/aws-sdk-go-v2/service/eventbridge"
+	"golang.org/x/time/rate"
+	"gotest.tools/v3/assert"
+
+	"github.com/aws-controllers-k8s/eventbridge-controller/pkg/testutil"
+)
+
+func Test_operationLimits_Set(t *testing.T) {
+	tests := []struct {
+		name    string
+		value   string
+		want    operationLimits
+		wantErr string
+	}{
+		{
+			name:  "limits",
+			value: "PutTargets=5, ListTargetsByRule=0.5,",
+			want:  operationLimits{"PutTargets": 5, "ListTargetsByRule": 0.5},
+		},
+		{
+			name:    "missing rate",
+			value:   "PutTargets",
+			wantErr: `invalid rate limit "PutTargets"`,
+		},
+		{
+			name:    "negative rate",
+			value:   "PutTargets=-1",
+			wantErr: "rate must be a number greater than or equal to 0",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := operationLimits{}
+			err := got.Set(tt.value)
+			if tt.wantErr != "" {
+				assert.ErrorContains(t, err, tt.wantErr)
+				return
+			}
+			assert.NilError(t, err)
+			assert.DeepEqual(t, got, tt.want)
+		})
+	}
+}
+
+func Test_adaptiveLimiter(t *testing.T) {
+	l := newAdaptiveLimiter(10, 1)
+	l.throttled()
+	assert.Equal(t, l.limiter.Limit(), rate.Limit(5))
+	for i := 0; i < 10; i++ {
+		l.throttled()
+	}
+	assert.Equal(t, l.limiter.Limit(), rate.Limit(0.5), "lowered to the minimum")
+
+	l.succeeded()
+	assert.Equal(t, l.limiter.Limit(), rate.Limit(1.5))
+	for i := 0; i < 10; i++ {
+		l.succeeded()
+	}
+	assert.Equal(t, l.limiter.Limit(), rate.Limit(10), "raised to the limit")
+}
+
+func Test_limiterFor(t *testing.T) {
+	defer func() { limiters = map[limiterKey]*adaptiveLimiter{} }()
+	config.operations["PutTargets"] = 5
+	config.operations["ListRules"] = 0
+	defer func() { config.operations = operationLimits{} }()
+
+	const account, other = "111111111111", "222222222222"
+	assert.Equal(t, limiterFor(account, "us-west-2", "PutTargets").limit, rate.Limit(5))
+	assert.Equal(t, limiterFor(account, "us-west-2", "PutRule").limit, rate.Limit(config.rate))
+	assert.Assert(t, limiterFor(account, "us-west-2", "ListRules") == nil)
+	assert.Assert(t, limiterFor(account, "us-west-2", "PutRule") == limiterFor(account, "us-west-2", "PutRule"), "shared")
+	assert.Assert(t, limiterFor(account, "us-west-2", "PutRule") != limiterFor(account, "us-east-1", "PutRule"))
+	assert.Assert(t, limiterFor(account, "us-west-2", "PutRule") != limiterFor(other, "us-west-2", "PutRule"))
+}
+
+func TestConfigure(t *testing.T) {
+	defer func() { limiters = map[limiterKey]*adaptiveLimiter{} }()
+	ctx := context.Background()
+	fake := testutil.NewEventBridge()
+	client := svcsdk.NewFromConfig(Configure(fake.Config(), testutil.DefaultAccountID), func(o *svcsdk.Options) {
+		o.RetryMaxAttempts = 1
+	})
+
+	fake.FailNext("DescribeEventBus", "ThrottlingException", "Rate exceeded")
+	_, err := client.DescribeEventBus(ctx, &svcsdk.DescribeEventBusInput{})
+	assert.Assert(t, IsThrottle(err))
+
+	l := limiterFor(testutil.DefaultAccountID, testutil.DefaultRegion, "DescribeEventBus")
+	assert.Equal(t, l.limiter.Limit(), rate.Limit(config.rate)*decreaseFactor)
+	// the limiter of another account isn't lowered
+	assert.Equal(t, limiterFor("222222222222", testutil.DefaultRegion, "DescribeEventBus").limiter.Limit(), rate.Limit(config.rate))
+
+	_, err = client.DescribeEventBus(ctx, &svcsdk.DescribeEventBusInput{Name: aws.String("default")})
+	assert.NilError(t, err)
+	assert.Assert(t, l.limiter.Limit() > rate.Limit(config.rate)*decreaseFactor)
+}
+
+func Test_requeueIfThrottled(t *testing.T) {
+	ctx := context.Background()
+	fake := testutil.NewEventBridge()
+	client := svcsdk.NewFromConfig(fake.Config(), func(o *svcsdk.Options) {
+		o.RetryMaxAttempts = 1
+	})
+	fake.FailNext("DescribeEventBus", "ThrottlingException", "Rate exceeded")
+	_, throttled := client.DescribeEventBus(ctx, &svcsdk.DescribeEventBusInput{})
+
+	err := requeueIfThrottled(throttled)
+	var requeue *ackrequeue.RequeueNeededAfter
+	assert.Assert(t, errors.As(err, &requeue))
+	assert.Assert(t, requeue.Duration() >= requeueDelay && requeue.Duration() < 2*requeueDelay)
+	assert.Equal(t, requeue.Unwrap(), throttled)
+
+	other := errors.New("other")
+	assert.Equal(t, requeueIfThrottled(other), other)
+	assert.NilError(t, requeueIfThrottled(nil))
+}
@@ -0,0 +1,140 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+// Package references resolves the references to resources of other ACK
+// controllers whose APIs aren't dependencies of this controller, e.g. the
+// HealthChecks of the route53 controller. The resources are read as
+// unstructured objects and checked like the generated references.go files
+// check the resources of the controllers this controller depends on.
+package references
+
+import (
+	"context"
+	"fmt"
+
+	ackv1alpha1 "github.com/aws-controllers-k8s/runtime/apis/core/v1alpha1"
+	ackerr "github.com/aws-controllers-k8s/runtime/pkg/errors"
+	ackrt "github.com/aws-controllers-k8s/runtime/pkg/runtime"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	rtclient "sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// Field is a field of a referenced resource which a reference resolves to
+type Field struct {
+	// Name is the name of the field in errors, e.g. Status.ID
+	Name string
+	// Path is the path of the field in the unstructured object
+	Path []string
+}
+
+// ARN is the ARN of a referenced resource
+var ARN = Field{
+	Name: "Status.ACKResourceMetadata.ARN",
+	Path: []string{"status", "ackResourceMetadata", "arn"},
+}
+
+// Reference is a reference of a resource to a resource of another controller
+type Reference struct {
+	// Kind is the kind of the referenced resource
+	Kind schema.GroupVersionKind
+	// Field is the name of the reference field in errors, e.g.
+	// RoutingConfig.FailoverConfig.Primary.HealthCheckRef
+	Field string
+	// From is the reference
+	From *ackv1alpha1.AWSResourceReference
+	// Target is the field of the referenced resource the reference
+	// resolves to
+	Target Field
+}
+
+// ResolveConfig is the configuration of the resource whose references are
+// resolved
+type ResolveConfig struct {
+	// EnableCrossNamespace is the controller configuration allowing
+	// references to other namespaces
+	EnableCrossNamespace bool
+	// Namespace is the namespace of the resource
+	Namespace string
+	// Conditions are the conditions of the resource
+	Conditions *[]*ackv1alpha1.Condition
+}
+
+// Resolve reads the resource referenced by the given resource and returns
+// the value of the target field of the reference. The referenced resource
+// must be synced. References to other namespaces are only resolved when the
+// controller enables them, which is recorded in the conditions of the
+// resource.
+func Resolve(
+	ctx context.Context,
+	reader rtclient.Reader,
+	cfg ResolveConfig,
+	ref Reference,
+) (string, error) {
+	if ref.From == nil || ref.From.Name == nil || *ref.From.Name == "" {
+		return "", fmt.Errorf("provided resource reference is nil or empty: %s", ref.Field)
+	}
+	name := *ref.From.Name
+	namespace, err := ackrt.ResolveCrossNamespaceReference(
+		ctx,
+		cfg.EnableCrossNamespace,
+		cfg.Conditions,
+		ackrt.CrossNamespaceRefKindResource,
+		cfg.Namespace,
+		ref.From.Namespace,
+		name,
+	)
+	if err != nil {
+		return "", err
+	}
+	if reader == nil {
+		return "", fmt.Errorf("no client to read %ss", ref.Kind.Kind)
+	}
+	obj := &unstructured.Unstructured{}
+	obj.SetGroupVersionKind(ref.Kind)
+	if err := reader.Get(ctx, rtclient.ObjectKey{Namespace: namespace, Name: name}, obj); err != nil {
+		return "", err
+	}
+	if err := checkSynced(obj, ref.Kind.Kind, namespace, name); err != nil {
+		return "", err
+	}
+	value, _, _ := unstructured.NestedString(obj.Object, ref.Target.Path...)
+	if value == "" {
+		return "", ackerr.ResourceReferenceMissingTargetFieldFor(ref.Kind.Kind, namespace, name, ref.Target.Name)
+	}
+	return value, nil
+}
+
+// checkSynced returns an error unless the referenced resource is synced. A
+// terminal resource is never synced.
+func checkSynced(obj *unstructured.Unstructured, kind, namespace, name string) error {
+	conditions, _, _ := unstructured.NestedSlice(obj.Object, "status", "conditions")
+	var synced bool
+	for _, c := range conditions {
+		cond, ok := c.(map[string]interface{})
+		if !ok || cond["status"] != string(corev1.ConditionTrue) {
+			continue
+		}
+		switch cond["type"] {
+		case string(ackv1alpha1.ConditionTypeTerminal):
+			return ackerr.ResourceReferenceTerminalFor(kind, namespace, name)
+		case string(ackv1alpha1.ConditionTypeResourceSynced):
+			synced = true
+		}
+	}
+	if !synced {
+		return ackerr.ResourceReferenceNotSyncedFor(kind, namespace, name)
+	}
+	return nil
+}
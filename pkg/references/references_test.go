@@ -0,0 +1,128 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package references
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	ackv1alpha1 "github.com/aws-controllers-k8s/runtime/apis/core/v1alpha1"
+	ackerr "github.com/aws-controllers-k8s/runtime/pkg/errors"
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"gotest.tools/v3/assert"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	ctrlrtfake "sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+var logGroupGVK = schema.GroupVersionKind{
+	Group:   "cloudwatchlogs.services.k8s.aws",
+	Version: "v1alpha1",
+	Kind:    "LogGroup",
+}
+
+func newLogGroup(namespace, name, arn string, conditions ...ackv1alpha1.ConditionType) *unstructured.Unstructured {
+	obj := &unstructured.Unstructured{}
+	obj.SetGroupVersionKind(logGroupGVK)
+	obj.SetNamespace(namespace)
+	obj.SetName(name)
+	var conds []interface{}
+	for _, c := range conditions {
+		conds = append(conds, map[string]interface{}{"type": string(c), "status": "True"})
+	}
+	obj.Object["status"] = map[string]interface{}{
+		"ackResourceMetadata": map[string]interface{}{"arn": arn},
+		"conditions":          conds,
+	}
+	return obj
+}
+
+func Test_Resolve(t *testing.T) {
+	const arn = "arn:aws:logs:us-west-2:123456789012:log-group:orders"
+	kc := ctrlrtfake.NewClientBuilder().WithObjects(
+		newLogGroup("default", "synced", arn, ackv1alpha1.ConditionTypeResourceSynced),
+		newLogGroup("default", "creating", ""),
+		newLogGroup("default", "terminal", arn, ackv1alpha1.ConditionTypeTerminal, ackv1alpha1.ConditionTypeResourceSynced),
+		newLogGroup("default", "no-arn", "", ackv1alpha1.ConditionTypeResourceSynced),
+		newLogGroup("other", "synced", arn, ackv1alpha1.ConditionTypeResourceSynced),
+	).Build()
+
+	tests := []struct {
+		name           string
+		from           *ackv1alpha1.AWSResourceReference
+		crossNamespace bool
+		want           string
+		wantErr        error
+	}{
+		{
+			name: "synced",
+			from: &ackv1alpha1.AWSResourceReference{Name: aws.String("synced")},
+			want: arn,
+		},
+		{
+			name:    "empty reference",
+			from:    &ackv1alpha1.AWSResourceReference{},
+			wantErr: errors.New("provided resource reference is nil or empty: LogGroupRefs"),
+		},
+		{
+			name:    "not synced",
+			from:    &ackv1alpha1.AWSResourceReference{Name: aws.String("creating")},
+			wantErr: ackerr.ResourceReferenceNotSynced,
+		},
+		{
+			name:    "terminal",
+			from:    &ackv1alpha1.AWSResourceReference{Name: aws.String("terminal")},
+			wantErr: ackerr.ResourceReferenceTerminal,
+		},
+		{
+			name:    "missing target field",
+			from:    &ackv1alpha1.AWSResourceReference{Name: aws.String("no-arn")},
+			wantErr: ackerr.ResourceReferenceMissingTargetField,
+		},
+		{
+			name:           "other namespace",
+			from:           &ackv1alpha1.AWSResourceReference{Name: aws.String("synced"), Namespace: aws.String("other")},
+			crossNamespace: true,
+			want:           arn,
+		},
+		{
+			name:    "other namespace disabled",
+			from:    &ackv1alpha1.AWSResourceReference{Name: aws.String("synced"), Namespace: aws.String("other")},
+			wantErr: ackerr.ResourceReferenceCrossNamespaceNotAllowed,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var conditions []*ackv1alpha1.Condition
+			got, err := Resolve(context.Background(), kc, ResolveConfig{
+				EnableCrossNamespace: tt.crossNamespace,
+				Namespace:            "default",
+				Conditions:           &conditions,
+			}, Reference{
+				Kind:   logGroupGVK,
+				Field:  "LogGroupRefs",
+				From:   tt.from,
+				Target: ARN,
+			})
+			if tt.wantErr != nil {
+				assert.Assert(t, err != nil)
+				assert.Assert(t, errors.Is(err, tt.wantErr) || err.Error() == tt.wantErr.Error(), "got %v", err)
+				return
+			}
+			assert.NilError(t, err)
+			assert.Equal(t, got, tt.want)
+		})
+	}
+}
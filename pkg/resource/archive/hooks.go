@@ -18,10 +18,12 @@ import (
 	"fmt"
 
 	ackrequeue "github.com/aws-controllers-k8s/runtime/pkg/requeue"
+	"github.com/aws/aws-sdk-go-v2/aws"
 	svcsdk "github.com/aws/aws-sdk-go-v2/service/eventbridge"
 	svcsdktypes "github.com/aws/aws-sdk-go-v2/service/eventbridge/types"
 
 	"github.com/aws-controllers-k8s/eventbridge-controller/apis/v1alpha1"
+	"github.com/aws-controllers-k8s/eventbridge-controller/pkg/metrics"
 )
 
 // TerminalStatuses are the status strings that are terminal states for an
@@ -103,3 +105,19 @@ func unsetRemovedSpecFields(
 		input.RetentionDays = nil
 	}
 }
+
+// Validate runs the validations the controller applies to an Archive before
+// creating it, without calling AWS
+func Validate(ko *v1alpha1.Archive) error {
+	return validateReferenceFields(ko)
+}
+
+// observeState reports the state of the archive in the controller metrics.
+// Archives which are being deleted are no longer reported.
+func observeState(ko *v1alpha1.Archive) {
+	if ko.DeletionTimestamp != nil {
+		metrics.Forget(metrics.KindArchive, ko.Namespace, ko.Name)
+		return
+	}
+	metrics.ObserveState(metrics.KindArchive, ko.Namespace, ko.Name, aws.ToString(ko.Status.State))
+}
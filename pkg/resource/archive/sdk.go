@@ -140,6 +140,7 @@ func (rm *resourceManager) sdkFind(
 	}
 
 	rm.setStatusDefaults(ko)
+	observeState(ko)
 	return &resource{ko}, nil
 }
 
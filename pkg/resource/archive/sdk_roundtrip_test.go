@@ -0,0 +1,58 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package archive
+
+import (
+	"context"
+	"fmt"
+	"math/rand"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/google/go-cmp/cmp"
+
+	svcapitypes "github.com/aws-controllers-k8s/eventbridge-controller/apis/v1alpha1"
+	"github.com/aws-controllers-k8s/eventbridge-controller/pkg/testutil"
+)
+
+func Test_resourceManager_specRoundTrip(t *testing.T) {
+	ctx := context.Background()
+	rm := newTestResourceManager(t, testutil.NewEventBridge(testutil.WithTransitionReads(0)))
+
+	testutil.CheckProperty(t, func(rnd *rand.Rand) error {
+		var spec svcapitypes.ArchiveSpec
+		testutil.Fill(&spec, rnd)
+		spec.EventSourceRef = nil
+		spec.Name = aws.String(testutil.RandomString(rnd, 16))
+		spec.EventSourceARN = aws.String("arn:aws:events:us-west-2:123456789012:event-bus/default")
+		spec.EventPattern = aws.String(fmt.Sprintf(`{"source":[%q]}`, testutil.RandomString(rnd, 8)))
+
+		created, err := rm.sdkCreate(ctx, &resource{ko: &svcapitypes.Archive{Spec: spec}})
+		if err != nil {
+			return err
+		}
+		latest, err := rm.sdkFind(ctx, created)
+		if err != nil {
+			return err
+		}
+		if _, err := rm.sdkDelete(ctx, latest); err != nil {
+			return err
+		}
+
+		if diff := cmp.Diff(spec, latest.ko.Spec); diff != "" {
+			return fmt.Errorf("spec differs (-want +got):\n%s", diff)
+		}
+		return nil
+	})
+}
@@ -0,0 +1,129 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package archive
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	ackcfg "github.com/aws-controllers-k8s/runtime/pkg/config"
+	ackerr "github.com/aws-controllers-k8s/runtime/pkg/errors"
+	ackmetrics "github.com/aws-controllers-k8s/runtime/pkg/metrics"
+	ackrequeue "github.com/aws-controllers-k8s/runtime/pkg/requeue"
+	"github.com/aws/aws-sdk-go-v2/aws"
+	svcsdktypes "github.com/aws/aws-sdk-go-v2/service/eventbridge/types"
+	"github.com/go-logr/logr"
+	"gotest.tools/v3/assert"
+
+	svcapitypes "github.com/aws-controllers-k8s/eventbridge-controller/apis/v1alpha1"
+	"github.com/aws-controllers-k8s/eventbridge-controller/pkg/testutil"
+)
+
+func newTestResourceManager(t *testing.T, fake *testutil.EventBridge) *resourceManager {
+	t.Helper()
+	rm, err := newResourceManager(
+		ackcfg.Config{}, fake.Config(), logr.Discard(),
+		ackmetrics.NewMetrics("eventbridge"), nil,
+		testutil.DefaultAccountID, testutil.DefaultRegion,
+	)
+	assert.NilError(t, err)
+	return rm
+}
+
+func Test_resourceManager_lifecycle(t *testing.T) {
+	ctx := context.Background()
+	fake := testutil.NewEventBridge()
+	rm := newTestResourceManager(t, fake)
+
+	desired := &resource{ko: &svcapitypes.Archive{
+		Spec: svcapitypes.ArchiveSpec{
+			Name:           aws.String("test-archive"),
+			EventSourceARN: aws.String("arn:aws:events:us-west-2:123456789012:event-bus/default"),
+			RetentionDays:  aws.Int64(1),
+		},
+	}}
+
+	_, err := rm.sdkFind(ctx, desired)
+	assert.Equal(t, err, ackerr.NotFound)
+
+	created, err := rm.sdkCreate(ctx, desired)
+	assert.NilError(t, err)
+	assert.Equal(t, aws.ToString(created.ko.Status.State), string(svcsdktypes.ArchiveStateCreating))
+
+	latest, err := rm.sdkFind(ctx, created)
+	assert.NilError(t, err)
+	assert.Equal(t, aws.ToString(latest.ko.Status.State), string(svcsdktypes.ArchiveStateCreating))
+
+	// updates are requeued until the archive is available
+	desired = latest.DeepCopy().(*resource)
+	desired.ko.Spec.RetentionDays = aws.Int64(7)
+	_, err = rm.sdkUpdate(ctx, desired, latest, newResourceDelta(desired, latest))
+	var requeue *ackrequeue.RequeueNeededAfter
+	assert.Assert(t, errors.As(err, &requeue))
+
+	latest, err = rm.sdkFind(ctx, latest)
+	assert.NilError(t, err)
+	assert.Equal(t, aws.ToString(latest.ko.Status.State), string(svcsdktypes.ArchiveStateEnabled))
+
+	_, err = rm.sdkUpdate(ctx, desired, latest, newResourceDelta(desired, latest))
+	assert.NilError(t, err)
+	fake.Settle()
+
+	latest, err = rm.sdkFind(ctx, latest)
+	assert.NilError(t, err)
+	assert.Equal(t, aws.ToInt64(latest.ko.Spec.RetentionDays), int64(7))
+
+	_, err = rm.sdkDelete(ctx, latest)
+	assert.NilError(t, err)
+	_, err = rm.sdkFind(ctx, latest)
+	assert.Equal(t, err, ackerr.NotFound)
+}
+
+func Test_resourceManager_terminalState(t *testing.T) {
+	ctx := context.Background()
+	fake := testutil.NewEventBridge()
+	rm := newTestResourceManager(t, fake)
+
+	desired := &resource{ko: &svcapitypes.Archive{
+		Spec: svcapitypes.ArchiveSpec{
+			Name:           aws.String("test-archive"),
+			EventSourceARN: aws.String("arn:aws:events:us-west-2:123456789012:event-bus/default"),
+		},
+	}}
+	created, err := rm.sdkCreate(ctx, desired)
+	assert.NilError(t, err)
+	fake.SetArchiveState("test-archive", svcsdktypes.ArchiveStateCreateFailed, "failed")
+
+	latest, err := rm.sdkFind(ctx, created)
+	assert.NilError(t, err)
+	assert.Assert(t, archiveInTerminalState(latest))
+}
+
+func Test_resourceManager_ARNFromName(t *testing.T) {
+	rm := newTestResourceManager(t, testutil.NewEventBridge())
+	rm.awsPartition = "aws"
+	tests := []struct {
+		name string
+		in   string
+		want string
+	}{
+		{name: "archive", in: "orders", want: "arn:aws:events:us-west-2:123456789012:archive/orders"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, rm.ARNFromName(tt.in), tt.want)
+		})
+	}
+}
@@ -25,10 +25,12 @@ import (
 	"github.com/aws/aws-sdk-go-v2/aws/arn"
 	"golang.org/x/exp/slices"
 
+	"github.com/aws/aws-sdk-go-v2/aws"
 	svcsdk "github.com/aws/aws-sdk-go-v2/service/eventbridge"
 	svcsdktypes "github.com/aws/aws-sdk-go-v2/service/eventbridge/types"
 
 	"github.com/aws-controllers-k8s/eventbridge-controller/apis/v1alpha1"
+	"github.com/aws-controllers-k8s/eventbridge-controller/pkg/metrics"
 	"github.com/aws-controllers-k8s/eventbridge-controller/pkg/tags"
 )
 
@@ -94,7 +96,7 @@ func validateEventBus(spec v1alpha1.EndpointSpec) error {
 	}
 
 	// event bus names must be identical
-	arns := make([]string, 2)
+	arns := make([]arn.ARN, 2)
 	for i, b := range spec.EventBuses {
 		if b.EventBusARN == nil {
 			return newValidationError("spec.eventBuses", "event bus arn must be set")
@@ -103,12 +105,24 @@ func validateEventBus(spec v1alpha1.EndpointSpec) error {
 		if err != nil {
 			return newValidationError("spec.eventBuses", fmt.Sprintf("invalid arn %q", *b.EventBusARN))
 		}
-		arns[i] = arnInfo.Resource
+		arns[i] = arnInfo
 	}
 
-	if arns[0] != arns[1] {
+	if arns[0].Resource != arns[1].Resource {
 		return newValidationError("spec.eventBuses", "event bus names must be identical")
 	}
+	if arns[0].Region == arns[1].Region {
+		return newValidationError("spec.eventBuses", "event buses must be in different Regions")
+	}
+
+	// events fail over to the event bus in the secondary Region
+	route := secondaryRegion(&v1alpha1.Endpoint{Spec: spec})
+	if route != "" && route != arns[0].Region && route != arns[1].Region {
+		return newValidationError(
+			"spec.routingConfig.failoverConfig.secondary.route",
+			fmt.Sprintf("must be the Region of one of the event buses, got %q", route),
+		)
+	}
 	return nil
 }
 
@@ -198,6 +212,8 @@ func customPreCompare(
 	if !equalEventBusConfigs(aBusCfg, bBusCfg) {
 		delta.Add("Spec.EventBuses", aReplCfg, bReplCfg)
 	}
+
+	compareFailoverDrill(delta, a)
 }
 
 func equalEventBusConfigs(a, b []*v1alpha1.EndpointEventBus) bool {
@@ -225,3 +241,22 @@ func equalReplicationConfigs(a, b *v1alpha1.ReplicationConfig) bool {
 
 	return false
 }
+
+// Validate runs the validations the controller applies to an Endpoint before
+// creating it, without calling AWS
+func Validate(ko *v1alpha1.Endpoint) error {
+	if err := validateReferenceFields(ko); err != nil {
+		return err
+	}
+	return validateEndpointSpec(nil, ko.Spec)
+}
+
+// observeState reports the state of the endpoint in the controller metrics.
+// Endpoints which are being deleted are no longer reported.
+func observeState(ko *v1alpha1.Endpoint) {
+	if ko.DeletionTimestamp != nil {
+		metrics.Forget(metrics.KindEndpoint, ko.Namespace, ko.Name)
+		return
+	}
+	metrics.ObserveState(metrics.KindEndpoint, ko.Namespace, ko.Name, aws.ToString(ko.Status.State))
+}
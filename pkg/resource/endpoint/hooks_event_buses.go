@@ -0,0 +1,105 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package endpoint
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/aws/arn"
+	svcsdk "github.com/aws/aws-sdk-go-v2/service/eventbridge"
+	"github.com/aws/smithy-go"
+	flag "github.com/spf13/pflag"
+
+	svcapitypes "github.com/aws-controllers-k8s/eventbridge-controller/apis/v1alpha1"
+)
+
+const flagValidateEventBuses = "endpoint-validate-event-buses"
+
+// validateEventBusesEnabled is set by the controller flag. The check calls
+// DescribeEventBus in the Region of each event bus, so it is opt-in.
+var validateEventBusesEnabled bool
+
+func init() {
+	flag.BoolVar(
+		&validateEventBusesEnabled, flagValidateEventBuses, false,
+		"Check that the event buses of Endpoints exist before creating or updating them. "+
+			"Requires the events:DescribeEventBus permission in the Regions of the event buses.",
+	)
+}
+
+// validateEventBusRegions validates that the primary event bus of the
+// endpoint, which isn't in the secondary Region, is in the Region of the
+// resource manager. Endpoints are created in the Region of their primary event
+// bus, and are stuck in CREATE_FAILED otherwise.
+func (rm *resourceManager) validateEventBusRegions(ko *svcapitypes.Endpoint) error {
+	secondary := secondaryRegion(ko)
+	for _, b := range ko.Spec.EventBuses {
+		parsed, err := arn.Parse(aws.ToString(b.EventBusARN))
+		if err != nil || parsed.Region == secondary {
+			continue
+		}
+		if parsed.Region != string(rm.awsRegion) {
+			return newValidationError(
+				"spec.eventBuses",
+				fmt.Sprintf("primary event bus must be in the Region of the controller %q, got %q", rm.awsRegion, parsed.Region),
+			)
+		}
+	}
+	return nil
+}
+
+// validateEventBusesExist validates that the event buses of the endpoint
+// exist, calling DescribeEventBus in the Region of each event bus. It does
+// nothing unless enabled by the controller flag.
+func (rm *resourceManager) validateEventBusesExist(
+	ctx context.Context,
+	ko *svcapitypes.Endpoint,
+) error {
+	if !validateEventBusesEnabled {
+		return nil
+	}
+	for _, b := range ko.Spec.EventBuses {
+		busARN := aws.ToString(b.EventBusARN)
+		parsed, err := arn.Parse(busARN)
+		if err != nil {
+			return newValidationError("spec.eventBuses", fmt.Sprintf("invalid arn %q", busARN))
+		}
+		_, err = rm.regionClient(parsed.Region).DescribeEventBus(ctx, &svcsdk.DescribeEventBusInput{
+			Name: aws.String(busARN),
+		})
+		rm.metrics.RecordAPICall("READ_ONE", "DescribeEventBus", err)
+		if err != nil {
+			var awsErr smithy.APIError
+			if errors.As(err, &awsErr) && awsErr.ErrorCode() == "ResourceNotFoundException" {
+				return newValidationError("spec.eventBuses", fmt.Sprintf("event bus %q does not exist", busARN))
+			}
+			return err
+		}
+	}
+	return nil
+}
+
+// regionClient returns an EventBridge client for the Region, using the
+// client of the resource manager for its own Region
+func (rm *resourceManager) regionClient(region string) *svcsdk.Client {
+	if region == "" || region == string(rm.awsRegion) {
+		return rm.sdkapi
+	}
+	return svcsdk.NewFromConfig(rm.clientcfg, func(o *svcsdk.Options) {
+		o.Region = region
+	})
+}
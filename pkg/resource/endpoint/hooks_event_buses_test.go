@@ -0,0 +1,73 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package endpoint
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	svcsdk "github.com/aws/aws-sdk-go-v2/service/eventbridge"
+	"gotest.tools/v3/assert"
+
+	"github.com/aws-controllers-k8s/eventbridge-controller/pkg/testutil"
+)
+
+func Test_resourceManager_validateEventBusRegions(t *testing.T) {
+	rm := newTestResourceManager(t, testutil.NewEventBridge())
+
+	// the fake runs in us-west-2, the primary Region of the endpoint
+	ko := newHealthCheckEndpoint("primary")
+	assert.NilError(t, rm.validateEventBusRegions(ko))
+
+	ko.Spec.EventBuses[1].EventBusARN = aws.String("arn:aws:events:eu-west-1:123456789012:event-bus/test-bus")
+	assert.ErrorContains(t, rm.validateEventBusRegions(ko),
+		`primary event bus must be in the Region of the controller "us-west-2", got "eu-west-1"`)
+}
+
+func Test_resourceManager_validateEventBusesExist(t *testing.T) {
+	defer func(enabled bool) { validateEventBusesEnabled = enabled }(validateEventBusesEnabled)
+	ctx := context.Background()
+	fake := testutil.NewEventBridge()
+	rm := newTestResourceManager(t, fake)
+	ko := newHealthCheckEndpoint("primary")
+	// the fake only serves event buses of its own Region
+	ko.Spec.EventBuses[0].EventBusARN = aws.String("arn:aws:events:us-west-2:123456789012:event-bus/test-bus")
+
+	// the check is opt-in
+	validateEventBusesEnabled = false
+	assert.NilError(t, rm.validateEventBusesExist(ctx, ko))
+
+	validateEventBusesEnabled = true
+	assert.ErrorContains(t, rm.validateEventBusesExist(ctx, ko),
+		`event bus "arn:aws:events:us-west-2:123456789012:event-bus/test-bus" does not exist`)
+
+	_, err := rm.sdkapi.CreateEventBus(ctx, &svcsdk.CreateEventBusInput{Name: aws.String("test-bus")})
+	assert.NilError(t, err)
+	assert.NilError(t, rm.validateEventBusesExist(ctx, ko))
+
+	// other errors are retried
+	fake.FailNext("DescribeEventBus", "AccessDeniedException", "not authorized")
+	err = rm.validateEventBusesExist(ctx, ko)
+	assert.ErrorContains(t, err, "not authorized")
+	assert.Assert(t, !errors.As(err, new(validationError)))
+}
+
+func Test_resourceManager_regionClient(t *testing.T) {
+	rm := newTestResourceManager(t, testutil.NewEventBridge())
+	assert.Equal(t, rm.regionClient("us-west-2"), rm.sdkapi)
+	assert.Equal(t, rm.regionClient("us-east-1").Options().Region, "us-east-1")
+	assert.Equal(t, rm.regionClient("").Options().Region, "us-west-2")
+}
@@ -0,0 +1,243 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package endpoint
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	ackcompare "github.com/aws-controllers-k8s/runtime/pkg/compare"
+	ackcondition "github.com/aws-controllers-k8s/runtime/pkg/condition"
+	ackerr "github.com/aws-controllers-k8s/runtime/pkg/errors"
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/aws/arn"
+	svcsdk "github.com/aws/aws-sdk-go-v2/service/eventbridge"
+	svcsdktypes "github.com/aws/aws-sdk-go-v2/service/eventbridge/types"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	rtclient "sigs.k8s.io/controller-runtime/pkg/client"
+
+	svcapitypes "github.com/aws-controllers-k8s/eventbridge-controller/apis/v1alpha1"
+	"github.com/aws-controllers-k8s/eventbridge-controller/pkg/clients"
+	"github.com/aws-controllers-k8s/eventbridge-controller/pkg/events"
+)
+
+const (
+	// AnnotationForceFailover starts a failover drill of an Endpoint. Set it
+	// to FailoverTargetSecondary to route events to the secondary Region
+	// until the drill ends, by replacing the primary health check of the
+	// routing configuration with AnnotationForceFailoverHealthCheck. The
+	// annotations are removed when the drill ends, removing them earlier
+	// ends the drill.
+	AnnotationForceFailover = "eventbridge.services.k8s.aws/force-failover"
+	// AnnotationForceFailoverDuration is the duration of the failover drill,
+	// e.g. "1h". Defaults to defaultFailoverDrillDuration.
+	AnnotationForceFailoverDuration = "eventbridge.services.k8s.aws/force-failover-duration"
+	// AnnotationForceFailoverHealthCheck is the ARN of the Route 53 health
+	// check used as the primary health check during the failover drill. It
+	// must report the primary Region unhealthy, e.g. an inverted health
+	// check. The health check of the Endpoint itself isn't modified, it may
+	// be managed by another controller.
+	AnnotationForceFailoverHealthCheck = "eventbridge.services.k8s.aws/force-failover-health-check"
+	// FailoverTargetSecondary is the AnnotationForceFailover value which fails
+	// over to the secondary Region
+	FailoverTargetSecondary = "secondary"
+
+	defaultFailoverDrillDuration = 30 * time.Minute
+
+	// failoverDrillPath is the delta path added while a failover drill has to
+	// be started, ended or watched, so that the resource manager's Update is
+	// called
+	failoverDrillPath = "Spec.FailoverDrill"
+
+	failoverDrillReason        = "FailoverDrill"
+	failoverDrillStartedReason = "FailoverDrillStarted"
+	failoverDrillEndedReason   = "FailoverDrillEnded"
+)
+
+// now returns the current time, replaced in tests
+var now = time.Now
+
+// failoverDrill is a failover drill requested by the annotations of an
+// Endpoint
+type failoverDrill struct {
+	duration    time.Duration
+	healthCheck string
+}
+
+// failoverDrillRequested returns the failover drill the Endpoint requests, or
+// nil if it requests none
+func failoverDrillRequested(ko *svcapitypes.Endpoint) (*failoverDrill, error) {
+	annotations := ko.GetAnnotations()
+	target, ok := annotations[AnnotationForceFailover]
+	if !ok {
+		return nil, nil
+	}
+	if target != FailoverTargetSecondary {
+		return nil, newValidationError(
+			"metadata.annotations."+AnnotationForceFailover,
+			fmt.Sprintf("unsupported value %q, must be %q", target, FailoverTargetSecondary),
+		)
+	}
+	drill := &failoverDrill{
+		duration:    defaultFailoverDrillDuration,
+		healthCheck: annotations[AnnotationForceFailoverHealthCheck],
+	}
+	if parsed, err := arn.Parse(drill.healthCheck); err != nil || parsed.Service != "route53" {
+		return nil, newValidationError(
+			"metadata.annotations."+AnnotationForceFailoverHealthCheck,
+			fmt.Sprintf("must be the ARN of a Route 53 health check, got %q", drill.healthCheck),
+		)
+	}
+	if s, ok := annotations[AnnotationForceFailoverDuration]; ok {
+		d, err := time.ParseDuration(s)
+		if err != nil || d <= 0 {
+			return nil, newValidationError(
+				"metadata.annotations."+AnnotationForceFailoverDuration,
+				fmt.Sprintf("invalid duration %q", s),
+			)
+		}
+		drill.duration = d
+	}
+	return drill, nil
+}
+
+// failoverDrillInProgress returns whether a failover drill was started and
+// hasn't ended
+func failoverDrillInProgress(ko *svcapitypes.Endpoint) bool {
+	return ko.Status.FailoverDrillStartTime != nil && ko.Status.FailoverDrillEndTime == nil
+}
+
+// compareFailoverDrill adds the failover drill path to the delta when a drill
+// is requested or in progress
+func compareFailoverDrill(delta *ackcompare.Delta, desired *resource) {
+	drill, err := failoverDrillRequested(desired.ko)
+	// invalid annotations are reported by syncFailoverDrill
+	if drill != nil || err != nil || failoverDrillInProgress(desired.ko) {
+		delta.Add(failoverDrillPath, desired.ko.GetAnnotations()[AnnotationForceFailover], nil)
+	}
+}
+
+// syncFailoverDrill starts a requested failover drill, and ends it when its
+// duration elapsed or its annotation was removed. A drill starts by updating
+// the routing configuration of the endpoint with the health check of the
+// drill, and ends once the endpoint is ACTIVE again with the routing
+// configuration of its spec. It returns requeueWaitWhileUpdating while the
+// endpoint is updated. While the drill is in progress ACK.ResourceSynced is
+// False and the other changes of the spec wait for the drill to end.
+func (rm *resourceManager) syncFailoverDrill(
+	ctx context.Context,
+	desired *resource,
+	latest *resource,
+) error {
+	ko := desired.ko
+	drill, err := failoverDrillRequested(ko)
+	if err != nil {
+		return ackerr.NewTerminalError(err)
+	}
+	t := now()
+
+	switch {
+	case drill != nil && !failoverDrillInProgress(ko):
+		if err := rm.updateRoutingConfig(ctx, ko, aws.String(drill.healthCheck)); err != nil {
+			return err
+		}
+		ko.Status.FailoverDrillStartTime = &metav1.Time{Time: t}
+		ko.Status.FailoverDrillEndTime = nil
+		events.Recorder().Eventf(ko, corev1.EventTypeNormal, failoverDrillStartedReason,
+			"failover drill started, events are routed to the secondary Region for %s", drill.duration)
+		setFailoverDrillSynced(desired, t.Add(drill.duration))
+		return requeueWaitWhileUpdating
+	case !failoverDrillInProgress(ko):
+		return nil
+	case drill != nil && t.Before(ko.Status.FailoverDrillStartTime.Add(drill.duration)):
+		setFailoverDrillSynced(desired, ko.Status.FailoverDrillStartTime.Add(drill.duration))
+		return nil
+	case endpointHealthCheckID(latest.ko) != endpointHealthCheckID(ko):
+		if err := rm.updateRoutingConfig(ctx, ko, nil); err != nil {
+			return err
+		}
+		return requeueWaitWhileUpdating
+	}
+
+	// the routing configuration is restored, the annotations are removed
+	// before the drill ends so that adding them again starts another drill
+	if drill != nil {
+		if err := removeFailoverDrillAnnotations(ctx, ko); err != nil {
+			return err
+		}
+	}
+	ko.Status.FailoverDrillEndTime = &metav1.Time{Time: t}
+	events.Recorder().Event(ko, corev1.EventTypeNormal, failoverDrillEndedReason,
+		"failover drill ended, events are routed to the primary Region again")
+	return nil
+}
+
+// setFailoverDrillSynced sets ACK.ResourceSynced to False until the failover
+// drill ends
+func setFailoverDrillSynced(r *resource, end time.Time) {
+	message := fmt.Sprintf("failover drill in progress until %s", end.UTC().Format(time.RFC3339))
+	ackcondition.SetSynced(r, corev1.ConditionFalse, &message, aws.String(failoverDrillReason))
+}
+
+// updateRoutingConfig updates the routing configuration of the endpoint to
+// the one of its spec, with the given primary health check if it isn't nil
+func (rm *resourceManager) updateRoutingConfig(
+	ctx context.Context,
+	ko *svcapitypes.Endpoint,
+	healthCheck *string,
+) error {
+	rc := ko.Spec.RoutingConfig
+	if rc == nil || rc.FailoverConfig == nil || rc.FailoverConfig.Secondary == nil {
+		return ackerr.NewTerminalError(newValidationError(
+			"spec.routingConfig.failoverConfig", "must be set for failover drills",
+		))
+	}
+	if healthCheck == nil {
+		var err error
+		if healthCheck, err = rm.primaryHealthCheck(ctx, ko); err != nil {
+			return err
+		}
+	}
+	_, err := rm.sdkapi.UpdateEndpoint(ctx, &svcsdk.UpdateEndpointInput{
+		Name: ko.Spec.Name,
+		RoutingConfig: &svcsdktypes.RoutingConfig{
+			FailoverConfig: &svcsdktypes.FailoverConfig{
+				Primary:   &svcsdktypes.Primary{HealthCheck: healthCheck},
+				Secondary: &svcsdktypes.Secondary{Route: rc.FailoverConfig.Secondary.Route},
+			},
+		},
+	})
+	rm.metrics.RecordAPICall("UPDATE", "UpdateEndpoint", err)
+	return err
+}
+
+// removeFailoverDrillAnnotations removes the failover drill annotations of the
+// Endpoint. The ACK runtime patches the metadata of the resource returned by
+// Update against the desired resource, so the annotations can't be removed by
+// returning a resource without them.
+func removeFailoverDrillAnnotations(ctx context.Context, ko *svcapitypes.Endpoint) error {
+	c, err := clients.FromContext(ctx)
+	if err != nil {
+		return err
+	}
+	patched := ko.DeepCopy()
+	annotations := patched.GetAnnotations()
+	delete(annotations, AnnotationForceFailover)
+	delete(annotations, AnnotationForceFailoverDuration)
+	delete(annotations, AnnotationForceFailoverHealthCheck)
+	patched.SetAnnotations(annotations)
+	return c.Client.Patch(ctx, patched, rtclient.MergeFrom(ko))
+}
@@ -0,0 +1,245 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package endpoint
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	ackcompare "github.com/aws-controllers-k8s/runtime/pkg/compare"
+	ackcondition "github.com/aws-controllers-k8s/runtime/pkg/condition"
+	ackerr "github.com/aws-controllers-k8s/runtime/pkg/errors"
+	ackrequeue "github.com/aws-controllers-k8s/runtime/pkg/requeue"
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/google/go-cmp/cmp"
+	"gotest.tools/v3/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/record"
+	rtclient "sigs.k8s.io/controller-runtime/pkg/client"
+	ctrlrtfake "sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	svcapitypes "github.com/aws-controllers-k8s/eventbridge-controller/apis/v1alpha1"
+	"github.com/aws-controllers-k8s/eventbridge-controller/pkg/clients"
+	"github.com/aws-controllers-k8s/eventbridge-controller/pkg/events"
+	"github.com/aws-controllers-k8s/eventbridge-controller/pkg/testutil"
+)
+
+const drillHealthCheck = "arn:aws:route53:::healthcheck/drill"
+
+func Test_failoverDrillRequested(t *testing.T) {
+	tests := []struct {
+		name        string
+		annotations map[string]string
+		wantDrill   *failoverDrill
+		wantErr     string
+	}{
+		{
+			name: "no annotation",
+		},
+		{
+			name: "default duration",
+			annotations: map[string]string{
+				AnnotationForceFailover:            "secondary",
+				AnnotationForceFailoverHealthCheck: drillHealthCheck,
+			},
+			wantDrill: &failoverDrill{duration: defaultFailoverDrillDuration, healthCheck: drillHealthCheck},
+		},
+		{
+			name: "duration",
+			annotations: map[string]string{
+				AnnotationForceFailover:            "secondary",
+				AnnotationForceFailoverDuration:    "2h",
+				AnnotationForceFailoverHealthCheck: drillHealthCheck,
+			},
+			wantDrill: &failoverDrill{duration: 2 * time.Hour, healthCheck: drillHealthCheck},
+		},
+		{
+			name: "unsupported target",
+			annotations: map[string]string{
+				AnnotationForceFailover:            "primary",
+				AnnotationForceFailoverHealthCheck: drillHealthCheck,
+			},
+			wantErr: `unsupported value "primary"`,
+		},
+		{
+			name:        "no health check",
+			annotations: map[string]string{AnnotationForceFailover: "secondary"},
+			wantErr:     `must be the ARN of a Route 53 health check, got ""`,
+		},
+		{
+			name: "invalid duration",
+			annotations: map[string]string{
+				AnnotationForceFailover:            "secondary",
+				AnnotationForceFailoverDuration:    "-1m",
+				AnnotationForceFailoverHealthCheck: drillHealthCheck,
+			},
+			wantErr: `invalid duration "-1m"`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ko := newHealthCheckEndpoint("primary")
+			ko.SetAnnotations(tt.annotations)
+			drill, err := failoverDrillRequested(ko)
+			if tt.wantErr != "" {
+				assert.ErrorContains(t, err, tt.wantErr)
+				return
+			}
+			assert.NilError(t, err)
+			assert.DeepEqual(t, drill, tt.wantDrill, cmp.AllowUnexported(failoverDrill{}))
+		})
+	}
+}
+
+func Test_compareFailoverDrill(t *testing.T) {
+	ko := newHealthCheckEndpoint("primary")
+	delta := ackcompare.NewDelta()
+	compareFailoverDrill(delta, &resource{ko})
+	assert.Assert(t, !delta.DifferentAt(failoverDrillPath))
+
+	// a drill in progress is watched after its annotation is removed
+	ko.Status.FailoverDrillStartTime = &metav1.Time{Time: time.Now()}
+	compareFailoverDrill(delta, &resource{ko})
+	assert.Assert(t, delta.DifferentAt(failoverDrillPath))
+
+	delta = ackcompare.NewDelta()
+	ko.Status.FailoverDrillEndTime = &metav1.Time{Time: time.Now()}
+	compareFailoverDrill(delta, &resource{ko})
+	assert.Assert(t, !delta.DifferentAt(failoverDrillPath))
+}
+
+func Test_resourceManager_syncFailoverDrill(t *testing.T) {
+	recorder := record.NewFakeRecorder(10)
+	events.SetRecorder(recorder)
+	defer events.SetRecorder(nil)
+	start := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	defer func(fn func() time.Time) { now = fn }(now)
+	now = func() time.Time { return start }
+
+	ctx := context.Background()
+	fake := testutil.NewEventBridge()
+	rm := newTestResourceManager(t, fake)
+
+	created, err := rm.sdkCreate(ctx, &resource{newHealthCheckEndpoint("arn:aws:route53:::healthcheck/primary")})
+	assert.Equal(t, err, requeueWaitWhileCreating)
+	fake.Settle()
+	// read returns the endpoint once it is no longer being updated
+	read := func() *resource {
+		t.Helper()
+		latest, err := rm.sdkFind(ctx, created)
+		assert.NilError(t, err)
+		return latest
+	}
+	sync := func(desired *resource) (*resource, error) {
+		t.Helper()
+		latest := read()
+		delta := newResourceDelta(desired, latest)
+		updated, err := rm.sdkUpdate(ctx, desired, latest, delta)
+		var requeue *ackrequeue.RequeueNeededAfter
+		if errors.As(err, &requeue) {
+			fake.Settle()
+		}
+		return updated, err
+	}
+
+	desired := read()
+	desired.ko.ObjectMeta = metav1.ObjectMeta{Name: "test-endpoint", Namespace: "default"}
+	desired.ko.SetAnnotations(map[string]string{
+		AnnotationForceFailover:            FailoverTargetSecondary,
+		AnnotationForceFailoverDuration:    "10m",
+		AnnotationForceFailoverHealthCheck: drillHealthCheck,
+	})
+	scheme := runtime.NewScheme()
+	assert.NilError(t, svcapitypes.AddToScheme(scheme))
+	kc := ctrlrtfake.NewClientBuilder().WithScheme(scheme).WithObjects(desired.ko.DeepCopy()).Build()
+	ctx = clients.NewContext(ctx, clients.New(kc, nil))
+
+	// starting the drill routes events with the health check of the drill
+	updated, err := sync(desired)
+	assert.Equal(t, err, requeueWaitWhileUpdating)
+	assert.Equal(t, updated.ko.Status.FailoverDrillStartTime.Time, start)
+	assert.Assert(t, updated.ko.Status.FailoverDrillEndTime == nil)
+	assert.Equal(t, <-recorder.Events,
+		"Normal FailoverDrillStarted failover drill started, events are routed to the secondary Region for 10m0s")
+	assert.Equal(t, endpointHealthCheckID(read().ko), "drill")
+
+	// the drill is in progress until the window elapsed, other changes wait
+	desired.ko.Status = updated.ko.Status
+	desired.ko.Status.Conditions = nil
+	desired.ko.Spec.Description = aws.String("updated")
+	fake.ResetCalls()
+	now = func() time.Time { return start.Add(5 * time.Minute) }
+	_, err = sync(desired)
+	assert.NilError(t, err)
+	synced := ackcondition.Synced(desired)
+	assert.Equal(t, synced.Status, corev1.ConditionFalse)
+	assert.Equal(t, *synced.Message, "failover drill in progress until 2024-01-01T12:10:00Z")
+	assert.DeepEqual(t, fake.Calls(), []string{"DescribeEndpoint"})
+	assert.Equal(t, len(recorder.Events), 0)
+
+	// the routing configuration is restored when the window elapsed
+	now = func() time.Time { return start.Add(10 * time.Minute) }
+	_, err = sync(desired)
+	assert.Equal(t, err, requeueWaitWhileUpdating)
+	assert.Equal(t, endpointHealthCheckID(read().ko), "primary")
+	assert.Assert(t, failoverDrillInProgress(desired.ko))
+
+	// and the drill ends once the endpoint is ACTIVE again, before the other
+	// changes are made
+	_, err = sync(desired)
+	var requeue *ackrequeue.RequeueNeededAfter
+	assert.Assert(t, errors.As(err, &requeue))
+	assert.Equal(t, desired.ko.Status.FailoverDrillEndTime.Time, start.Add(10*time.Minute))
+	assert.Equal(t, <-recorder.Events,
+		"Normal FailoverDrillEnded failover drill ended, events are routed to the primary Region again")
+	assert.Equal(t, aws.ToString(read().ko.Spec.Description), "updated")
+
+	// the annotations are removed from the stored Endpoint
+	stored := &svcapitypes.Endpoint{}
+	assert.NilError(t, kc.Get(ctx, rtclient.ObjectKeyFromObject(desired.ko), stored))
+	assert.Equal(t, len(stored.GetAnnotations()), 0)
+
+	// the ended drill isn't started again, unless it is requested again
+	desired.ko.SetAnnotations(nil)
+	assert.Assert(t, !newResourceDelta(desired, read()).DifferentAt("Spec"))
+
+	desired.ko.SetAnnotations(map[string]string{
+		AnnotationForceFailover:            FailoverTargetSecondary,
+		AnnotationForceFailoverHealthCheck: drillHealthCheck,
+	})
+	_, err = sync(desired)
+	assert.Equal(t, err, requeueWaitWhileUpdating)
+	assert.Assert(t, failoverDrillInProgress(desired.ko))
+	<-recorder.Events
+
+	// removing the annotations ends the drill early
+	desired.ko.SetAnnotations(nil)
+	now = func() time.Time { return start.Add(time.Hour) }
+	_, err = sync(desired)
+	assert.Equal(t, err, requeueWaitWhileUpdating)
+	_, err = sync(desired)
+	assert.NilError(t, err)
+	assert.Equal(t, desired.ko.Status.FailoverDrillEndTime.Time, start.Add(time.Hour))
+	assert.Equal(t, endpointHealthCheckID(read().ko), "primary")
+
+	// invalid annotations are terminal errors
+	desired.ko.SetAnnotations(map[string]string{AnnotationForceFailover: FailoverTargetSecondary})
+	var terminal *ackerr.TerminalError
+	_, err = sync(desired)
+	assert.Assert(t, errors.As(err, &terminal))
+}
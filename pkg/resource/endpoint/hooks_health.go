@@ -0,0 +1,323 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package endpoint
+
+import (
+	"context"
+	"strings"
+
+	ackerr "github.com/aws-controllers-k8s/runtime/pkg/errors"
+	ackrtlog "github.com/aws-controllers-k8s/runtime/pkg/runtime/log"
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/aws/arn"
+	svcsdktypes "github.com/aws/aws-sdk-go-v2/service/eventbridge/types"
+	"github.com/aws/aws-sdk-go-v2/service/route53"
+	route53types "github.com/aws/aws-sdk-go-v2/service/route53/types"
+	flag "github.com/spf13/pflag"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	svcapitypes "github.com/aws-controllers-k8s/eventbridge-controller/apis/v1alpha1"
+	"github.com/aws-controllers-k8s/eventbridge-controller/pkg/clients"
+	"github.com/aws-controllers-k8s/eventbridge-controller/pkg/events"
+	"github.com/aws-controllers-k8s/eventbridge-controller/pkg/references"
+)
+
+// +kubebuilder:rbac:groups="",resources=events,verbs=create;patch
+// +kubebuilder:rbac:groups=route53.services.k8s.aws,resources=healthchecks,verbs=get;list
+// +kubebuilder:rbac:groups=route53.services.k8s.aws,resources=healthchecks/status,verbs=get;list
+
+const (
+	flagHealthCheckStatus = "endpoint-health-check-status"
+
+	// failoverReason is the reason of the Event recorded when events are
+	// routed to the secondary Region
+	failoverReason = "EndpointFailover"
+	// failbackReason is the reason of the Event recorded when events are
+	// routed to the primary Region again
+	failbackReason = "EndpointFailback"
+
+	// healthyCheckersRatio is the ratio of Route 53 health checkers which
+	// must report a healthy endpoint for Route 53 to consider it healthy
+	healthyCheckersRatio = 0.18
+)
+
+// healthCheckStatusEnabled is set by the controller flag. Reading the status
+// requires the route53:GetHealthCheckStatus permission, so it is opt-in.
+var healthCheckStatusEnabled bool
+
+func init() {
+	flag.BoolVar(
+		&healthCheckStatusEnabled, flagHealthCheckStatus, false,
+		"Read the Route 53 health check status of Endpoints to report their active Region. "+
+			"Requires the route53:GetHealthCheckStatus permission.",
+	)
+}
+
+// healthCheckGVK is the kind of the route53 controller's HealthCheck
+// resources. They are read as unstructured objects, the route53 controller
+// API isn't a dependency of this controller.
+var healthCheckGVK = schema.GroupVersionKind{
+	Group:   "route53.services.k8s.aws",
+	Version: "v1alpha1",
+	Kind:    "HealthCheck",
+}
+
+// healthCheckID is the field of the HealthChecks which references resolve to
+var healthCheckIDField = references.Field{Name: "Status.ID", Path: []string{"status", "id"}}
+
+// route53API is the Route 53 API used to read the status of health checks
+type route53API interface {
+	GetHealthCheckStatus(
+		ctx context.Context,
+		input *route53.GetHealthCheckStatusInput,
+		optFns ...func(*route53.Options),
+	) (*route53.GetHealthCheckStatusOutput, error)
+}
+
+// newRoute53API returns a Route 53 client with the configuration of a
+// resource manager. It is replaced in tests.
+var newRoute53API = func(cfg aws.Config) route53API {
+	return route53.NewFromConfig(cfg)
+}
+
+// route53 returns the Route 53 client of the account and Region of the
+// resource manager
+func (rm *resourceManager) route53(ctx context.Context) (route53API, error) {
+	c, err := clients.FromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return clients.AWS(c, route53.ServiceID, rm.awsAccountID, rm.awsRegion, func() route53API {
+		return newRoute53API(rm.clientcfg)
+	}), nil
+}
+
+// setHealthStatus sets the health of the primary Region and the active Region
+// of the endpoint from the status of its Route 53 health check, and records
+// an Event when the active Region changes. The status can only be read when
+// the endpoint has a health check. Failing to read it doesn't fail the
+// reconciliation, the previous status is kept.
+func (rm *resourceManager) setHealthStatus(
+	ctx context.Context,
+	ko *svcapitypes.Endpoint,
+) {
+	if !healthCheckStatusEnabled {
+		return
+	}
+	healthCheckID := endpointHealthCheckID(ko)
+	if healthCheckID == "" {
+		ko.Status.ActiveRegion = nil
+		ko.Status.PrimaryHealthy = nil
+		return
+	}
+
+	rlog := ackrtlog.FromContext(ctx)
+	client, err := rm.route53(ctx)
+	if err != nil {
+		rlog.Info("unable to read health check status", "healthCheckID", healthCheckID, "error", err)
+		return
+	}
+	resp, err := client.GetHealthCheckStatus(ctx, &route53.GetHealthCheckStatusInput{
+		HealthCheckId: aws.String(healthCheckID),
+	})
+	rm.metrics.RecordAPICall("READ_ONE", "GetHealthCheckStatus", err)
+	if err != nil {
+		rlog.Info("unable to read health check status", "healthCheckID", healthCheckID, "error", err)
+		return
+	}
+
+	healthy := healthCheckHealthy(resp.HealthCheckObservations)
+	activeRegion := rm.primaryRegion(ko)
+	if !healthy {
+		activeRegion = secondaryRegion(ko)
+	}
+	previousRegion := aws.ToString(ko.Status.ActiveRegion)
+	ko.Status.PrimaryHealthy = aws.Bool(healthy)
+	ko.Status.ActiveRegion = aws.String(activeRegion)
+
+	if previousRegion == "" || previousRegion == activeRegion {
+		return
+	}
+	if healthy {
+		events.Recorder().Eventf(ko, corev1.EventTypeNormal, failbackReason,
+			"primary Region is healthy, events are routed to %s again", activeRegion)
+	} else {
+		events.Recorder().Eventf(ko, corev1.EventTypeWarning, failoverReason,
+			"primary Region %s is unhealthy, events are routed to %s", previousRegion, activeRegion)
+	}
+}
+
+// endpointHealthCheckID returns the ID of the health check of the endpoint, or
+// an empty string if it has none
+func endpointHealthCheckID(ko *svcapitypes.Endpoint) string {
+	rc := ko.Spec.RoutingConfig
+	if rc == nil || rc.FailoverConfig == nil || rc.FailoverConfig.Primary == nil {
+		return ""
+	}
+	return parseHealthCheckID(aws.ToString(rc.FailoverConfig.Primary.HealthCheck))
+}
+
+// parseHealthCheckID returns the ID of a health check from its ARN or ID
+func parseHealthCheckID(healthCheck string) string {
+	if parsed, err := arn.Parse(healthCheck); err == nil {
+		healthCheck = parsed.Resource
+	}
+	return strings.TrimPrefix(healthCheck, "healthcheck/")
+}
+
+// healthCheckARN returns the ARN of the Route 53 health check with the ID.
+// Health checks are global, their ARNs have neither a Region nor an account.
+func healthCheckARN(partition, id string) string {
+	if partition == "" {
+		partition = "aws"
+	}
+	return arn.ARN{
+		Partition: partition,
+		Service:   "route53",
+		Resource:  "healthcheck/" + id,
+	}.String()
+}
+
+// primaryHealthCheck returns the health check of the primary Region of the
+// endpoint. When the endpoint references a HealthCheck, it is the ARN of the
+// referenced HealthCheck, which isn't written to the spec.
+func (rm *resourceManager) primaryHealthCheck(
+	ctx context.Context,
+	ko *svcapitypes.Endpoint,
+) (*string, error) {
+	rc := ko.Spec.RoutingConfig
+	if rc == nil || rc.FailoverConfig == nil || rc.FailoverConfig.Primary == nil {
+		return nil, nil
+	}
+	primary := rc.FailoverConfig.Primary
+	if primary.HealthCheckRef == nil || primary.HealthCheckRef.From == nil {
+		return primary.HealthCheck, nil
+	}
+	if primary.HealthCheck != nil {
+		return nil, ackerr.ResourceReferenceAndIDNotSupportedFor(
+			"RoutingConfig.FailoverConfig.Primary.HealthCheck",
+			"RoutingConfig.FailoverConfig.Primary.HealthCheckRef",
+		)
+	}
+	c, err := clients.FromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	id, err := references.Resolve(ctx, c.APIReader, references.ResolveConfig{
+		EnableCrossNamespace: rm.cfg.EnableCrossNamespace,
+		Namespace:            ko.GetNamespace(),
+		Conditions:           &ko.Status.Conditions,
+	}, references.Reference{
+		Kind:   healthCheckGVK,
+		Field:  "RoutingConfig.FailoverConfig.Primary.HealthCheckRef",
+		From:   primary.HealthCheckRef.From,
+		Target: healthCheckIDField,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return aws.String(healthCheckARN(string(rm.awsPartition), id)), nil
+}
+
+// setPrimaryHealthCheck sets the health check of the primary Region of a
+// request to the referenced HealthCheck
+func (rm *resourceManager) setPrimaryHealthCheck(
+	ctx context.Context,
+	ko *svcapitypes.Endpoint,
+	rc *svcsdktypes.RoutingConfig,
+) error {
+	if rc == nil || rc.FailoverConfig == nil || rc.FailoverConfig.Primary == nil {
+		return nil
+	}
+	healthCheck, err := rm.primaryHealthCheck(ctx, ko)
+	if err != nil {
+		return err
+	}
+	rc.FailoverConfig.Primary.HealthCheck = healthCheck
+	return nil
+}
+
+// keepHealthCheckRef keeps the HealthCheck reference of the desired endpoint
+// in the endpoint read from the API, and unsets the health check of its
+// primary Region when it is the referenced HealthCheck, so the spec keeps
+// only the reference. Failing to resolve the reference doesn't fail the
+// read, the health check is then updated, which fails with the resolution
+// error.
+func (rm *resourceManager) keepHealthCheckRef(
+	ctx context.Context,
+	desired *svcapitypes.Endpoint,
+	ko *svcapitypes.Endpoint,
+) {
+	rc := ko.Spec.RoutingConfig
+	if rc == nil || rc.FailoverConfig == nil || rc.FailoverConfig.Primary == nil {
+		return
+	}
+	desiredRC := desired.Spec.RoutingConfig
+	if desiredRC == nil || desiredRC.FailoverConfig == nil || desiredRC.FailoverConfig.Primary == nil ||
+		desiredRC.FailoverConfig.Primary.HealthCheckRef == nil {
+		return
+	}
+	primary := rc.FailoverConfig.Primary
+	primary.HealthCheckRef = desiredRC.FailoverConfig.Primary.HealthCheckRef.DeepCopy()
+	if primary.HealthCheck == nil {
+		return
+	}
+	resolved, err := rm.primaryHealthCheck(ctx, desired)
+	if err != nil {
+		ackrtlog.FromContext(ctx).Info("unable to resolve health check reference", "error", err)
+		return
+	}
+	if parseHealthCheckID(aws.ToString(resolved)) == parseHealthCheckID(*primary.HealthCheck) {
+		primary.HealthCheck = nil
+	}
+}
+
+// healthCheckHealthy returns whether Route 53 considers a health check
+// healthy, which is when more than 18% of its health checkers report it
+// healthy
+func healthCheckHealthy(observations []route53types.HealthCheckObservation) bool {
+	if len(observations) == 0 {
+		return false
+	}
+	var healthy int
+	for _, o := range observations {
+		if o.StatusReport != nil && strings.HasPrefix(aws.ToString(o.StatusReport.Status), "Success") {
+			healthy++
+		}
+	}
+	return float64(healthy) > healthyCheckersRatio*float64(len(observations))
+}
+
+// primaryRegion returns the Region of the event bus which isn't in the
+// secondary Region, and defaults to the Region of the resource manager
+func (rm *resourceManager) primaryRegion(ko *svcapitypes.Endpoint) string {
+	secondary := secondaryRegion(ko)
+	for _, b := range ko.Spec.EventBuses {
+		parsed, err := arn.Parse(aws.ToString(b.EventBusARN))
+		if err == nil && parsed.Region != secondary {
+			return parsed.Region
+		}
+	}
+	return string(rm.awsRegion)
+}
+
+// secondaryRegion returns the Region events are routed to on failover
+func secondaryRegion(ko *svcapitypes.Endpoint) string {
+	rc := ko.Spec.RoutingConfig
+	if rc == nil || rc.FailoverConfig == nil || rc.FailoverConfig.Secondary == nil {
+		return ""
+	}
+	return aws.ToString(rc.FailoverConfig.Secondary.Route)
+}
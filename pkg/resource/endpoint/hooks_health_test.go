@@ -0,0 +1,300 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package endpoint
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	ackv1alpha1 "github.com/aws-controllers-k8s/runtime/apis/core/v1alpha1"
+	ackerr "github.com/aws-controllers-k8s/runtime/pkg/errors"
+	"github.com/aws/aws-sdk-go-v2/aws"
+	svcsdk "github.com/aws/aws-sdk-go-v2/service/eventbridge"
+	svcsdktypes "github.com/aws/aws-sdk-go-v2/service/eventbridge/types"
+	"github.com/aws/aws-sdk-go-v2/service/route53"
+	route53types "github.com/aws/aws-sdk-go-v2/service/route53/types"
+	"gotest.tools/v3/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/client-go/tools/record"
+	ctrlrtfake "sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	svcapitypes "github.com/aws-controllers-k8s/eventbridge-controller/apis/v1alpha1"
+	"github.com/aws-controllers-k8s/eventbridge-controller/pkg/clients"
+	"github.com/aws-controllers-k8s/eventbridge-controller/pkg/events"
+	"github.com/aws-controllers-k8s/eventbridge-controller/pkg/testutil"
+)
+
+// fakeRoute53API reports the observations of health checks by ID
+type fakeRoute53API struct {
+	observations map[string][]route53types.HealthCheckObservation
+	err          error
+}
+
+func newFakeRoute53API() *fakeRoute53API {
+	return &fakeRoute53API{
+		observations: make(map[string][]route53types.HealthCheckObservation),
+	}
+}
+
+// useFakeRoute53API replaces the Route 53 client of resource managers with
+// the fake for the duration of the test
+func useFakeRoute53API(t *testing.T, fake *fakeRoute53API) {
+	t.Helper()
+	newAPI := newRoute53API
+	newRoute53API = func(aws.Config) route53API { return fake }
+	t.Cleanup(func() { newRoute53API = newAPI })
+}
+
+func (f *fakeRoute53API) GetHealthCheckStatus(
+	_ context.Context,
+	input *route53.GetHealthCheckStatusInput,
+	_ ...func(*route53.Options),
+) (*route53.GetHealthCheckStatusOutput, error) {
+	if f.err != nil {
+		return nil, f.err
+	}
+	return &route53.GetHealthCheckStatusOutput{
+		HealthCheckObservations: f.observations[aws.ToString(input.HealthCheckId)],
+	}, nil
+}
+
+// observations returns the observations of healthy and unhealthy checkers
+func observations(healthy, unhealthy int) []route53types.HealthCheckObservation {
+	var res []route53types.HealthCheckObservation
+	for i := 0; i < healthy+unhealthy; i++ {
+		status := "Success: HTTP Status Code 200, OK"
+		if i >= healthy {
+			status = "Failure: Connection timed out."
+		}
+		res = append(res, route53types.HealthCheckObservation{
+			StatusReport: &route53types.StatusReport{Status: aws.String(status)},
+		})
+	}
+	return res
+}
+
+func newHealthCheckEndpoint(healthCheck string) *svcapitypes.Endpoint {
+	return &svcapitypes.Endpoint{
+		Spec: svcapitypes.EndpointSpec{
+			Name: aws.String("test-endpoint"),
+			EventBuses: []*svcapitypes.EndpointEventBus{
+				{EventBusARN: aws.String("arn:aws:events:us-east-1:123456789012:event-bus/test-bus")},
+				{EventBusARN: aws.String("arn:aws:events:us-west-2:123456789012:event-bus/test-bus")},
+			},
+			RoutingConfig: &svcapitypes.RoutingConfig{
+				FailoverConfig: &svcapitypes.FailoverConfig{
+					Primary:   &svcapitypes.Primary{HealthCheck: aws.String(healthCheck)},
+					Secondary: &svcapitypes.Secondary{Route: aws.String("us-east-1")},
+				},
+			},
+		},
+	}
+}
+
+func Test_healthCheckHealthy(t *testing.T) {
+	assert.Assert(t, !healthCheckHealthy(nil))
+	assert.Assert(t, healthCheckHealthy(observations(16, 0)))
+	assert.Assert(t, healthCheckHealthy(observations(3, 13)))
+	assert.Assert(t, !healthCheckHealthy(observations(2, 14)))
+}
+
+func Test_endpointHealthCheckID(t *testing.T) {
+	assert.Equal(t, endpointHealthCheckID(newHealthCheckEndpoint("arn:aws:route53:::healthcheck/abc")), "abc")
+	assert.Equal(t, endpointHealthCheckID(newHealthCheckEndpoint("abc")), "abc")
+	assert.Equal(t, endpointHealthCheckID(&svcapitypes.Endpoint{}), "")
+}
+
+func Test_healthCheckARN(t *testing.T) {
+	assert.Equal(t, healthCheckARN("", "abc"), "arn:aws:route53:::healthcheck/abc")
+	assert.Equal(t, healthCheckARN("aws-cn", "abc"), "arn:aws-cn:route53:::healthcheck/abc")
+}
+
+func Test_resourceManager_setHealthStatus(t *testing.T) {
+	defer func(enabled bool) { healthCheckStatusEnabled = enabled }(healthCheckStatusEnabled)
+	healthCheckStatusEnabled = true
+	fake := newFakeRoute53API()
+	useFakeRoute53API(t, fake)
+	recorder := record.NewFakeRecorder(10)
+	events.SetRecorder(recorder)
+	defer events.SetRecorder(nil)
+
+	ctx := clients.NewContext(context.Background(), clients.New(nil, nil))
+	rm := newTestResourceManager(t, testutil.NewEventBridge())
+	ko := newHealthCheckEndpoint("arn:aws:route53:::healthcheck/primary")
+
+	fake.observations["primary"] = observations(16, 0)
+	rm.setHealthStatus(ctx, ko)
+	assert.Equal(t, aws.ToString(ko.Status.ActiveRegion), "us-west-2")
+	assert.Equal(t, aws.ToBool(ko.Status.PrimaryHealthy), true)
+	assert.Equal(t, len(recorder.Events), 0)
+
+	fake.observations["primary"] = observations(0, 16)
+	rm.setHealthStatus(ctx, ko)
+	assert.Equal(t, aws.ToString(ko.Status.ActiveRegion), "us-east-1")
+	assert.Equal(t, aws.ToBool(ko.Status.PrimaryHealthy), false)
+	assert.Equal(t, <-recorder.Events,
+		"Warning EndpointFailover primary Region us-west-2 is unhealthy, events are routed to us-east-1")
+
+	// the status is kept when it can't be read
+	fake.err = errors.New("AccessDenied")
+	rm.setHealthStatus(ctx, ko)
+	assert.Equal(t, aws.ToString(ko.Status.ActiveRegion), "us-east-1")
+	fake.err = nil
+
+	fake.observations["primary"] = observations(16, 0)
+	rm.setHealthStatus(ctx, ko)
+	assert.Equal(t, aws.ToString(ko.Status.ActiveRegion), "us-west-2")
+	assert.Equal(t, <-recorder.Events,
+		"Normal EndpointFailback primary Region is healthy, events are routed to us-west-2 again")
+
+	ko.Spec.RoutingConfig.FailoverConfig.Primary.HealthCheck = nil
+	rm.setHealthStatus(ctx, ko)
+	assert.Assert(t, ko.Status.ActiveRegion == nil)
+	assert.Assert(t, ko.Status.PrimaryHealthy == nil)
+}
+
+// newHealthCheck returns a HealthCheck of the route53 controller with the ID
+// and conditions
+func newHealthCheck(name, id string, conditions ...ackv1alpha1.ConditionType) *unstructured.Unstructured {
+	obj := &unstructured.Unstructured{}
+	obj.SetGroupVersionKind(healthCheckGVK)
+	obj.SetNamespace("default")
+	obj.SetName(name)
+	var conds []interface{}
+	for _, c := range conditions {
+		conds = append(conds, map[string]interface{}{"type": string(c), "status": "True"})
+	}
+	obj.Object["status"] = map[string]interface{}{"id": id, "conditions": conds}
+	return obj
+}
+
+func newHealthCheckRefEndpoint(name string) *svcapitypes.Endpoint {
+	ko := newHealthCheckEndpoint("")
+	ko.Namespace = "default"
+	ko.Spec.RoutingConfig.FailoverConfig.Primary = &svcapitypes.Primary{
+		HealthCheckRef: &ackv1alpha1.AWSResourceReferenceWrapper{
+			From: &ackv1alpha1.AWSResourceReference{Name: aws.String(name)},
+		},
+	}
+	return ko
+}
+
+func Test_resourceManager_primaryHealthCheck(t *testing.T) {
+	kc := ctrlrtfake.NewClientBuilder().WithObjects(
+		newHealthCheck("synced", "abc", ackv1alpha1.ConditionTypeResourceSynced),
+		newHealthCheck("creating", ""),
+		newHealthCheck("terminal", "abc", ackv1alpha1.ConditionTypeTerminal),
+		newHealthCheck("no-id", "", ackv1alpha1.ConditionTypeResourceSynced),
+	).Build()
+	ctx := clients.NewContext(context.Background(), clients.New(nil, kc))
+	rm := newTestResourceManager(t, testutil.NewEventBridge())
+
+	withHealthCheck := newHealthCheckRefEndpoint("synced")
+	withHealthCheck.Spec.RoutingConfig.FailoverConfig.Primary.HealthCheck = aws.String("abc")
+
+	tests := []struct {
+		name    string
+		ko      *svcapitypes.Endpoint
+		want    *string
+		wantErr error
+	}{
+		{
+			name: "health check",
+			ko:   newHealthCheckEndpoint("arn:aws:route53:::healthcheck/abc"),
+			want: aws.String("arn:aws:route53:::healthcheck/abc"),
+		},
+		{
+			name: "synced health check reference",
+			ko:   newHealthCheckRefEndpoint("synced"),
+			want: aws.String("arn:aws:route53:::healthcheck/abc"),
+		},
+		{
+			name:    "health check and reference",
+			ko:      withHealthCheck,
+			wantErr: ackerr.ResourceReferenceAndIDNotSupported,
+		},
+		{
+			name:    "health check not synced",
+			ko:      newHealthCheckRefEndpoint("creating"),
+			wantErr: ackerr.ResourceReferenceNotSynced,
+		},
+		{
+			name:    "terminal health check",
+			ko:      newHealthCheckRefEndpoint("terminal"),
+			wantErr: ackerr.ResourceReferenceTerminal,
+		},
+		{
+			name:    "health check without ID",
+			ko:      newHealthCheckRefEndpoint("no-id"),
+			wantErr: ackerr.ResourceReferenceMissingTargetField,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := rm.primaryHealthCheck(ctx, tt.ko)
+			if tt.wantErr != nil {
+				assert.Assert(t, errors.Is(err, tt.wantErr), "got %v", err)
+				return
+			}
+			assert.NilError(t, err)
+			assert.DeepEqual(t, got, tt.want)
+		})
+	}
+
+	t.Run("missing health check", func(t *testing.T) {
+		_, err := rm.primaryHealthCheck(ctx, newHealthCheckRefEndpoint("missing"))
+		assert.ErrorContains(t, err, "not found")
+	})
+}
+
+func Test_resourceManager_healthCheckRef(t *testing.T) {
+	kc := ctrlrtfake.NewClientBuilder().WithObjects(
+		newHealthCheck("synced", "abc", ackv1alpha1.ConditionTypeResourceSynced),
+	).Build()
+	ctx := clients.NewContext(context.Background(), clients.New(nil, kc))
+	fake := testutil.NewEventBridge()
+	rm := newTestResourceManager(t, fake)
+	desired := &resource{ko: newHealthCheckRefEndpoint("synced")}
+	desired.ko.Spec.RoleARN = aws.String("arn:aws:iam::123456789012:role/replication")
+	desired.ko.ObjectMeta = metav1.ObjectMeta{Namespace: "default", Name: "test-endpoint"}
+
+	// the endpoint is created with the referenced health check, which isn't
+	// written to the spec
+	_, err := rm.sdkCreate(ctx, desired)
+	assert.Equal(t, err, requeueWaitWhileCreating)
+	fake.Settle()
+	latest, err := rm.sdkFind(ctx, desired)
+	assert.NilError(t, err)
+	assert.Assert(t, latest.ko.Spec.RoutingConfig.FailoverConfig.Primary.HealthCheck == nil)
+	assert.Assert(t, latest.ko.Spec.RoutingConfig.FailoverConfig.Primary.HealthCheckRef != nil)
+
+	// the health check is read when it isn't the referenced one
+	_, err = rm.sdkapi.UpdateEndpoint(ctx, &svcsdk.UpdateEndpointInput{
+		Name: desired.ko.Spec.Name,
+		RoutingConfig: &svcsdktypes.RoutingConfig{
+			FailoverConfig: &svcsdktypes.FailoverConfig{
+				Primary:   &svcsdktypes.Primary{HealthCheck: aws.String("arn:aws:route53:::healthcheck/other")},
+				Secondary: &svcsdktypes.Secondary{Route: aws.String("us-east-1")},
+			},
+		},
+	})
+	assert.NilError(t, err)
+	fake.Settle()
+	latest, err = rm.sdkFind(ctx, desired)
+	assert.NilError(t, err)
+	assert.Equal(t, aws.ToString(latest.ko.Spec.RoutingConfig.FailoverConfig.Primary.HealthCheck),
+		"arn:aws:route53:::healthcheck/other")
+}
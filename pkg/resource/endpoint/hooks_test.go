@@ -97,6 +97,31 @@ func Test_validateEndpointSpec(t *testing.T) {
 			},
 			wantErr: "event bus names must be identical",
 		},
+		{
+			name: "two event buses in the same region",
+			spec: v1alpha1.EndpointSpec{
+				EventBuses: []*v1alpha1.EndpointEventBus{
+					{EventBusARN: aws.String("arn:aws:events:us-east-1:123456789012:myApplicationBus")},
+					{EventBusARN: aws.String("arn:aws:events:us-east-1:111122223333:myApplicationBus")},
+				},
+				Name: aws.String("endpointspec"),
+			},
+			wantErr: "event buses must be in different Regions",
+		},
+		{
+			name: "secondary route not in the region of an event bus",
+			spec: v1alpha1.EndpointSpec{
+				EventBuses: []*v1alpha1.EndpointEventBus{
+					{EventBusARN: aws.String("arn:aws:events:us-east-1:123456789012:myApplicationBus")},
+					{EventBusARN: aws.String("arn:aws:events:us-east-2:123456789012:myApplicationBus")},
+				},
+				Name: aws.String("endpointspec"),
+				RoutingConfig: &v1alpha1.RoutingConfig{FailoverConfig: &v1alpha1.FailoverConfig{
+					Secondary: &v1alpha1.Secondary{Route: aws.String("eu-central-1")},
+				}},
+			},
+			wantErr: `must be the Region of one of the event buses, got "eu-central-1"`,
+		},
 		{
 			name: "routing config not set",
 			spec: v1alpha1.EndpointSpec{
@@ -134,7 +159,7 @@ func Test_validateEndpointSpec(t *testing.T) {
 						HealthCheck: aws.String("arn:aws:route53:::healthcheck/1dc6d4f8-5ec8-4089-8b2d-692eef46316b"),
 					},
 					Secondary: &v1alpha1.Secondary{
-						Route: aws.String("eu-central-1"),
+						Route: aws.String("us-east-2"),
 					},
 				}},
 			},
@@ -153,7 +178,7 @@ func Test_validateEndpointSpec(t *testing.T) {
 						HealthCheck: aws.String("arn:aws:route53:::healthcheck/1dc6d4f8-5ec8-4089-8b2d-692eef46316b"),
 					},
 					Secondary: &v1alpha1.Secondary{
-						Route: aws.String("eu-central-1"),
+						Route: aws.String("us-east-2"),
 					},
 				}},
 				Description: aws.String("some description"),
@@ -182,7 +207,7 @@ func Test_validateEndpointSpec(t *testing.T) {
 						HealthCheck: aws.String("arn:aws:route53:::healthcheck/1dc6d4f8-5ec8-4089-8b2d-692eef46316b"),
 					},
 					Secondary: &v1alpha1.Secondary{
-						Route: aws.String("eu-central-1"),
+						Route: aws.String("us-east-2"),
 					},
 				}},
 			},
@@ -210,7 +235,7 @@ func Test_validateEndpointSpec(t *testing.T) {
 						HealthCheck: aws.String("arn:aws:route53:::healthcheck/1dc6d4f8-5ec8-4089-8b2d-692eef46316b"),
 					},
 					Secondary: &v1alpha1.Secondary{
-						Route: aws.String("eu-central-1"),
+						Route: aws.String("us-east-2"),
 					},
 				}},
 				RoleARN: aws.String("arn:aws:iam::1234567890:role/role"),
@@ -230,7 +255,7 @@ func Test_validateEndpointSpec(t *testing.T) {
 								HealthCheck: aws.String("arn:aws:route53:::healthcheck/1dc6d4f8-5ec8-4089-8b2d-692eef46316b"),
 							},
 							Secondary: &v1alpha1.Secondary{
-								Route: aws.String("eu-central-1"),
+								Route: aws.String("us-east-2"),
 							},
 						}},
 					},
@@ -275,7 +300,7 @@ func Test_unsetRemovedSpecFields(t *testing.T) {
 						HealthCheck: aws.String("arn:aws:route53:::healthcheck/1dc6d4f8-5ec8-4089-8b2d-692eef46316b"),
 					},
 					Secondary: &v1alpha1.Secondary{
-						Route: aws.String("eu-central-1"),
+						Route: aws.String("us-east-2"),
 					},
 				}},
 			},
@@ -290,7 +315,7 @@ func Test_unsetRemovedSpecFields(t *testing.T) {
 						HealthCheck: aws.String("arn:aws:route53:::healthcheck/1dc6d4f8-5ec8-4089-8b2d-692eef46316b"),
 					},
 					Secondary: &svcsdktypes.Secondary{
-						Route: aws.String("eu-central-1"),
+						Route: aws.String("us-east-2"),
 					},
 				}},
 			},
@@ -315,7 +340,7 @@ func Test_unsetRemovedSpecFields(t *testing.T) {
 						HealthCheck: aws.String("arn:aws:route53:::healthcheck/1dc6d4f8-5ec8-4089-8b2d-692eef46316b"),
 					},
 					Secondary: &svcsdktypes.Secondary{
-						Route: aws.String("eu-central-1"),
+						Route: aws.String("us-east-2"),
 					},
 				}},
 			},
@@ -334,7 +359,7 @@ func Test_unsetRemovedSpecFields(t *testing.T) {
 						HealthCheck: aws.String("arn:aws:route53:::healthcheck/1dc6d4f8-5ec8-4089-8b2d-692eef46316b"),
 					},
 					Secondary: &v1alpha1.Secondary{
-						Route: aws.String("eu-central-1"),
+						Route: aws.String("us-east-2"),
 					},
 				}},
 			},
@@ -349,7 +374,7 @@ func Test_unsetRemovedSpecFields(t *testing.T) {
 						HealthCheck: aws.String("arn:aws:route53:::healthcheck/1dc6d4f8-5ec8-4089-8b2d-692eef46316b"),
 					},
 					Secondary: &svcsdktypes.Secondary{
-						Route: aws.String("eu-central-1"),
+						Route: aws.String("us-east-2"),
 					},
 				}},
 			},
@@ -373,7 +398,7 @@ func Test_unsetRemovedSpecFields(t *testing.T) {
 						HealthCheck: aws.String("arn:aws:route53:::healthcheck/1dc6d4f8-5ec8-4089-8b2d-692eef46316b"),
 					},
 					Secondary: &svcsdktypes.Secondary{
-						Route: aws.String("eu-central-1"),
+						Route: aws.String("us-east-2"),
 					},
 				}},
 				ReplicationConfig: &svcsdktypes.ReplicationConfig{State: svcsdktypes.ReplicationStateEnabled},
@@ -551,6 +576,20 @@ func Test_customPreCompare(t *testing.T) {
 			},
 			wantDifferences: []string{"Spec.ReplicationConfig"},
 		},
+		{
+			name: "failover drill requested",
+			args: args{
+				a: func() *resource {
+					a := aResource.ko.DeepCopy()
+					a.SetAnnotations(map[string]string{AnnotationForceFailover: FailoverTargetSecondary})
+					return &resource{ko: a}
+				},
+				b: func() *resource {
+					return aResource
+				},
+			},
+			wantDifferences: []string{failoverDrillPath},
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -17,7 +17,6 @@ package endpoint
 
 import (
 	"context"
-	"fmt"
 	"time"
 
 	ackv1alpha1 "github.com/aws-controllers-k8s/runtime/apis/core/v1alpha1"
@@ -38,6 +37,7 @@ import (
 	corev1 "k8s.io/api/core/v1"
 
 	svcapitypes "github.com/aws-controllers-k8s/eventbridge-controller/apis/v1alpha1"
+	"github.com/aws-controllers-k8s/eventbridge-controller/pkg/arns"
 )
 
 var (
@@ -194,11 +194,11 @@ func (rm *resourceManager) Delete(
 // GetAttributes operations but all we have (for new CRs at least) is a
 // name for the resource
 func (rm *resourceManager) ARNFromName(name string) string {
-	return fmt.Sprintf(
-		"arn:%s:eventbridge:%s:%s:%s",
-		rm.awsPartition,
-		rm.awsRegion,
-		rm.awsAccountID,
+	return arns.FromName(
+		string(rm.awsPartition),
+		string(rm.awsRegion),
+		string(rm.awsAccountID),
+		arns.KindEndpoint,
 		name,
 	)
 }
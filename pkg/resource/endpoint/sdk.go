@@ -170,6 +170,9 @@ func (rm *resourceManager) sdkFind(
 	}
 
 	rm.setStatusDefaults(ko)
+	rm.setHealthStatus(ctx, ko)
+	rm.keepHealthCheckRef(ctx, r.ko, ko)
+	observeState(ko)
 	return &resource{ko}, nil
 }
 
@@ -212,11 +215,22 @@ func (rm *resourceManager) sdkCreate(
 	if err = validateEndpointSpec(nil, desired.ko.Spec); err != nil {
 		return nil, ackerr.NewTerminalError(err)
 	}
+	if err = rm.validateEventBusRegions(desired.ko); err != nil {
+		return nil, ackerr.NewTerminalError(err)
+	}
+	// event buses may be created after the endpoint, so a missing event bus is
+	// retried
+	if err = rm.validateEventBusesExist(ctx, desired.ko); err != nil {
+		return nil, err
+	}
 
 	input, err := rm.newCreateRequestPayload(ctx, desired)
 	if err != nil {
 		return nil, err
 	}
+	if err = rm.setPrimaryHealthCheck(ctx, desired.ko, input.RoutingConfig); err != nil {
+		return nil, err
+	}
 
 	var resp *svcsdk.CreateEndpointOutput
 	_ = resp
@@ -299,6 +313,7 @@ func (rm *resourceManager) sdkCreate(
 	}
 
 	rm.setStatusDefaults(ko)
+	rm.keepHealthCheckRef(ctx, desired.ko, ko)
 	if !endpointAvailable(&resource{ko}) {
 		return &resource{ko}, requeueWaitWhileCreating
 	}
@@ -383,17 +398,34 @@ func (rm *resourceManager) sdkUpdate(
 	if err = validateEndpointSpec(delta, desired.ko.Spec); err != nil {
 		return nil, ackerr.NewTerminalError(err)
 	}
+	if err = rm.validateEventBusRegions(desired.ko); err != nil {
+		return nil, ackerr.NewTerminalError(err)
+	}
+	if delta.DifferentAt("Spec.EventBuses") {
+		if err = rm.validateEventBusesExist(ctx, desired.ko); err != nil {
+			return nil, err
+		}
+	}
 
 	if endpointInMutatingState(latest) {
 		return latest, requeueWaitWhileUpdating
 	}
 
+	if delta.DifferentAt(failoverDrillPath) {
+		if err = rm.syncFailoverDrill(ctx, desired, latest); err != nil || failoverDrillInProgress(desired.ko) || !delta.DifferentExcept(failoverDrillPath) {
+			return desired, err
+		}
+	}
+
 	input, err := rm.newUpdateRequestPayload(ctx, desired, delta)
 	if err != nil {
 		return nil, err
 	}
 	// we need to explicitly unset nil spec values
 	unsetRemovedSpecFields(delta, desired.ko.Spec, input)
+	if err = rm.setPrimaryHealthCheck(ctx, desired.ko, input.RoutingConfig); err != nil {
+		return nil, err
+	}
 
 	var resp *svcsdk.UpdateEndpointOutput
 	_ = resp
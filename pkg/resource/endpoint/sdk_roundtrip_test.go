@@ -0,0 +1,70 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package endpoint
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/rand"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/google/go-cmp/cmp"
+
+	svcapitypes "github.com/aws-controllers-k8s/eventbridge-controller/apis/v1alpha1"
+	"github.com/aws-controllers-k8s/eventbridge-controller/pkg/testutil"
+)
+
+func Test_resourceManager_specRoundTrip(t *testing.T) {
+	ctx := context.Background()
+	rm := newTestResourceManager(t, testutil.NewEventBridge(testutil.WithTransitionReads(0)))
+
+	testutil.CheckProperty(t, func(rnd *rand.Rand) error {
+		var spec svcapitypes.EndpointSpec
+		testutil.Fill(&spec, rnd)
+		spec.RoleRef = nil
+		if fc := spec.RoutingConfig.FailoverConfig; fc != nil && fc.Primary != nil {
+			fc.Primary.HealthCheckRef = nil
+		}
+		spec.Name = aws.String(testutil.RandomString(rnd, 16))
+		// an endpoint replicates between two event buses with the same name
+		bus := testutil.RandomString(rnd, 8)
+		spec.EventBuses = []*svcapitypes.EndpointEventBus{
+			{EventBusARN: aws.String("arn:aws:events:us-west-2:123456789012:event-bus/" + bus)},
+			{EventBusARN: aws.String("arn:aws:events:us-east-1:123456789012:event-bus/" + bus)},
+		}
+		if fc := spec.RoutingConfig.FailoverConfig; fc != nil && fc.Secondary != nil {
+			fc.Secondary.Route = aws.String("us-east-1")
+		}
+		spec.ReplicationConfig.State = aws.String([]string{"ENABLED", "DISABLED"}[rnd.Intn(2)])
+
+		created, err := rm.sdkCreate(ctx, &resource{ko: &svcapitypes.Endpoint{Spec: spec}})
+		if err != nil && !errors.Is(err, requeueWaitWhileCreating) {
+			return err
+		}
+		latest, err := rm.sdkFind(ctx, created)
+		if err != nil {
+			return err
+		}
+		if _, err := rm.sdkDelete(ctx, latest); err != nil && !errors.Is(err, requeueWaitWhileDeleting) {
+			return err
+		}
+
+		if diff := cmp.Diff(spec, latest.ko.Spec); diff != "" {
+			return fmt.Errorf("spec differs (-want +got):\n%s", diff)
+		}
+		return nil
+	})
+}
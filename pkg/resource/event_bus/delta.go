@@ -20,6 +20,7 @@ import (
 
 	ackcompare "github.com/aws-controllers-k8s/runtime/pkg/compare"
 	acktags "github.com/aws-controllers-k8s/runtime/pkg/tags"
+	"k8s.io/apimachinery/pkg/api/equality"
 )
 
 // Hack to avoid import errors during build...
@@ -49,6 +50,37 @@ func newResourceDelta(
 			delta.Add("Spec.EventSourceName", a.ko.Spec.EventSourceName, b.ko.Spec.EventSourceName)
 		}
 	}
+	if ackcompare.HasNilDifference(a.ko.Spec.LogConfig, b.ko.Spec.LogConfig) {
+		delta.Add("Spec.LogConfig", a.ko.Spec.LogConfig, b.ko.Spec.LogConfig)
+	} else if a.ko.Spec.LogConfig != nil && b.ko.Spec.LogConfig != nil {
+		if !equality.Semantic.Equalities.DeepEqual(a.ko.Spec.LogConfig.DeliveryStreamRefs, b.ko.Spec.LogConfig.DeliveryStreamRefs) {
+			delta.Add("Spec.LogConfig.DeliveryStreamRefs", a.ko.Spec.LogConfig.DeliveryStreamRefs, b.ko.Spec.LogConfig.DeliveryStreamRefs)
+		}
+		if len(a.ko.Spec.LogConfig.DestinationARNs) != len(b.ko.Spec.LogConfig.DestinationARNs) {
+			delta.Add("Spec.LogConfig.DestinationARNs", a.ko.Spec.LogConfig.DestinationARNs, b.ko.Spec.LogConfig.DestinationARNs)
+		} else if len(a.ko.Spec.LogConfig.DestinationARNs) > 0 {
+			if !ackcompare.SliceStringPEqual(a.ko.Spec.LogConfig.DestinationARNs, b.ko.Spec.LogConfig.DestinationARNs) {
+				delta.Add("Spec.LogConfig.DestinationARNs", a.ko.Spec.LogConfig.DestinationARNs, b.ko.Spec.LogConfig.DestinationARNs)
+			}
+		}
+		if ackcompare.HasNilDifference(a.ko.Spec.LogConfig.IncludeDetail, b.ko.Spec.LogConfig.IncludeDetail) {
+			delta.Add("Spec.LogConfig.IncludeDetail", a.ko.Spec.LogConfig.IncludeDetail, b.ko.Spec.LogConfig.IncludeDetail)
+		} else if a.ko.Spec.LogConfig.IncludeDetail != nil && b.ko.Spec.LogConfig.IncludeDetail != nil {
+			if *a.ko.Spec.LogConfig.IncludeDetail != *b.ko.Spec.LogConfig.IncludeDetail {
+				delta.Add("Spec.LogConfig.IncludeDetail", a.ko.Spec.LogConfig.IncludeDetail, b.ko.Spec.LogConfig.IncludeDetail)
+			}
+		}
+		if ackcompare.HasNilDifference(a.ko.Spec.LogConfig.Level, b.ko.Spec.LogConfig.Level) {
+			delta.Add("Spec.LogConfig.Level", a.ko.Spec.LogConfig.Level, b.ko.Spec.LogConfig.Level)
+		} else if a.ko.Spec.LogConfig.Level != nil && b.ko.Spec.LogConfig.Level != nil {
+			if *a.ko.Spec.LogConfig.Level != *b.ko.Spec.LogConfig.Level {
+				delta.Add("Spec.LogConfig.Level", a.ko.Spec.LogConfig.Level, b.ko.Spec.LogConfig.Level)
+			}
+		}
+		if !equality.Semantic.Equalities.DeepEqual(a.ko.Spec.LogConfig.LogGroupRefs, b.ko.Spec.LogConfig.LogGroupRefs) {
+			delta.Add("Spec.LogConfig.LogGroupRefs", a.ko.Spec.LogConfig.LogGroupRefs, b.ko.Spec.LogConfig.LogGroupRefs)
+		}
+	}
 	if ackcompare.HasNilDifference(a.ko.Spec.Name, b.ko.Spec.Name) {
 		delta.Add("Spec.Name", a.ko.Spec.Name, b.ko.Spec.Name)
 	} else if a.ko.Spec.Name != nil && b.ko.Spec.Name != nil {
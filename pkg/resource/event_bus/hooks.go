@@ -18,10 +18,9 @@ import (
 
 	ackcompare "github.com/aws-controllers-k8s/runtime/pkg/compare"
 	ackrtlog "github.com/aws-controllers-k8s/runtime/pkg/runtime/log"
-	svcsdk "github.com/aws/aws-sdk-go-v2/service/eventbridge"
-	svcsdktypes "github.com/aws/aws-sdk-go-v2/service/eventbridge/types"
 
 	svcapitypes "github.com/aws-controllers-k8s/eventbridge-controller/apis/v1alpha1"
+	"github.com/aws-controllers-k8s/eventbridge-controller/pkg/cache"
 	pkgtags "github.com/aws-controllers-k8s/eventbridge-controller/pkg/tags"
 )
 
@@ -37,42 +36,37 @@ func (rm *resourceManager) setResourceAdditionalFields(
 
 	if ko.Status.ACKResourceMetadata != nil && ko.Status.ACKResourceMetadata.ARN != nil &&
 		*ko.Status.ACKResourceMetadata.ARN != "" {
-		// Set event data store tags
-		ko.Spec.Tags, err = rm.getTags(ctx, string(*ko.Status.ACKResourceMetadata.ARN))
+		arn := string(*ko.Status.ACKResourceMetadata.ARN)
+		if tags, ok := tagsCache.Get(arn, ko.Generation); ok {
+			ko.Spec.Tags = tags
+			return nil
+		}
+		ko.Spec.Tags, err = rm.tagsManager().Get(ctx, arn)
 		if err != nil {
 			return err
 		}
+		tagsCache.Set(arn, ko.Generation, ko.Spec.Tags)
 	}
 	return nil
 }
 
-// getTags retrieves a resource list of tags.
-func (rm *resourceManager) getTags(
-	ctx context.Context,
-	resourceARN string,
-) (tags []*svcapitypes.Tag, err error) {
-	rlog := ackrtlog.FromContext(ctx)
-	exit := rlog.Trace("rm.getTags")
-	defer func() { exit(err) }()
+// tagsCache caches the tags read by sdkFind, which would otherwise be listed
+// on every reconciliation
+var tagsCache = cache.New("event_bus_tags", pkgtags.Copy)
 
-	var listTagsResponse *svcsdk.ListTagsForResourceOutput
-	listTagsResponse, err = rm.sdkapi.ListTagsForResource(
-		ctx,
-		&svcsdk.ListTagsForResourceInput{
-			ResourceARN: &resourceARN,
-		},
-	)
-	rm.metrics.RecordAPICall("GET", "ListTagsForResource", err)
-	if err != nil {
-		return nil, err
-	}
-	for _, tag := range listTagsResponse.Tags {
-		tags = append(tags, &svcapitypes.Tag{
-			Key:   tag.Key,
-			Value: tag.Value,
-		})
+// invalidateReads drops the cached tags and log deliveries of the event bus,
+// after they were written
+func invalidateReads(r *resource) {
+	if r == nil || r.ko.Status.ACKResourceMetadata == nil || r.ko.Status.ACKResourceMetadata.ARN == nil {
+		return
 	}
-	return tags, nil
+	tagsCache.Invalidate(string(*r.ko.Status.ACKResourceMetadata.ARN))
+	logDeliveriesCache.Invalidate(string(*r.ko.Status.ACKResourceMetadata.ARN))
+}
+
+// tagsManager returns the manager of the tags of event buses
+func (rm *resourceManager) tagsManager() *pkgtags.Manager {
+	return pkgtags.NewManager(rm.sdkapi, rm.metrics)
 }
 
 // customUpdate implements a custom logic for handling EventBus resource
@@ -89,76 +83,22 @@ func (rm *resourceManager) customUpdate(
 	defer func() { exit(err) }()
 
 	if delta.DifferentAt("Spec.Tags") {
-		err = rm.syncTags(ctx, latest, desired)
+		defer invalidateReads(latest)
+		err = rm.tagsManager().Sync(
+			ctx, string(*latest.ko.Status.ACKResourceMetadata.ARN),
+			desired.ko.Spec.Tags, latest.ko.Spec.Tags,
+		)
 		if err != nil {
 			return nil, err
 		}
 	}
-	return desired, nil
-}
-
-// syncTags updates event bus tags
-func (rm *resourceManager) syncTags(
-	ctx context.Context,
-	latest *resource,
-	desired *resource,
-) (err error) {
-	rlog := ackrtlog.FromContext(ctx)
-	exit := rlog.Trace("rm.syncTags")
-	defer func() { exit(err) }()
-
-	missing, extra := pkgtags.ComputeTagsDelta(desired.ko.Spec.Tags, latest.ko.Spec.Tags)
-
-	arn := (*string)(latest.ko.Status.ACKResourceMetadata.ARN)
-	if len(extra) > 0 {
-		_, err = rm.sdkapi.UntagResource(
-			ctx,
-			&svcsdk.UntagResourceInput{
-				ResourceARN: arn,
-				TagKeys:     sdkTagStringsFromResourceTags(extra),
-			})
-
-		rm.metrics.RecordAPICall("UPDATE", "UntagResource", err)
-		if err != nil {
-			return err
-		}
-	}
-
-	if len(missing) > 0 {
-		_, err = rm.sdkapi.TagResource(
-			ctx,
-			&svcsdk.TagResourceInput{
-				ResourceARN: arn,
-				Tags:        sdkTagsFromResourceTags(missing),
-			})
-
-		rm.metrics.RecordAPICall("UPDATE", "TagResource", err)
-		if err != nil {
-			return err
-		}
-	}
-	return nil
-}
-
-// sdkTagsFromResourceTags transforms a *svcapitypes.Tag array to a *svcsdk.Tag array.
-func sdkTagsFromResourceTags(rTags []*svcapitypes.Tag) []svcsdktypes.Tag {
-	tags := make([]svcsdktypes.Tag, len(rTags))
-	for i := range rTags {
-		tags[i] = svcsdktypes.Tag{
-			Key:   rTags[i].Key,
-			Value: rTags[i].Value,
+	if delta.DifferentAt("Spec.LogConfig") {
+		defer invalidateReads(latest)
+		if err = rm.syncLogConfig(ctx, desired, latest, delta); err != nil {
+			return nil, err
 		}
 	}
-	return tags
-}
-
-// sdkTagStringsFromResourceTags transforms a *svcapitypes.Tag array to a string array.
-func sdkTagStringsFromResourceTags(rTags []*svcapitypes.Tag) []string {
-	tags := make([]string, len(rTags))
-	for i := range rTags {
-		tags[i] = *rTags[i].Key
-	}
-	return tags
+	return desired, nil
 }
 
 // compareTags is a custom comparison function for comparing lists of Tag
@@ -168,11 +108,11 @@ func compareTags(
 	desired *resource,
 	latest *resource,
 ) {
-	if len(desired.ko.Spec.Tags) != len(latest.ko.Spec.Tags) {
-		delta.Add("Spec.Tags", desired.ko.Spec.Tags, latest.ko.Spec.Tags)
-		return
-	}
-	if !pkgtags.EqualTags(desired.ko.Spec.Tags, latest.ko.Spec.Tags) {
-		delta.Add("Spec.Tags", desired.ko.Spec.Tags, latest.ko.Spec.Tags)
-	}
+	pkgtags.Compare(delta, desired.ko.Spec.Tags, latest.ko.Spec.Tags)
+}
+
+// Validate runs the validations the controller applies to an EventBus before
+// creating it, without calling AWS
+func Validate(ko *svcapitypes.EventBus) error {
+	return validateReferenceFields(ko)
 }
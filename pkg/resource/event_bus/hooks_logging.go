@@ -0,0 +1,665 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package event_bus
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"hash/fnv"
+	"regexp"
+	"strings"
+	"time"
+
+	ackv1alpha1 "github.com/aws-controllers-k8s/runtime/apis/core/v1alpha1"
+	ackcompare "github.com/aws-controllers-k8s/runtime/pkg/compare"
+	ackrequeue "github.com/aws-controllers-k8s/runtime/pkg/requeue"
+	ackrtlog "github.com/aws-controllers-k8s/runtime/pkg/runtime/log"
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/aws/arn"
+	"github.com/aws/aws-sdk-go-v2/service/cloudwatchlogs"
+	logstypes "github.com/aws/aws-sdk-go-v2/service/cloudwatchlogs/types"
+	svcsdk "github.com/aws/aws-sdk-go-v2/service/eventbridge"
+	svcsdktypes "github.com/aws/aws-sdk-go-v2/service/eventbridge/types"
+	"golang.org/x/exp/slices"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	svcapitypes "github.com/aws-controllers-k8s/eventbridge-controller/apis/v1alpha1"
+	"github.com/aws-controllers-k8s/eventbridge-controller/pkg/cache"
+	"github.com/aws-controllers-k8s/eventbridge-controller/pkg/clients"
+	"github.com/aws-controllers-k8s/eventbridge-controller/pkg/references"
+)
+
+// +kubebuilder:rbac:groups=cloudwatchlogs.services.k8s.aws,resources=loggroups,verbs=get;list
+// +kubebuilder:rbac:groups=cloudwatchlogs.services.k8s.aws,resources=loggroups/status,verbs=get;list
+// +kubebuilder:rbac:groups=firehose.services.k8s.aws,resources=deliverystreams,verbs=get;list
+// +kubebuilder:rbac:groups=firehose.services.k8s.aws,resources=deliverystreams/status,verbs=get;list
+
+const (
+	// maxDeliveryNameLength is the maximum length of the names of log
+	// delivery sources and destinations
+	maxDeliveryNameLength = 60
+	// deliveryDestinationPrefix is the prefix of the names of the log
+	// delivery destinations created for event buses
+	deliveryDestinationPrefix = "eventbridge-"
+)
+
+// logTypes are the types of the logs of event buses. A delivery source is
+// created per type, the log level of the event bus decides which of them
+// receive records.
+var logTypes = []string{"ERROR_LOGS", "INFO_LOGS", "TRACE_LOGS"}
+
+var (
+	// logGroupGVK is the kind of the cloudwatchlogs controller's LogGroup
+	// resources
+	logGroupGVK = schema.GroupVersionKind{
+		Group:   "cloudwatchlogs.services.k8s.aws",
+		Version: "v1alpha1",
+		Kind:    "LogGroup",
+	}
+	// deliveryStreamGVK is the kind of the firehose controller's
+	// DeliveryStream resources
+	deliveryStreamGVK = schema.GroupVersionKind{
+		Group:   "firehose.services.k8s.aws",
+		Version: "v1alpha1",
+		Kind:    "DeliveryStream",
+	}
+)
+
+// requeueWaitForLogDeliveries requeues an event bus after it was created, its
+// log deliveries are created by the update which follows
+var requeueWaitForLogDeliveries = ackrequeue.NeededAfter(
+	errors.New("event bus created, requeueing to create its log deliveries"),
+	5*time.Second,
+)
+
+// logs returns the CloudWatch Logs client of the account and Region of the
+// resource manager, which manages the log deliveries of event buses
+func (rm *resourceManager) logs(ctx context.Context) (*cloudwatchlogs.Client, error) {
+	c, err := clients.FromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return clients.AWS(c, cloudwatchlogs.ServiceID, rm.awsAccountID, rm.awsRegion, func() *cloudwatchlogs.Client {
+		return cloudwatchlogs.NewFromConfig(rm.clientcfg)
+	}), nil
+}
+
+// logDelivery is a log delivery from a delivery source of an event bus
+type logDelivery struct {
+	id      string
+	logType string
+	// destination is the ARN of the resource the logs are delivered to
+	destination string
+}
+
+// logDeliveriesCache caches the log deliveries read by sdkFind, which would
+// otherwise be listed on every reconciliation
+var logDeliveriesCache = cache.New("event_bus_log_deliveries", slices.Clone[[]logDelivery])
+
+// logDestinationsManaged returns whether the controller manages the log
+// destinations of the event bus, which is when any of the destination fields
+// is set. An empty list removes all destinations.
+func logDestinationsManaged(ko *svcapitypes.EventBus) bool {
+	lc := ko.Spec.LogConfig
+	return lc != nil && (lc.DestinationARNs != nil || lc.LogGroupRefs != nil || lc.DeliveryStreamRefs != nil)
+}
+
+// logDestinationARN returns the ARN of a log destination as accepted by log
+// deliveries. CloudWatch Logs reports the ARNs of log groups with a ":*"
+// suffix, which deliveries don't accept.
+func logDestinationARN(s string) string {
+	return strings.TrimSuffix(s, ":*")
+}
+
+// invalidNameChars matches the characters log delivery names can't contain
+var invalidNameChars = regexp.MustCompile(`[^\w-]`)
+
+// deliverySourceName returns the name of the delivery source of the logs of
+// the given type of an event bus. Event bus names which aren't valid delivery
+// names, or are too long, are replaced by a valid prefix and a hash.
+func deliverySourceName(busName, logType string) string {
+	maxLength := maxDeliveryNameLength - len(logType) - 1
+	name := invalidNameChars.ReplaceAllString(busName, "-")
+	if name != busName || len(name) > maxLength {
+		h := fnv.New32a()
+		_, _ = h.Write([]byte(busName))
+		hash := fmt.Sprintf("%08x", h.Sum32())
+		if len(name) > maxLength-len(hash)-1 {
+			name = name[:maxLength-len(hash)-1]
+		}
+		name += "-" + hash
+	}
+	return name + "-" + logType
+}
+
+// deliveryDestinationName returns the name of the delivery destination of a
+// destination resource. Delivery destinations are shared by the event buses
+// which deliver logs to the same resource.
+func deliveryDestinationName(destination string) string {
+	h := fnv.New64a()
+	_, _ = h.Write([]byte(destination))
+	return fmt.Sprintf("%s%016x", deliveryDestinationPrefix, h.Sum64())
+}
+
+// keepLogDestinations keeps the log destinations of the desired event bus in
+// the event bus read from the API, which only returns the log level and
+// detail
+func keepLogDestinations(desired, ko *svcapitypes.EventBus) {
+	lc := desired.Spec.LogConfig
+	if lc == nil {
+		return
+	}
+	if ko.Spec.LogConfig == nil {
+		ko.Spec.LogConfig = &svcapitypes.LogConfig{}
+	}
+	c := lc.DeepCopy()
+	ko.Spec.LogConfig.DestinationARNs = c.DestinationARNs
+	ko.Spec.LogConfig.LogGroupRefs = c.LogGroupRefs
+	ko.Spec.LogConfig.DeliveryStreamRefs = c.DeliveryStreamRefs
+}
+
+// setLogConfig sets the log configuration of the event bus read from the API
+// for the fields of the desired log configuration, and reads its log
+// destinations when the controller manages them
+func (rm *resourceManager) setLogConfig(
+	ctx context.Context,
+	desired *svcapitypes.EventBus,
+	ko *svcapitypes.EventBus,
+) error {
+	lc := desired.Spec.LogConfig
+	if lc == nil {
+		return rm.setObservedLogConfig(ctx, ko)
+	}
+	if ko.Spec.LogConfig == nil {
+		ko.Spec.LogConfig = &svcapitypes.LogConfig{}
+	}
+	latest := ko.Spec.LogConfig
+	// the fields which aren't set are left to the API defaults
+	if lc.IncludeDetail == nil {
+		latest.IncludeDetail = nil
+	}
+	if lc.Level == nil {
+		latest.Level = nil
+	}
+	if !logDestinationsManaged(desired) {
+		return nil
+	}
+
+	deliveries, err := rm.logDeliveries(ctx, ko)
+	if err != nil {
+		return err
+	}
+	resolved, err := rm.desiredLogDestinations(ctx, desired)
+	if err != nil {
+		// the destinations are then updated, which fails with the
+		// resolution error
+		ackrtlog.FromContext(ctx).Info("unable to resolve log destination references", "error", err)
+	}
+	wanted := map[string]bool{}
+	for _, d := range resolved {
+		wanted[d] = true
+	}
+	deliveredTypes := map[string]int{}
+	for _, d := range deliveries {
+		deliveredTypes[d.destination]++
+	}
+	var actual []string
+	for destination, n := range deliveredTypes {
+		// destinations which miss the deliveries of some log types are
+		// reported missing when they are desired, and extra otherwise, so
+		// that the update completes or removes their deliveries
+		if n == len(logTypes) || !wanted[destination] {
+			actual = append(actual, destination)
+		}
+	}
+	slices.Sort(actual)
+
+	if err == nil && slices.Equal(actual, resolved) {
+		keepLogDestinations(desired, ko)
+		return nil
+	}
+	// the references can't be compared with the destinations read from the
+	// API, they are reported unresolved
+	latest.DestinationARNs = aws.StringSlice(actual)
+	latest.LogGroupRefs = nil
+	latest.DeliveryStreamRefs = nil
+	return nil
+}
+
+// setObservedLogConfig sets the log configuration of an event bus without a
+// desired log configuration. Logging which is on is reported with the
+// destinations of its log deliveries, so that removing the log configuration
+// turns logging off and deletes the deliveries. The deliveries aren't read
+// when logging is off.
+func (rm *resourceManager) setObservedLogConfig(
+	ctx context.Context,
+	ko *svcapitypes.EventBus,
+) error {
+	latest := ko.Spec.LogConfig
+	if latest == nil || latest.Level == nil || *latest.Level == string(svcsdktypes.LevelOff) {
+		ko.Spec.LogConfig = nil
+		return nil
+	}
+	deliveries, err := rm.logDeliveries(ctx, ko)
+	if err != nil {
+		return err
+	}
+	var destinations []string
+	for _, d := range deliveries {
+		destinations = append(destinations, d.destination)
+	}
+	if len(destinations) > 0 {
+		slices.Sort(destinations)
+		latest.DestinationARNs = aws.StringSlice(slices.Compact(destinations))
+	}
+	return nil
+}
+
+// desiredLogDestinations returns the sorted ARNs of the log destinations of
+// the event bus, including the referenced LogGroups and DeliveryStreams
+func (rm *resourceManager) desiredLogDestinations(
+	ctx context.Context,
+	ko *svcapitypes.EventBus,
+) ([]string, error) {
+	lc := ko.Spec.LogConfig
+	if lc == nil {
+		return nil, nil
+	}
+	var destinations []string
+	for _, d := range lc.DestinationARNs {
+		if d != nil && *d != "" {
+			destinations = append(destinations, logDestinationARN(*d))
+		}
+	}
+	for _, ref := range lc.LogGroupRefs {
+		d, err := rm.referencedARN(ctx, ko, logGroupGVK, "LogConfig.LogGroupRefs", ref)
+		if err != nil {
+			return nil, err
+		}
+		destinations = append(destinations, d)
+	}
+	for _, ref := range lc.DeliveryStreamRefs {
+		d, err := rm.referencedARN(ctx, ko, deliveryStreamGVK, "LogConfig.DeliveryStreamRefs", ref)
+		if err != nil {
+			return nil, err
+		}
+		destinations = append(destinations, d)
+	}
+	slices.Sort(destinations)
+	return slices.Compact(destinations), nil
+}
+
+// referencedARN returns the ARN of a resource of another ACK controller, which
+// must be synced
+func (rm *resourceManager) referencedARN(
+	ctx context.Context,
+	ko *svcapitypes.EventBus,
+	gvk schema.GroupVersionKind,
+	field string,
+	ref *ackv1alpha1.AWSResourceReferenceWrapper,
+) (string, error) {
+	var from *ackv1alpha1.AWSResourceReference
+	if ref != nil {
+		from = ref.From
+	}
+	c, err := clients.FromContext(ctx)
+	if err != nil {
+		return "", err
+	}
+	resourceARN, err := references.Resolve(ctx, c.APIReader, references.ResolveConfig{
+		EnableCrossNamespace: rm.cfg.EnableCrossNamespace,
+		Namespace:            ko.GetNamespace(),
+		Conditions:           &ko.Status.Conditions,
+	}, references.Reference{
+		Kind:   gvk,
+		Field:  field,
+		From:   from,
+		Target: references.ARN,
+	})
+	if err != nil {
+		return "", err
+	}
+	return logDestinationARN(resourceARN), nil
+}
+
+// logDeliveries returns the log deliveries of the event bus
+func (rm *resourceManager) logDeliveries(
+	ctx context.Context,
+	ko *svcapitypes.EventBus,
+) ([]logDelivery, error) {
+	if ko.Status.ACKResourceMetadata == nil || ko.Status.ACKResourceMetadata.ARN == nil {
+		return rm.readLogDeliveries(ctx, *ko.Spec.Name)
+	}
+	busARN := string(*ko.Status.ACKResourceMetadata.ARN)
+	if deliveries, ok := logDeliveriesCache.Get(busARN, ko.Generation); ok {
+		return deliveries, nil
+	}
+	deliveries, err := rm.readLogDeliveries(ctx, *ko.Spec.Name)
+	if err != nil {
+		return nil, err
+	}
+	logDeliveriesCache.Set(busARN, ko.Generation, deliveries)
+	return deliveries, nil
+}
+
+// readLogDeliveries lists the log deliveries from the delivery sources of the
+// event bus with the given name
+func (rm *resourceManager) readLogDeliveries(
+	ctx context.Context,
+	busName string,
+) ([]logDelivery, error) {
+	logs, err := rm.logs(ctx)
+	if err != nil {
+		return nil, err
+	}
+	sources := map[string]string{}
+	for _, logType := range logTypes {
+		sources[deliverySourceName(busName, logType)] = logType
+	}
+	// destinations are the ARNs of the resources of the delivery
+	// destinations, by delivery destination ARN
+	destinations := map[string]string{}
+
+	var deliveries []logDelivery
+	p := cloudwatchlogs.NewDescribeDeliveriesPaginator(logs, &cloudwatchlogs.DescribeDeliveriesInput{})
+	for p.HasMorePages() {
+		page, err := p.NextPage(ctx)
+		rm.metrics.RecordAPICall("READ_MANY", "DescribeDeliveries", err)
+		if err != nil {
+			return nil, err
+		}
+		for _, d := range page.Deliveries {
+			logType, ok := sources[aws.ToString(d.DeliverySourceName)]
+			if !ok {
+				continue
+			}
+			destinationARN := aws.ToString(d.DeliveryDestinationArn)
+			destination, ok := destinations[destinationARN]
+			if !ok {
+				if destination, err = rm.deliveryDestinationResource(ctx, destinationARN); err != nil {
+					return nil, err
+				}
+				destinations[destinationARN] = destination
+			}
+			deliveries = append(deliveries, logDelivery{
+				id:          aws.ToString(d.Id),
+				logType:     logType,
+				destination: destination,
+			})
+		}
+	}
+	return deliveries, nil
+}
+
+// deliveryDestinationResource returns the ARN of the resource of a delivery
+// destination
+func (rm *resourceManager) deliveryDestinationResource(
+	ctx context.Context,
+	destinationARN string,
+) (string, error) {
+	parsed, err := arn.Parse(destinationARN)
+	if err != nil {
+		return "", err
+	}
+	logs, err := rm.logs(ctx)
+	if err != nil {
+		return "", err
+	}
+	resp, err := logs.GetDeliveryDestination(ctx, &cloudwatchlogs.GetDeliveryDestinationInput{
+		Name: aws.String(strings.TrimPrefix(parsed.Resource, "delivery-destination:")),
+	})
+	rm.metrics.RecordAPICall("READ_ONE", "GetDeliveryDestination", err)
+	if err != nil {
+		return "", err
+	}
+	if resp.DeliveryDestination == nil || resp.DeliveryDestination.DeliveryDestinationConfiguration == nil {
+		return "", nil
+	}
+	return aws.ToString(resp.DeliveryDestination.DeliveryDestinationConfiguration.DestinationResourceArn), nil
+}
+
+// syncLogConfig updates the log level and detail of the event bus, and its
+// log deliveries when the controller manages its log destinations. Logging is
+// turned off and the log deliveries are deleted when the log configuration is
+// removed.
+func (rm *resourceManager) syncLogConfig(
+	ctx context.Context,
+	desired *resource,
+	latest *resource,
+	delta *ackcompare.Delta,
+) error {
+	if desired.ko.Spec.LogConfig == nil {
+		// the deliveries are deleted first, they aren't read once logging
+		// is off
+		if err := rm.deleteLogDeliveries(ctx, latest.ko); err != nil {
+			return err
+		}
+		return rm.updateLogConfig(ctx, desired.ko)
+	}
+	if delta.DifferentAt("Spec.LogConfig.IncludeDetail") || delta.DifferentAt("Spec.LogConfig.Level") {
+		if err := rm.updateLogConfig(ctx, desired.ko); err != nil {
+			return err
+		}
+	}
+	if logDestinationsManaged(desired.ko) && (delta.DifferentAt("Spec.LogConfig.DestinationARNs") ||
+		delta.DifferentAt("Spec.LogConfig.LogGroupRefs") ||
+		delta.DifferentAt("Spec.LogConfig.DeliveryStreamRefs")) {
+		return rm.syncLogDeliveries(ctx, desired.ko, string(*latest.ko.Status.ACKResourceMetadata.ARN))
+	}
+	return nil
+}
+
+// updateLogConfig updates the log level and detail of the event bus, or turns
+// logging off without a log configuration. UpdateEventBus replaces the description, KMS key and dead-letter queue of
+// the event bus, which the controller doesn't manage, so they are read first
+// and kept.
+func (rm *resourceManager) updateLogConfig(
+	ctx context.Context,
+	ko *svcapitypes.EventBus,
+) error {
+	bus, err := rm.sdkapi.DescribeEventBus(ctx, &svcsdk.DescribeEventBusInput{Name: ko.Spec.Name})
+	rm.metrics.RecordAPICall("READ_ONE", "DescribeEventBus", err)
+	if err != nil {
+		return err
+	}
+	logConfig := &svcsdktypes.LogConfig{
+		IncludeDetail: svcsdktypes.IncludeDetailNone,
+		Level:         svcsdktypes.LevelOff,
+	}
+	if lc := ko.Spec.LogConfig; lc != nil {
+		if bus.LogConfig != nil {
+			logConfig.IncludeDetail = bus.LogConfig.IncludeDetail
+			logConfig.Level = bus.LogConfig.Level
+		}
+		if lc.IncludeDetail != nil {
+			logConfig.IncludeDetail = svcsdktypes.IncludeDetail(*lc.IncludeDetail)
+		}
+		if lc.Level != nil {
+			logConfig.Level = svcsdktypes.Level(*lc.Level)
+		}
+	}
+	_, err = rm.sdkapi.UpdateEventBus(ctx, &svcsdk.UpdateEventBusInput{
+		Name:             ko.Spec.Name,
+		Description:      bus.Description,
+		KmsKeyIdentifier: bus.KmsKeyIdentifier,
+		DeadLetterConfig: bus.DeadLetterConfig,
+		LogConfig:        logConfig,
+	})
+	rm.metrics.RecordAPICall("UPDATE", "UpdateEventBus", err)
+	return err
+}
+
+// syncLogDeliveries creates the delivery sources of the event bus and the
+// deliveries of all log types to its destinations, and deletes the
+// deliveries to other destinations
+func (rm *resourceManager) syncLogDeliveries(
+	ctx context.Context,
+	ko *svcapitypes.EventBus,
+	busARN string,
+) error {
+	destinations, err := rm.desiredLogDestinations(ctx, ko)
+	if err != nil {
+		return err
+	}
+	logs, err := rm.logs(ctx)
+	if err != nil {
+		return err
+	}
+	busName := *ko.Spec.Name
+	deliveries, err := rm.readLogDeliveries(ctx, busName)
+	if err != nil {
+		return err
+	}
+
+	wanted := map[string]bool{}
+	for _, d := range destinations {
+		wanted[d] = true
+	}
+	existing := map[logDelivery]bool{}
+	var removed []string
+	for _, d := range deliveries {
+		if wanted[d.destination] {
+			existing[logDelivery{logType: d.logType, destination: d.destination}] = true
+			continue
+		}
+		if err := rm.deleteLogDelivery(ctx, d.id); err != nil {
+			return err
+		}
+		removed = append(removed, d.destination)
+	}
+	for _, d := range removed {
+		if err := rm.deleteDeliveryDestination(ctx, d); err != nil {
+			return err
+		}
+	}
+	if len(destinations) == 0 {
+		return rm.deleteDeliverySources(ctx, busName)
+	}
+
+	for _, logType := range logTypes {
+		_, err := logs.PutDeliverySource(ctx, &cloudwatchlogs.PutDeliverySourceInput{
+			Name:        aws.String(deliverySourceName(busName, logType)),
+			ResourceArn: aws.String(busARN),
+			LogType:     aws.String(logType),
+		})
+		rm.metrics.RecordAPICall("CREATE", "PutDeliverySource", err)
+		if err != nil {
+			return err
+		}
+	}
+	for _, d := range destinations {
+		resp, err := logs.PutDeliveryDestination(ctx, &cloudwatchlogs.PutDeliveryDestinationInput{
+			Name: aws.String(deliveryDestinationName(d)),
+			DeliveryDestinationConfiguration: &logstypes.DeliveryDestinationConfiguration{
+				DestinationResourceArn: aws.String(d),
+			},
+		})
+		rm.metrics.RecordAPICall("CREATE", "PutDeliveryDestination", err)
+		if err != nil {
+			return err
+		}
+		for _, logType := range logTypes {
+			if existing[logDelivery{logType: logType, destination: d}] {
+				continue
+			}
+			_, err := logs.CreateDelivery(ctx, &cloudwatchlogs.CreateDeliveryInput{
+				DeliverySourceName:     aws.String(deliverySourceName(busName, logType)),
+				DeliveryDestinationArn: resp.DeliveryDestination.Arn,
+			})
+			rm.metrics.RecordAPICall("CREATE", "CreateDelivery", err)
+			if err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// deleteLogDeliveries deletes the log deliveries and delivery sources of the
+// event bus, when the controller manages its log destinations
+func (rm *resourceManager) deleteLogDeliveries(
+	ctx context.Context,
+	ko *svcapitypes.EventBus,
+) error {
+	if !logDestinationsManaged(ko) || ko.Spec.Name == nil {
+		return nil
+	}
+	deliveries, err := rm.readLogDeliveries(ctx, *ko.Spec.Name)
+	if err != nil {
+		return err
+	}
+	for _, d := range deliveries {
+		if err := rm.deleteLogDelivery(ctx, d.id); err != nil {
+			return err
+		}
+	}
+	for _, d := range deliveries {
+		if err := rm.deleteDeliveryDestination(ctx, d.destination); err != nil {
+			return err
+		}
+	}
+	return rm.deleteDeliverySources(ctx, *ko.Spec.Name)
+}
+
+func (rm *resourceManager) deleteLogDelivery(ctx context.Context, id string) error {
+	logs, err := rm.logs(ctx)
+	if err != nil {
+		return err
+	}
+	_, err = logs.DeleteDelivery(ctx, &cloudwatchlogs.DeleteDeliveryInput{Id: aws.String(id)})
+	rm.metrics.RecordAPICall("DELETE", "DeleteDelivery", err)
+	var notFound *logstypes.ResourceNotFoundException
+	if errors.As(err, &notFound) {
+		return nil
+	}
+	return err
+}
+
+// deleteDeliveryDestination deletes the delivery destination of a
+// destination resource, unless other event buses still deliver logs to it
+func (rm *resourceManager) deleteDeliveryDestination(ctx context.Context, destination string) error {
+	logs, err := rm.logs(ctx)
+	if err != nil {
+		return err
+	}
+	_, err = logs.DeleteDeliveryDestination(ctx, &cloudwatchlogs.DeleteDeliveryDestinationInput{
+		Name: aws.String(deliveryDestinationName(destination)),
+	})
+	rm.metrics.RecordAPICall("DELETE", "DeleteDeliveryDestination", err)
+	var notFound *logstypes.ResourceNotFoundException
+	var conflict *logstypes.ConflictException
+	if errors.As(err, &notFound) || errors.As(err, &conflict) {
+		return nil
+	}
+	return err
+}
+
+func (rm *resourceManager) deleteDeliverySources(ctx context.Context, busName string) error {
+	logs, err := rm.logs(ctx)
+	if err != nil {
+		return err
+	}
+	for _, logType := range logTypes {
+		_, err := logs.DeleteDeliverySource(ctx, &cloudwatchlogs.DeleteDeliverySourceInput{
+			Name: aws.String(deliverySourceName(busName, logType)),
+		})
+		rm.metrics.RecordAPICall("DELETE", "DeleteDeliverySource", err)
+		var notFound *logstypes.ResourceNotFoundException
+		if err != nil && !errors.As(err, &notFound) {
+			return err
+		}
+	}
+	return nil
+}
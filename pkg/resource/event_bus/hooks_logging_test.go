@@ -0,0 +1,265 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package event_bus
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	ackv1alpha1 "github.com/aws-controllers-k8s/runtime/apis/core/v1alpha1"
+	ackerr "github.com/aws-controllers-k8s/runtime/pkg/errors"
+	"github.com/aws/aws-sdk-go-v2/aws"
+	svcsdk "github.com/aws/aws-sdk-go-v2/service/eventbridge"
+	"gotest.tools/v3/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	ctrlrtfake "sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	svcapitypes "github.com/aws-controllers-k8s/eventbridge-controller/apis/v1alpha1"
+	"github.com/aws-controllers-k8s/eventbridge-controller/pkg/clients"
+	"github.com/aws-controllers-k8s/eventbridge-controller/pkg/testutil"
+)
+
+const (
+	testLogGroupARN       = "arn:aws:logs:us-west-2:123456789012:log-group:/aws/events/test-bus"
+	testDeliveryStreamARN = "arn:aws:firehose:us-west-2:123456789012:deliverystream/test-bus"
+)
+
+// newLogGroup returns a LogGroup of the cloudwatchlogs controller with the
+// given ARN and conditions
+func newLogGroup(name, arn string, conditions ...ackv1alpha1.ConditionType) *unstructured.Unstructured {
+	obj := &unstructured.Unstructured{}
+	obj.SetGroupVersionKind(logGroupGVK)
+	obj.SetNamespace("default")
+	obj.SetName(name)
+	var conds []interface{}
+	for _, c := range conditions {
+		conds = append(conds, map[string]interface{}{"type": string(c), "status": "True"})
+	}
+	obj.Object["status"] = map[string]interface{}{
+		"ackResourceMetadata": map[string]interface{}{"arn": arn},
+		"conditions":          conds,
+	}
+	return obj
+}
+
+func Test_deliverySourceName(t *testing.T) {
+	tests := []struct {
+		name    string
+		busName string
+		want    string
+	}{
+		{
+			name:    "valid name",
+			busName: "orders",
+			want:    "orders-INFO_LOGS",
+		},
+		{
+			name:    "invalid characters",
+			busName: "aws.partner/saas.com/1234",
+			want:    "aws-partner-saas-com-1234-",
+		},
+		{
+			name:    "long name",
+			busName: strings.Repeat("a", 256),
+			want:    strings.Repeat("a", 41) + "-",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := deliverySourceName(tt.busName, "INFO_LOGS")
+			assert.Assert(t, strings.HasPrefix(got, tt.want), "got %s", got)
+			assert.Assert(t, strings.HasSuffix(got, "-INFO_LOGS"), "got %s", got)
+			assert.Assert(t, len(got) <= maxDeliveryNameLength, "got %s", got)
+			assert.Assert(t, !invalidNameChars.MatchString(got), "got %s", got)
+			assert.Equal(t, got, deliverySourceName(tt.busName, "INFO_LOGS"))
+		})
+	}
+	assert.Assert(t, deliverySourceName("a.b", "INFO_LOGS") != deliverySourceName("a-b", "INFO_LOGS"))
+}
+
+func Test_resourceManager_logDestinations(t *testing.T) {
+	kc := ctrlrtfake.NewClientBuilder().WithObjects(
+		newLogGroup("synced", testLogGroupARN+":*", ackv1alpha1.ConditionTypeResourceSynced),
+		newLogGroup("creating", ""),
+	).Build()
+	ctx := clients.NewContext(context.Background(), clients.New(nil, kc))
+	fake := testutil.NewEventBridge()
+	rm := newTestResourceManager(t, fake)
+	desired := &resource{ko: &svcapitypes.EventBus{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "test-bus"},
+		Spec: svcapitypes.EventBusSpec{
+			Name: aws.String("test-bus"),
+			LogConfig: &svcapitypes.LogConfig{
+				IncludeDetail:   aws.String("FULL"),
+				Level:           aws.String("INFO"),
+				DestinationARNs: []*string{aws.String(testDeliveryStreamARN)},
+				LogGroupRefs: []*ackv1alpha1.AWSResourceReferenceWrapper{
+					{From: &ackv1alpha1.AWSResourceReference{Name: aws.String("synced")}},
+				},
+			},
+		},
+	}}
+
+	// the deliveries are created by the update which follows the creation
+	created, err := rm.sdkCreate(ctx, desired)
+	assert.Equal(t, err, requeueWaitForLogDeliveries)
+	assert.DeepEqual(t, created.ko.Spec.LogConfig, desired.ko.Spec.LogConfig)
+	busARN := string(*created.ko.Status.ACKResourceMetadata.ARN)
+
+	desired = created
+	latest, err := rm.sdkFind(ctx, desired)
+	assert.NilError(t, err)
+	delta := newResourceDelta(desired, latest)
+	assert.Assert(t, delta.DifferentAt("Spec.LogConfig.DestinationARNs"))
+	assert.Assert(t, !delta.DifferentAt("Spec.LogConfig.Level"))
+	_, err = rm.sdkUpdate(ctx, desired, latest, delta)
+	assert.NilError(t, err)
+	both := []string{testDeliveryStreamARN, testLogGroupARN}
+	assert.DeepEqual(t, fake.LogDeliveries(busARN), map[string][]string{
+		"ERROR_LOGS": both,
+		"INFO_LOGS":  both,
+		"TRACE_LOGS": both,
+	})
+
+	latest, err = rm.sdkFind(ctx, desired)
+	assert.NilError(t, err)
+	assert.Assert(t, !newResourceDelta(desired, latest).DifferentAt("Spec.LogConfig"))
+
+	// the log level is updated and the removed destination's deliveries
+	// are deleted
+	desired.ko.Spec.LogConfig.Level = aws.String("ERROR")
+	desired.ko.Spec.LogConfig.DestinationARNs = nil
+	latest, err = rm.sdkFind(ctx, desired)
+	assert.NilError(t, err)
+	delta = newResourceDelta(desired, latest)
+	assert.Assert(t, delta.DifferentAt("Spec.LogConfig.Level"))
+	assert.Assert(t, delta.DifferentAt("Spec.LogConfig.DestinationARNs"))
+	_, err = rm.sdkUpdate(ctx, desired, latest, delta)
+	assert.NilError(t, err)
+	bus, err := rm.sdkapi.DescribeEventBus(ctx, &svcsdk.DescribeEventBusInput{Name: aws.String("test-bus")})
+	assert.NilError(t, err)
+	assert.Equal(t, string(bus.LogConfig.Level), "ERROR")
+	assert.Equal(t, string(bus.LogConfig.IncludeDetail), "FULL")
+	assert.DeepEqual(t, fake.LogDeliveries(busARN), map[string][]string{
+		"ERROR_LOGS": {testLogGroupARN},
+		"INFO_LOGS":  {testLogGroupARN},
+		"TRACE_LOGS": {testLogGroupARN},
+	})
+	sources, destinations := fake.DeliveryResources()
+	assert.Equal(t, sources, 3)
+	assert.Equal(t, destinations, 1)
+
+	latest, err = rm.sdkFind(ctx, desired)
+	assert.NilError(t, err)
+	assert.Assert(t, !newResourceDelta(desired, latest).DifferentAt("Spec.LogConfig"))
+
+	// the update fails until the referenced log group is synced
+	notSynced := desired.DeepCopy().(*resource)
+	notSynced.ko.Spec.LogConfig.LogGroupRefs[0].From.Name = aws.String("creating")
+	latest, err = rm.sdkFind(ctx, notSynced)
+	assert.NilError(t, err)
+	_, err = rm.sdkUpdate(ctx, notSynced, latest, newResourceDelta(notSynced, latest))
+	assert.Assert(t, errors.Is(err, ackerr.ResourceReferenceNotSynced), "got %v", err)
+
+	// the deliveries are deleted with the event bus
+	_, err = rm.sdkDelete(ctx, desired)
+	assert.NilError(t, err)
+	assert.DeepEqual(t, fake.LogDeliveries(busARN), map[string][]string{})
+	sources, destinations = fake.DeliveryResources()
+	assert.Equal(t, sources, 0)
+	assert.Equal(t, destinations, 0)
+}
+
+func Test_resourceManager_logConfigRemoved(t *testing.T) {
+	ctx := clients.NewContext(context.Background(), clients.New(nil, nil))
+	fake := testutil.NewEventBridge()
+	rm := newTestResourceManager(t, fake)
+	desired := &resource{ko: &svcapitypes.EventBus{
+		Spec: svcapitypes.EventBusSpec{
+			Name: aws.String("test-bus"),
+			LogConfig: &svcapitypes.LogConfig{
+				Level:           aws.String("INFO"),
+				DestinationARNs: []*string{aws.String(testDeliveryStreamARN)},
+			},
+		},
+	}}
+	created, err := rm.sdkCreate(ctx, desired)
+	assert.Equal(t, err, requeueWaitForLogDeliveries)
+	busARN := string(*created.ko.Status.ACKResourceMetadata.ARN)
+	latest, err := rm.sdkFind(ctx, created)
+	assert.NilError(t, err)
+	_, err = rm.sdkUpdate(ctx, created, latest, newResourceDelta(created, latest))
+	assert.NilError(t, err)
+	assert.Equal(t, len(fake.LogDeliveries(busARN)), len(logTypes))
+
+	// removing the log configuration reports the observed logging, which
+	// is turned off and its deliveries deleted
+	desired = created.DeepCopy().(*resource)
+	desired.ko.Spec.LogConfig = nil
+	latest, err = rm.sdkFind(ctx, desired)
+	assert.NilError(t, err)
+	assert.Equal(t, aws.ToString(latest.ko.Spec.LogConfig.Level), "INFO")
+	assert.DeepEqual(t, aws.ToStringSlice(latest.ko.Spec.LogConfig.DestinationARNs), []string{testDeliveryStreamARN})
+	delta := newResourceDelta(desired, latest)
+	assert.Assert(t, delta.DifferentAt("Spec.LogConfig"))
+	_, err = rm.sdkUpdate(ctx, desired, latest, delta)
+	assert.NilError(t, err)
+	bus, err := rm.sdkapi.DescribeEventBus(ctx, &svcsdk.DescribeEventBusInput{Name: aws.String("test-bus")})
+	assert.NilError(t, err)
+	assert.Equal(t, string(bus.LogConfig.Level), "OFF")
+	assert.DeepEqual(t, fake.LogDeliveries(busARN), map[string][]string{})
+	sources, destinations := fake.DeliveryResources()
+	assert.Equal(t, sources, 0)
+	assert.Equal(t, destinations, 0)
+
+	// the deliveries aren't read once logging is off
+	fake.ResetCalls()
+	latest, err = rm.sdkFind(ctx, desired)
+	assert.NilError(t, err)
+	assert.Assert(t, latest.ko.Spec.LogConfig == nil)
+	assert.Assert(t, !newResourceDelta(desired, latest).DifferentAt("Spec.LogConfig"))
+	for _, call := range fake.Calls() {
+		assert.Assert(t, call != "DescribeDeliveries")
+	}
+}
+
+func Test_resourceManager_logDestinationsUnmanaged(t *testing.T) {
+	ctx := clients.NewContext(context.Background(), clients.New(nil, nil))
+	fake := testutil.NewEventBridge()
+	rm := newTestResourceManager(t, fake)
+	desired := &resource{ko: &svcapitypes.EventBus{
+		Spec: svcapitypes.EventBusSpec{
+			Name:      aws.String("test-bus"),
+			LogConfig: &svcapitypes.LogConfig{Level: aws.String("TRACE")},
+		},
+	}}
+
+	// without destinations the log level is set on creation and the
+	// deliveries aren't read
+	created, err := rm.sdkCreate(ctx, desired)
+	assert.NilError(t, err)
+	fake.ResetCalls()
+	latest, err := rm.sdkFind(ctx, created)
+	assert.NilError(t, err)
+	assert.Equal(t, aws.ToString(latest.ko.Spec.LogConfig.Level), "TRACE")
+	assert.Assert(t, !newResourceDelta(created, latest).DifferentAt("Spec.LogConfig"))
+	for _, call := range fake.Calls() {
+		assert.Assert(t, call != "DescribeDeliveries")
+	}
+	_, err = rm.sdkDelete(ctx, latest)
+	assert.NilError(t, err)
+}
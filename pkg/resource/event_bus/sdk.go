@@ -97,6 +97,18 @@ func (rm *resourceManager) sdkFind(
 		arn := ackv1alpha1.AWSResourceName(*resp.Arn)
 		ko.Status.ACKResourceMetadata.ARN = &arn
 	}
+	if resp.LogConfig != nil {
+		f6 := &svcapitypes.LogConfig{}
+		if resp.LogConfig.IncludeDetail != "" {
+			f6.IncludeDetail = aws.String(string(resp.LogConfig.IncludeDetail))
+		}
+		if resp.LogConfig.Level != "" {
+			f6.Level = aws.String(string(resp.LogConfig.Level))
+		}
+		ko.Spec.LogConfig = f6
+	} else {
+		ko.Spec.LogConfig = nil
+	}
 	if resp.Name != nil {
 		ko.Spec.Name = resp.Name
 	} else {
@@ -107,6 +119,9 @@ func (rm *resourceManager) sdkFind(
 	if err := rm.setResourceAdditionalFields(ctx, ko); err != nil {
 		return nil, err
 	}
+	if err := rm.setLogConfig(ctx, r.ko, ko); err != nil {
+		return nil, err
+	}
 
 	return &resource{ko}, nil
 }
@@ -169,8 +184,24 @@ func (rm *resourceManager) sdkCreate(
 		arn := ackv1alpha1.AWSResourceName(*resp.EventBusArn)
 		ko.Status.ACKResourceMetadata.ARN = &arn
 	}
+	if resp.LogConfig != nil {
+		f1 := &svcapitypes.LogConfig{}
+		if resp.LogConfig.IncludeDetail != "" {
+			f1.IncludeDetail = aws.String(string(resp.LogConfig.IncludeDetail))
+		}
+		if resp.LogConfig.Level != "" {
+			f1.Level = aws.String(string(resp.LogConfig.Level))
+		}
+		ko.Spec.LogConfig = f1
+	} else {
+		ko.Spec.LogConfig = nil
+	}
 
 	rm.setStatusDefaults(ko)
+	keepLogDestinations(desired.ko, ko)
+	if logDestinationsManaged(ko) {
+		return &resource{ko}, requeueWaitForLogDeliveries
+	}
 	return &resource{ko}, nil
 }
 
@@ -185,22 +216,32 @@ func (rm *resourceManager) newCreateRequestPayload(
 	if r.ko.Spec.EventSourceName != nil {
 		res.EventSourceName = r.ko.Spec.EventSourceName
 	}
+	if r.ko.Spec.LogConfig != nil {
+		f1 := &svcsdktypes.LogConfig{}
+		if r.ko.Spec.LogConfig.IncludeDetail != nil {
+			f1.IncludeDetail = svcsdktypes.IncludeDetail(*r.ko.Spec.LogConfig.IncludeDetail)
+		}
+		if r.ko.Spec.LogConfig.Level != nil {
+			f1.Level = svcsdktypes.Level(*r.ko.Spec.LogConfig.Level)
+		}
+		res.LogConfig = f1
+	}
 	if r.ko.Spec.Name != nil {
 		res.Name = r.ko.Spec.Name
 	}
 	if r.ko.Spec.Tags != nil {
-		f2 := []svcsdktypes.Tag{}
-		for _, f2iter := range r.ko.Spec.Tags {
-			f2elem := &svcsdktypes.Tag{}
-			if f2iter.Key != nil {
-				f2elem.Key = f2iter.Key
+		f3 := []svcsdktypes.Tag{}
+		for _, f3iter := range r.ko.Spec.Tags {
+			f3elem := &svcsdktypes.Tag{}
+			if f3iter.Key != nil {
+				f3elem.Key = f3iter.Key
 			}
-			if f2iter.Value != nil {
-				f2elem.Value = f2iter.Value
+			if f3iter.Value != nil {
+				f3elem.Value = f3iter.Value
 			}
-			f2 = append(f2, *f2elem)
+			f3 = append(f3, *f3elem)
 		}
-		res.Tags = f2
+		res.Tags = f3
 	}
 
 	return res, nil
@@ -227,6 +268,9 @@ func (rm *resourceManager) sdkDelete(
 	defer func() {
 		exit(err)
 	}()
+	if err = rm.deleteLogDeliveries(ctx, r.ko); err != nil {
+		return nil, err
+	}
 	input, err := rm.newDeleteRequestPayload(r)
 	if err != nil {
 		return nil, err
@@ -235,6 +279,7 @@ func (rm *resourceManager) sdkDelete(
 	_ = resp
 	resp, err = rm.sdkapi.DeleteEventBus(ctx, input)
 	rm.metrics.RecordAPICall("DELETE", "DeleteEventBus", err)
+	invalidateReads(r)
 	return nil, err
 }
 
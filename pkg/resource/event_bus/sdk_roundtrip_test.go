@@ -0,0 +1,70 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package event_bus
+
+import (
+	"context"
+	"fmt"
+	"math/rand"
+	"sort"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/google/go-cmp/cmp"
+
+	svcapitypes "github.com/aws-controllers-k8s/eventbridge-controller/apis/v1alpha1"
+	"github.com/aws-controllers-k8s/eventbridge-controller/pkg/testutil"
+)
+
+func Test_resourceManager_specRoundTrip(t *testing.T) {
+	ctx := context.Background()
+	rm := newTestResourceManager(t, testutil.NewEventBridge())
+
+	testutil.CheckProperty(t, func(rnd *rand.Rand) error {
+		var spec svcapitypes.EventBusSpec
+		testutil.Fill(&spec, rnd)
+		spec.Name = aws.String(testutil.RandomString(rnd, 16))
+		for i, tag := range spec.Tags {
+			tag.Key = aws.String(fmt.Sprintf("key-%d", i))
+		}
+		// the log destinations are written by updates, through CloudWatch
+		// Logs
+		if spec.LogConfig != nil {
+			spec.LogConfig.DestinationARNs = nil
+			spec.LogConfig.LogGroupRefs = nil
+			spec.LogConfig.DeliveryStreamRefs = nil
+		}
+
+		created, err := rm.sdkCreate(ctx, &resource{ko: &svcapitypes.EventBus{Spec: spec}})
+		if err != nil {
+			return err
+		}
+		latest, err := rm.sdkFind(ctx, created)
+		if err != nil {
+			return err
+		}
+		if _, err := rm.sdkDelete(ctx, latest); err != nil {
+			return err
+		}
+
+		// the API doesn't guarantee the order of tags
+		sort.Slice(latest.ko.Spec.Tags, func(i, j int) bool {
+			return *latest.ko.Spec.Tags[i].Key < *latest.ko.Spec.Tags[j].Key
+		})
+		if diff := cmp.Diff(spec, latest.ko.Spec); diff != "" {
+			return fmt.Errorf("spec differs (-want +got):\n%s", diff)
+		}
+		return nil
+	})
+}
@@ -0,0 +1,124 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package event_bus
+
+import (
+	"context"
+	"testing"
+
+	ackcfg "github.com/aws-controllers-k8s/runtime/pkg/config"
+	ackerr "github.com/aws-controllers-k8s/runtime/pkg/errors"
+	ackmetrics "github.com/aws-controllers-k8s/runtime/pkg/metrics"
+	"github.com/aws/aws-sdk-go-v2/aws"
+	svcsdk "github.com/aws/aws-sdk-go-v2/service/eventbridge"
+	"github.com/go-logr/logr"
+	"gotest.tools/v3/assert"
+
+	svcapitypes "github.com/aws-controllers-k8s/eventbridge-controller/apis/v1alpha1"
+	"github.com/aws-controllers-k8s/eventbridge-controller/pkg/testutil"
+)
+
+func newTestResourceManager(t *testing.T, fake *testutil.EventBridge) *resourceManager {
+	t.Helper()
+	rm, err := newResourceManager(
+		ackcfg.Config{}, fake.Config(), logr.Discard(),
+		ackmetrics.NewMetrics("eventbridge"), nil,
+		testutil.DefaultAccountID, testutil.DefaultRegion,
+	)
+	assert.NilError(t, err)
+	// the fake reuses the ARNs of other tests
+	tagsCache.Reset()
+	logDeliveriesCache.Reset()
+	return rm
+}
+
+func Test_resourceManager_lifecycle(t *testing.T) {
+	ctx := context.Background()
+	fake := testutil.NewEventBridge()
+	rm := newTestResourceManager(t, fake)
+
+	desired := &resource{ko: &svcapitypes.EventBus{
+		Spec: svcapitypes.EventBusSpec{
+			Name: aws.String("test-bus"),
+			Tags: []*svcapitypes.Tag{
+				{Key: aws.String("team"), Value: aws.String("a")},
+			},
+		},
+	}}
+
+	_, err := rm.sdkFind(ctx, desired)
+	assert.Equal(t, err, ackerr.NotFound)
+
+	created, err := rm.sdkCreate(ctx, desired)
+	assert.NilError(t, err)
+	assert.Equal(t,
+		string(*created.ko.Status.ACKResourceMetadata.ARN),
+		"arn:aws:events:us-west-2:123456789012:event-bus/test-bus",
+	)
+
+	latest, err := rm.sdkFind(ctx, created)
+	assert.NilError(t, err)
+	assert.Equal(t, len(latest.ko.Spec.Tags), 1)
+
+	desired = latest.DeepCopy().(*resource)
+	desired.ko.Spec.Tags = []*svcapitypes.Tag{
+		{Key: aws.String("env"), Value: aws.String("test")},
+	}
+	_, err = rm.sdkUpdate(ctx, desired, latest, newResourceDelta(desired, latest))
+	assert.NilError(t, err)
+
+	latest, err = rm.sdkFind(ctx, desired)
+	assert.NilError(t, err)
+	assert.Equal(t, len(latest.ko.Spec.Tags), 1)
+	assert.Equal(t, aws.ToString(latest.ko.Spec.Tags[0].Key), "env")
+
+	// event buses with rules can't be deleted
+	client := svcsdk.NewFromConfig(fake.Config())
+	_, err = client.PutRule(ctx, &svcsdk.PutRuleInput{
+		Name:         aws.String("test-rule"),
+		EventBusName: aws.String("test-bus"),
+		EventPattern: aws.String(`{"source":["test"]}`),
+	})
+	assert.NilError(t, err)
+	_, err = rm.sdkDelete(ctx, latest)
+	assert.ErrorContains(t, err, "ValidationException")
+
+	_, err = client.DeleteRule(ctx, &svcsdk.DeleteRuleInput{
+		Name:         aws.String("test-rule"),
+		EventBusName: aws.String("test-bus"),
+	})
+	assert.NilError(t, err)
+	_, err = rm.sdkDelete(ctx, latest)
+	assert.NilError(t, err)
+	_, err = rm.sdkFind(ctx, latest)
+	assert.Equal(t, err, ackerr.NotFound)
+}
+
+func Test_resourceManager_ARNFromName(t *testing.T) {
+	rm := newTestResourceManager(t, testutil.NewEventBridge())
+	rm.awsPartition = "aws"
+	tests := []struct {
+		name string
+		in   string
+		want string
+	}{
+		{name: "custom event bus", in: "orders", want: "arn:aws:events:us-west-2:123456789012:event-bus/orders"},
+		{name: "partner event bus", in: "aws.partner/saas.com/1234", want: "arn:aws:events:us-west-2:123456789012:event-bus/aws.partner/saas.com/1234"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, rm.ARNFromName(tt.in), tt.want)
+		})
+	}
+}
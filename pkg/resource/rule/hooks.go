@@ -19,11 +19,13 @@ import (
 
 	ackcompare "github.com/aws-controllers-k8s/runtime/pkg/compare"
 	ackrtlog "github.com/aws-controllers-k8s/runtime/pkg/runtime/log"
+	"github.com/aws/aws-sdk-go-v2/aws"
 	svcsdk "github.com/aws/aws-sdk-go-v2/service/eventbridge"
 	svcsdktypes "github.com/aws/aws-sdk-go-v2/service/eventbridge/types"
 
 	"github.com/aws-controllers-k8s/eventbridge-controller/apis/v1alpha1"
 	svcapitypes "github.com/aws-controllers-k8s/eventbridge-controller/apis/v1alpha1"
+	"github.com/aws-controllers-k8s/eventbridge-controller/pkg/arns"
 	pkgtags "github.com/aws-controllers-k8s/eventbridge-controller/pkg/tags"
 )
 
@@ -86,8 +88,11 @@ func validateRuleSpec(spec v1alpha1.RuleSpec) error {
 			)
 		}
 	}
+	if err := validateTargetParameters(spec.Targets); err != nil {
+		return err
+	}
 
-	return nil
+	return validateTargetInputs(spec)
 }
 
 // setResourceAdditionalFields will set the fields that are not returned by
@@ -102,15 +107,25 @@ func (rm *resourceManager) setResourceAdditionalFields(
 
 	if ko.Status.ACKResourceMetadata != nil && ko.Status.ACKResourceMetadata.ARN != nil &&
 		*ko.Status.ACKResourceMetadata.ARN != "" {
-		// Set event data store tags
-		ko.Spec.Tags, err = rm.getTags(ctx, string(*ko.Status.ACKResourceMetadata.ARN))
-		if err != nil {
-			return err
+		arn := string(*ko.Status.ACKResourceMetadata.ARN)
+		if tags, ok := tagsCache.Get(arn, ko.Generation); ok {
+			ko.Spec.Tags = tags
+		} else {
+			ko.Spec.Tags, err = rm.tagsManager().Get(ctx, arn)
+			if err != nil {
+				return err
+			}
+			tagsCache.Set(arn, ko.Generation, ko.Spec.Tags)
 		}
 
-		ko.Spec.Targets, err = rm.getTargets(ctx, *ko.Spec.Name, *ko.Spec.EventBusName)
-		if err != nil {
-			return err
+		if targets, ok := targetsCache.Get(arn, ko.Generation); ok {
+			ko.Spec.Targets = targets
+		} else {
+			ko.Spec.Targets, err = rm.getTargets(ctx, *ko.Spec.Name, ko.Spec.EventBusName)
+			if err != nil {
+				return err
+			}
+			targetsCache.Set(arn, ko.Generation, ko.Spec.Targets)
 		}
 	}
 
@@ -122,19 +137,30 @@ func customPreCompare(
 	desired *resource,
 	latest *resource,
 ) {
-	if len(desired.ko.Spec.Tags) != len(latest.ko.Spec.Tags) {
-		delta.Add("Spec.Tags", desired.ko.Spec.Tags, latest.ko.Spec.Tags)
-	}
+	pkgtags.Compare(delta, desired.ko.Spec.Tags, latest.ko.Spec.Tags)
 
-	if !pkgtags.EqualTags(desired.ko.Spec.Tags, latest.ko.Spec.Tags) {
-		delta.Add("Spec.Tags", desired.ko.Spec.Tags, latest.ko.Spec.Tags)
+	// sdkFind sets the account and Region of the controller as the owner of
+	// the latest rule
+	var accountID, region string
+	if md := latest.ko.Status.ACKResourceMetadata; md != nil {
+		if md.OwnerAccountID != nil {
+			accountID = string(*md.OwnerAccountID)
+		}
+		if md.Region != nil {
+			region = string(*md.Region)
+		}
 	}
 
 	if len(desired.ko.Spec.Targets) != len(latest.ko.Spec.Targets) {
 		delta.Add("Spec.Targets", desired.ko.Spec.Targets, latest.ko.Spec.Targets)
 	}
 
-	if !equalTargets(desired.ko.Spec.Targets, latest.ko.Spec.Targets) {
+	// sdkFind sets the default target role of the namespace of the latest
+	// rule
+	if !equalTargets(
+		desired.ko.Spec.Targets, latest.ko.Spec.Targets,
+		desired.ko.Namespace, accountID, region, aws.ToString(latest.ko.Status.TargetRoleARN),
+	) {
 		delta.Add("Spec.Targets", desired.ko.Spec.Targets, latest.ko.Spec.Targets)
 	}
 
@@ -148,7 +174,7 @@ func customPreCompare(
 
 	desiredBusName := desired.ko.Spec.EventBusName
 	latestBusName := latest.ko.Spec.EventBusName
-	if !equalEventBusName(desiredBusName, latestBusName) {
+	if !equalEventBusName(desiredBusName, latestBusName, accountID, region) {
 		delta.Add("Spec.EventBusName", desiredBusName, latestBusName)
 	}
 }
@@ -173,23 +199,46 @@ func equalScheduleExpression(desiredExpression, latestExpression *string) bool {
 // equalEventBusName is a helper function comparing the provided event bus
 // names. A "default" and nil value is treated as equal.
 // @embano1: fixes #aws-controllers-k8s/community/issues/1989
-func equalEventBusName(desiredBus, latestBus *string) bool {
-	isDefaultBus := func(name *string) bool {
-		return name == nil || *name == "default" || *name == ""
+//
+// An event bus given by ARN is equal to the event bus with the same name in
+// the account and Region of the controller, since DescribeRule returns the
+// name of the event bus. Two ARNs must be identical, as they may refer to
+// event buses of different accounts.
+func equalEventBusName(desiredBus, latestBus *string, accountID, region string) bool {
+	desired, latest := aws.ToString(desiredBus), aws.ToString(latestBus)
+	desiredARN, desiredErr := arns.Parse(desired)
+	latestARN, latestErr := arns.Parse(latest)
+	switch {
+	case desiredErr == nil && latestErr == nil:
+		return desiredARN == latestARN
+	case desiredErr == nil && !isLocalEventBus(desiredARN, accountID, region):
+		return false
+	case latestErr == nil && !isLocalEventBus(latestARN, accountID, region):
+		return false
 	}
+	return arns.EventBusName(desired) == arns.EventBusName(latest)
+}
 
-	if isDefaultBus(desiredBus) && isDefaultBus(latestBus) {
-		return true
-	}
+// isLocalEventBus returns true if the event bus ARN is in the account and
+// Region
+func isLocalEventBus(a arns.ARN, accountID, region string) bool {
+	return a.AccountID == accountID && a.Region == region
+}
 
-	if ackcompare.HasNilDifference(desiredBus, latestBus) {
-		return false
-	} else if desiredBus != nil && latestBus != nil {
-		if *desiredBus != *latestBus {
-			return false
-		}
+// eventBusIdentifier returns the event bus of a rule as sent to the targets
+// APIs: nil for the default event bus of the controller's account, the name
+// for the other event buses of the account, and the ARN for the event buses
+// of other accounts and Regions.
+func (rm *resourceManager) eventBusIdentifier(nameOrARN *string) *string {
+	name := arns.EventBusName(aws.ToString(nameOrARN))
+	if a, err := arns.Parse(aws.ToString(nameOrARN)); err == nil &&
+		!isLocalEventBus(a, string(rm.awsAccountID), string(rm.awsRegion)) {
+		return nameOrARN
 	}
-	return true
+	if name == arns.DefaultEventBus {
+		return nil
+	}
+	return aws.String(name)
 }
 
 // unsetScheduleExpression is a helper function to unset the ScheduleExpression
@@ -200,3 +249,15 @@ func unsetScheduleExpression(spec v1alpha1.RuleSpec, input *svcsdk.PutRuleInput)
 		input.ScheduleExpression = nil
 	}
 }
+
+// Validate runs the validations the controller applies to a Rule before
+// creating or updating it, without calling AWS
+func Validate(ko *svcapitypes.Rule) error {
+	if err := validateReferenceFields(ko); err != nil {
+		return err
+	}
+	if err := validateRuleSpec(ko.Spec); err != nil {
+		return err
+	}
+	return validateTargets(ko.Spec.Targets)
+}
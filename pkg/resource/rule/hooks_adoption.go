@@ -0,0 +1,71 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package rule
+
+import (
+	"fmt"
+	"strings"
+
+	ackerrors "github.com/aws-controllers-k8s/runtime/pkg/errors"
+	"github.com/aws/aws-sdk-go-v2/aws"
+
+	svcapitypes "github.com/aws-controllers-k8s/eventbridge-controller/apis/v1alpha1"
+	"github.com/aws-controllers-k8s/eventbridge-controller/pkg/arns"
+)
+
+// isARN returns true if the identifier of an adopted rule is an ARN
+func isARN(nameOrARN string) bool {
+	return strings.HasPrefix(nameOrARN, "arn:")
+}
+
+// identifiersFromARN returns the rule to read, which is a copy of the rule
+// with the name and event bus of the ARN for rules adopted by ARN. Adoption
+// sets the ARN, passed as the name of the AdoptedResource or the name field
+// of the adoption annotation, as the name of the rule. The event bus is set by
+// ARN, so that rules on the event buses of other accounts are read from the
+// account of the event bus.
+func identifiersFromARN(r *resource) (*resource, error) {
+	ruleARN := aws.ToString(r.ko.Spec.Name)
+	if !isARN(ruleARN) {
+		return r, nil
+	}
+	a, err := arns.Parse(ruleARN)
+	if err != nil || a.Kind != arns.KindRule {
+		return nil, ackerrors.NewTerminalError(fmt.Errorf("invalid rule ARN %q", ruleARN))
+	}
+	bus := arns.ARN{
+		Partition: a.Partition,
+		Region:    a.Region,
+		AccountID: a.AccountID,
+		Kind:      arns.KindEventBus,
+		Name:      a.EventBus,
+	}
+	ko := r.ko.DeepCopy()
+	ko.Spec.Name = aws.String(a.Name)
+	ko.Spec.EventBusName = aws.String(bus.String())
+	return &resource{ko: ko}, nil
+}
+
+// keepEventBusName keeps the event bus of the desired rule when it refers to
+// the event bus read by DescribeRule by ARN. DescribeRule returns the name of
+// the event bus, which would otherwise replace the ARN of adopted rules. The
+// rule is read from the event bus of the desired rule, so the names suffice.
+func keepEventBusName(desired, latest *svcapitypes.Rule) {
+	if desired.Spec.EventBusName == nil || latest.Spec.EventBusName == nil {
+		return
+	}
+	if arns.EventBusName(*desired.Spec.EventBusName) == arns.EventBusName(*latest.Spec.EventBusName) {
+		latest.Spec.EventBusName = desired.Spec.EventBusName
+	}
+}
@@ -0,0 +1,100 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package rule
+
+import (
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"gotest.tools/v3/assert"
+
+	svcapitypes "github.com/aws-controllers-k8s/eventbridge-controller/apis/v1alpha1"
+)
+
+func Test_identifiersFromARN(t *testing.T) {
+	tests := []struct {
+		name         string
+		spec         svcapitypes.RuleSpec
+		wantName     string
+		wantEventBus *string
+		wantErr      string
+	}{
+		{
+			name:         "name",
+			spec:         svcapitypes.RuleSpec{Name: aws.String("order-created"), EventBusName: aws.String("orders")},
+			wantName:     "order-created",
+			wantEventBus: aws.String("orders"),
+		},
+		{
+			name:         "ARN of a rule on the default event bus",
+			spec:         svcapitypes.RuleSpec{Name: aws.String("arn:aws:events:us-west-2:111122223333:rule/order-created")},
+			wantName:     "order-created",
+			wantEventBus: aws.String("arn:aws:events:us-west-2:111122223333:event-bus/default"),
+		},
+		{
+			name:         "ARN of a rule on a custom event bus",
+			spec:         svcapitypes.RuleSpec{Name: aws.String("arn:aws:events:us-west-2:444455556666:rule/orders/order-created")},
+			wantName:     "order-created",
+			wantEventBus: aws.String("arn:aws:events:us-west-2:444455556666:event-bus/orders"),
+		},
+		{
+			name:    "ARN of an event bus",
+			spec:    svcapitypes.RuleSpec{Name: aws.String("arn:aws:events:us-west-2:111122223333:event-bus/orders")},
+			wantErr: `invalid rule ARN "arn:aws:events:us-west-2:111122223333:event-bus/orders"`,
+		},
+		{
+			name:    "ARN of another service",
+			spec:    svcapitypes.RuleSpec{Name: aws.String("arn:aws:sqs:us-west-2:444455556666:orders")},
+			wantErr: "invalid rule ARN",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := &resource{ko: &svcapitypes.Rule{Spec: tt.spec}}
+			got, err := identifiersFromARN(r)
+			if tt.wantErr != "" {
+				assert.ErrorContains(t, err, tt.wantErr)
+				return
+			}
+			assert.NilError(t, err)
+			assert.Equal(t, aws.ToString(got.ko.Spec.Name), tt.wantName)
+			assert.DeepEqual(t, got.ko.Spec.EventBusName, tt.wantEventBus)
+			// the rule passed in is left as it is
+			assert.DeepEqual(t, r.ko.Spec, tt.spec)
+		})
+	}
+}
+
+func Test_keepEventBusName(t *testing.T) {
+	const busARN = "arn:aws:events:us-west-2:444455556666:event-bus/orders"
+	tests := []struct {
+		name    string
+		desired *string
+		latest  *string
+		want    *string
+	}{
+		{name: "same name", desired: aws.String("orders"), latest: aws.String("orders"), want: aws.String("orders")},
+		{name: "ARN of the event bus", desired: aws.String(busARN), latest: aws.String("orders"), want: aws.String(busARN)},
+		{name: "ARN of another event bus", desired: aws.String(busARN), latest: aws.String("payments"), want: aws.String("payments")},
+		{name: "desired unset", latest: aws.String("default"), want: aws.String("default")},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			desired := &svcapitypes.Rule{Spec: svcapitypes.RuleSpec{EventBusName: tt.desired}}
+			latest := &svcapitypes.Rule{Spec: svcapitypes.RuleSpec{EventBusName: tt.latest}}
+			keepEventBusName(desired, latest)
+			assert.DeepEqual(t, latest.Spec.EventBusName, tt.want)
+		})
+	}
+}
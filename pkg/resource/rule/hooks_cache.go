@@ -0,0 +1,49 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package rule
+
+import (
+	svcapitypes "github.com/aws-controllers-k8s/eventbridge-controller/apis/v1alpha1"
+	"github.com/aws-controllers-k8s/eventbridge-controller/pkg/cache"
+	pkgtags "github.com/aws-controllers-k8s/eventbridge-controller/pkg/tags"
+)
+
+// The tags and targets read by sdkFind, which would otherwise be listed on
+// every reconciliation
+var (
+	tagsCache    = cache.New("rule_tags", pkgtags.Copy)
+	targetsCache = cache.New("rule_targets", copyTargets)
+)
+
+func copyTargets(targets []*svcapitypes.Target) []*svcapitypes.Target {
+	if targets == nil {
+		return nil
+	}
+	out := make([]*svcapitypes.Target, len(targets))
+	for i, t := range targets {
+		out[i] = t.DeepCopy()
+	}
+	return out
+}
+
+// invalidateReads drops the cached tags and targets of the rule, after they
+// were written
+func invalidateReads(r *resource) {
+	if r == nil || r.ko.Status.ACKResourceMetadata == nil || r.ko.Status.ACKResourceMetadata.ARN == nil {
+		return
+	}
+	arn := string(*r.ko.Status.ACKResourceMetadata.ARN)
+	tagsCache.Invalidate(arn)
+	targetsCache.Invalidate(arn)
+}
@@ -0,0 +1,103 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package rule
+
+import (
+	ackv1alpha1 "github.com/aws-controllers-k8s/runtime/apis/core/v1alpha1"
+	ackcondition "github.com/aws-controllers-k8s/runtime/pkg/condition"
+	"github.com/aws/aws-sdk-go-v2/aws"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	// ConditionTypeAttributesSynced indicates whether the rule attributes
+	// (event pattern, schedule expression, state, role and description) applied
+	// with PutRule match the desired state.
+	ConditionTypeAttributesSynced ackv1alpha1.ConditionType = "AttributesSynced"
+	// ConditionTypeTargetsSynced indicates whether the rule targets match the
+	// desired state.
+	ConditionTypeTargetsSynced ackv1alpha1.ConditionType = "TargetsSynced"
+	// ConditionTypeTagsSynced indicates whether the rule tags match the desired
+	// state.
+	ConditionTypeTagsSynced ackv1alpha1.ConditionType = "TagsSynced"
+)
+
+const (
+	syncedReason     = "Synced"
+	syncFailedReason = "SyncFailed"
+)
+
+// syncConditionTypes are the sub-resource conditions reported on a Rule in
+// addition to the ACK.ResourceSynced and Ready conditions set by the runtime
+var syncConditionTypes = []ackv1alpha1.ConditionType{
+	ConditionTypeAttributesSynced,
+	ConditionTypeTargetsSynced,
+	ConditionTypeTagsSynced,
+}
+
+// setSyncCondition sets the sub-resource condition of the supplied type. A nil
+// error marks the condition as True, otherwise the condition is set to False
+// with the error as message.
+func setSyncCondition(
+	r *resource,
+	condType ackv1alpha1.ConditionType,
+	err error,
+) {
+	status := corev1.ConditionTrue
+	reason := syncedReason
+	var message *string
+	if err != nil {
+		status = corev1.ConditionFalse
+		reason = syncFailedReason
+		message = aws.String(err.Error())
+	}
+	setCondition(r, condType, status, reason, message)
+}
+
+// setCondition sets the condition of the supplied type, adding it to the
+// resource conditions if it is not present yet. The transition time is only
+// updated when the status changes.
+func setCondition(
+	r *resource,
+	condType ackv1alpha1.ConditionType,
+	status corev1.ConditionStatus,
+	reason string,
+	message *string,
+) {
+	allConds := r.Conditions()
+	c := ackcondition.FirstOfType(r, condType)
+	if c == nil {
+		c = &ackv1alpha1.Condition{Type: condType}
+		allConds = append(allConds, c)
+	}
+	if c.Status != status || c.LastTransitionTime == nil {
+		now := metav1.Now()
+		c.LastTransitionTime = &now
+	}
+	c.Status = status
+	c.Reason = &reason
+	c.Message = message
+	r.ReplaceConditions(allConds)
+}
+
+// ensureSyncConditions marks all sub-resource conditions which have not been
+// set during the current reconciliation as synced
+func ensureSyncConditions(r *resource) {
+	for _, condType := range syncConditionTypes {
+		if ackcondition.FirstOfType(r, condType) == nil {
+			setSyncCondition(r, condType, nil)
+		}
+	}
+}
@@ -0,0 +1,84 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package rule
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	ackv1alpha1 "github.com/aws-controllers-k8s/runtime/apis/core/v1alpha1"
+	ackcondition "github.com/aws-controllers-k8s/runtime/pkg/condition"
+	"gotest.tools/v3/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	svcapitypes "github.com/aws-controllers-k8s/eventbridge-controller/apis/v1alpha1"
+)
+
+func Test_setSyncCondition(t *testing.T) {
+	r := &resource{ko: &svcapitypes.Rule{}}
+
+	setSyncCondition(r, ConditionTypeTargetsSynced, errors.New("target failed"))
+	c := ackcondition.FirstOfType(r, ConditionTypeTargetsSynced)
+	assert.Assert(t, c != nil)
+	assert.Equal(t, c.Status, corev1.ConditionFalse)
+	assert.Equal(t, *c.Reason, syncFailedReason)
+	assert.Equal(t, *c.Message, "target failed")
+
+	// setting the condition again must update and not duplicate it
+	setSyncCondition(r, ConditionTypeTargetsSynced, nil)
+	assert.Equal(t, len(r.Conditions()), 1)
+	c = ackcondition.FirstOfType(r, ConditionTypeTargetsSynced)
+	assert.Equal(t, c.Status, corev1.ConditionTrue)
+	assert.Equal(t, *c.Reason, syncedReason)
+	assert.Assert(t, c.Message == nil)
+}
+
+func Test_setSyncCondition_lastTransitionTime(t *testing.T) {
+	r := &resource{ko: &svcapitypes.Rule{}}
+	setSyncCondition(r, ConditionTypeTargetsSynced, nil)
+	c := ackcondition.FirstOfType(r, ConditionTypeTargetsSynced)
+	assert.Assert(t, c.LastTransitionTime != nil)
+	past := metav1.NewTime(c.LastTransitionTime.Add(-time.Hour))
+	c.LastTransitionTime = &past
+
+	// the transition time is kept while the status doesn't change
+	setSyncCondition(r, ConditionTypeTargetsSynced, nil)
+	c = ackcondition.FirstOfType(r, ConditionTypeTargetsSynced)
+	assert.Equal(t, c.LastTransitionTime.Time, past.Time)
+
+	setSyncCondition(r, ConditionTypeTargetsSynced, errors.New("target failed"))
+	c = ackcondition.FirstOfType(r, ConditionTypeTargetsSynced)
+	assert.Assert(t, c.LastTransitionTime.After(past.Time))
+}
+
+func Test_ensureSyncConditions(t *testing.T) {
+	r := &resource{ko: &svcapitypes.Rule{}}
+	setSyncCondition(r, ConditionTypeTagsSynced, errors.New("tagging failed"))
+
+	ensureSyncConditions(r)
+	assert.Equal(t, len(r.Conditions()), len(syncConditionTypes))
+
+	want := map[ackv1alpha1.ConditionType]corev1.ConditionStatus{
+		ConditionTypeAttributesSynced: corev1.ConditionTrue,
+		ConditionTypeTargetsSynced:    corev1.ConditionTrue,
+		ConditionTypeTagsSynced:       corev1.ConditionFalse,
+	}
+	for condType, status := range want {
+		c := ackcondition.FirstOfType(r, condType)
+		assert.Assert(t, c != nil, condType)
+		assert.Equal(t, c.Status, status, condType)
+	}
+}
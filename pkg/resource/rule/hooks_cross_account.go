@@ -0,0 +1,138 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package rule
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	ackerr "github.com/aws-controllers-k8s/runtime/pkg/errors"
+	"github.com/aws/aws-sdk-go-v2/aws"
+	corev1 "k8s.io/api/core/v1"
+	rtclient "sigs.k8s.io/controller-runtime/pkg/client"
+
+	svcapitypes "github.com/aws-controllers-k8s/eventbridge-controller/apis/v1alpha1"
+	"github.com/aws-controllers-k8s/eventbridge-controller/pkg/arns"
+	"github.com/aws-controllers-k8s/eventbridge-controller/pkg/clients"
+)
+
+// +kubebuilder:rbac:groups="",resources=namespaces,verbs=get
+
+const (
+	// AnnotationTargetRoleARN is the Namespace annotation with the default
+	// role of the Rule targets which send events to an event bus in another
+	// account or Region. EventBridge requires a role for these targets.
+	AnnotationTargetRoleARN = "eventbridge.services.k8s.aws/cross-account-target-role-arn"
+
+	// targetRoleMissingReason is the reason of the TargetsSynced condition
+	// set when a cross-account target has no role
+	targetRoleMissingReason = "TargetRoleMissing"
+)
+
+// namespaceTargetRole returns the default target role of a Namespace, or ""
+func namespaceTargetRole(ctx context.Context, name string) (string, error) {
+	c, err := clients.FromContext(ctx)
+	if err != nil {
+		return "", err
+	}
+	ns := &corev1.Namespace{}
+	if err := c.APIReader.Get(ctx, rtclient.ObjectKey{Name: name}, ns); err != nil {
+		return "", err
+	}
+	return ns.Annotations[AnnotationTargetRoleARN], nil
+}
+
+// targetRoleMissingError is returned when targets which send events to an
+// event bus in another account or Region have no role
+type targetRoleMissingError struct {
+	ids []string
+}
+
+func (e targetRoleMissingError) Error() string {
+	return fmt.Sprintf(
+		"invalid Spec: \"spec.targets\": targets %s send events to an event bus in another account or Region "+
+			"and require a roleARN, set it or annotate the namespace with %s",
+		strings.Join(e.ids, ", "), AnnotationTargetRoleARN,
+	)
+}
+
+// isCrossAccountEventBus returns true if the target ARN is an event bus in
+// another account or Region than the given ones
+func isCrossAccountEventBus(targetARN, accountID, region string) bool {
+	a, err := arns.Parse(targetARN)
+	if err != nil || a.Kind != arns.KindEventBus {
+		return false
+	}
+	return !isLocalEventBus(a, accountID, region)
+}
+
+// crossAccountTargetsWithoutRole returns the IDs of the targets which send
+// events to an event bus in another account or Region than the controller's
+// and omit their role
+func (rm *resourceManager) crossAccountTargetsWithoutRole(targets []*svcapitypes.Target) []string {
+	var ids []string
+	for _, t := range targets {
+		if t != nil && aws.ToString(t.RoleARN) == "" &&
+			isCrossAccountEventBus(aws.ToString(t.ARN), string(rm.awsAccountID), string(rm.awsRegion)) {
+			ids = append(ids, aws.ToString(t.ID))
+		}
+	}
+	return ids
+}
+
+// resolveTargetRole sets the status of the rule to the default target role
+// of its namespace, if any target of the rule requires it, for the target
+// defaults. The role is read from the namespace by every read, create and
+// update, so the role in the status is the one the targets were compared
+// with. The IDs of these targets are returned if the namespace has no
+// default role.
+func (rm *resourceManager) resolveTargetRole(
+	ctx context.Context,
+	r *resource,
+	ko *svcapitypes.Rule,
+) ([]string, error) {
+	ko.Status.TargetRoleARN = nil
+	ids := rm.crossAccountTargetsWithoutRole(r.ko.Spec.Targets)
+	if len(ids) == 0 {
+		return nil, nil
+	}
+	roleARN, err := namespaceTargetRole(ctx, r.ko.Namespace)
+	if err != nil {
+		return nil, fmt.Errorf("reading the default target role of namespace %q: %w", r.ko.Namespace, err)
+	}
+	if roleARN != "" {
+		ko.Status.TargetRoleARN = aws.String(roleARN)
+		return nil, nil
+	}
+	return ids, nil
+}
+
+// checkCrossAccountTargetRoles resolves the default target role of the
+// namespace of the rule. A terminal targetRoleMissingError is returned, and
+// the TargetsSynced condition set to False, if targets which send events to
+// an event bus in another account or Region have no role.
+func (rm *resourceManager) checkCrossAccountTargetRoles(ctx context.Context, r *resource) error {
+	missing, err := rm.resolveTargetRole(ctx, r, r.ko)
+	if err != nil {
+		return err
+	}
+	if len(missing) > 0 {
+		err := targetRoleMissingError{ids: missing}
+		setCondition(r, ConditionTypeTargetsSynced, corev1.ConditionFalse,
+			targetRoleMissingReason, aws.String(err.Error()))
+		return ackerr.NewTerminalError(err)
+	}
+	return nil
+}
@@ -0,0 +1,194 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package rule
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	ackcondition "github.com/aws-controllers-k8s/runtime/pkg/condition"
+	ackerr "github.com/aws-controllers-k8s/runtime/pkg/errors"
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"gotest.tools/v3/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	rtclient "sigs.k8s.io/controller-runtime/pkg/client"
+	ctrlrtfake "sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	svcapitypes "github.com/aws-controllers-k8s/eventbridge-controller/apis/v1alpha1"
+	"github.com/aws-controllers-k8s/eventbridge-controller/pkg/clients"
+	"github.com/aws-controllers-k8s/eventbridge-controller/pkg/testutil"
+)
+
+const (
+	spokeBusARN     = "arn:aws:events:us-west-2:444455556666:event-bus/orders"
+	spokeRoleARN    = "arn:aws:iam::123456789012:role/spoke-events"
+	explicitRoleARN = "arn:aws:iam::123456789012:role/explicit"
+)
+
+// newNamespaceContext returns a context with a client which reads the
+// namespaces with the given annotations
+func newNamespaceContext(annotations map[string]map[string]string) context.Context {
+	var objs []rtclient.Object
+	for name, a := range annotations {
+		objs = append(objs, &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: name, Annotations: a}})
+	}
+	kc := ctrlrtfake.NewClientBuilder().WithObjects(objs...).Build()
+	return clients.NewContext(context.Background(), clients.New(kc, kc))
+}
+
+func Test_resourceManager_checkCrossAccountTargetRoles(t *testing.T) {
+	rm := &resourceManager{awsAccountID: testutil.DefaultAccountID, awsRegion: testutil.DefaultRegion}
+	ctx := newNamespaceContext(map[string]map[string]string{
+		"spoke": {AnnotationTargetRoleARN: spokeRoleARN},
+		"other": nil,
+	})
+	tests := []struct {
+		name         string
+		namespace    string
+		target       svcapitypes.Target
+		wantRoleARN  string
+		wantTerminal bool
+		wantErr      string
+	}{
+		{
+			name:      "queue target",
+			namespace: "other",
+			target:    svcapitypes.Target{ID: aws.String("t1"), ARN: aws.String("arn:aws:sqs:us-west-2:444455556666:queue")},
+		},
+		{
+			name:      "event bus in the account",
+			namespace: "other",
+			target:    svcapitypes.Target{ID: aws.String("t1"), ARN: aws.String("arn:aws:events:us-west-2:123456789012:event-bus/orders")},
+		},
+		{
+			name:        "event bus in another account with a role",
+			namespace:   "spoke",
+			target:      svcapitypes.Target{ID: aws.String("t1"), ARN: aws.String(spokeBusARN), RoleARN: aws.String(explicitRoleARN)},
+			wantRoleARN: explicitRoleARN,
+		},
+		{
+			name:        "event bus in another account defaulted from the namespace",
+			namespace:   "spoke",
+			target:      svcapitypes.Target{ID: aws.String("t1"), ARN: aws.String(spokeBusARN)},
+			wantRoleARN: spokeRoleARN,
+		},
+		{
+			name:        "event bus in another region defaulted from the namespace",
+			namespace:   "spoke",
+			target:      svcapitypes.Target{ID: aws.String("t1"), ARN: aws.String("arn:aws:events:eu-west-1:123456789012:event-bus/orders")},
+			wantRoleARN: spokeRoleARN,
+		},
+		{
+			name:         "event bus in another account without a default",
+			namespace:    "other",
+			target:       svcapitypes.Target{ID: aws.String("t1"), ARN: aws.String(spokeBusARN)},
+			wantTerminal: true,
+			wantErr:      "targets t1 send events to an event bus in another account or Region and require a roleARN",
+		},
+		{
+			name:      "namespace lookup fails",
+			namespace: "missing",
+			target:    svcapitypes.Target{ID: aws.String("t1"), ARN: aws.String(spokeBusARN)},
+			wantErr:   `reading the default target role of namespace "missing": namespaces "missing" not found`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := &resource{ko: &svcapitypes.Rule{
+				ObjectMeta: metav1.ObjectMeta{Namespace: tt.namespace},
+				Spec:       svcapitypes.RuleSpec{Targets: []*svcapitypes.Target{tt.target.DeepCopy()}},
+			}}
+
+			err := rm.checkCrossAccountTargetRoles(ctx, r)
+			if tt.wantErr != "" {
+				assert.ErrorContains(t, err, tt.wantErr)
+				var terminal *ackerr.TerminalError
+				assert.Equal(t, errors.As(err, &terminal), tt.wantTerminal)
+				if tt.wantTerminal {
+					c := ackcondition.FirstOfType(r, ConditionTypeTargetsSynced)
+					assert.Assert(t, c != nil)
+					assert.Equal(t, c.Status, corev1.ConditionFalse)
+					assert.Equal(t, *c.Reason, targetRoleMissingReason)
+				}
+				return
+			}
+			assert.NilError(t, err)
+			assert.Assert(t, ackcondition.FirstOfType(r, ConditionTypeTargetsSynced) == nil)
+			// the role is defaulted with the target defaults, not in the spec
+			assert.DeepEqual(t, r.ko.Spec.Targets[0], &tt.target)
+			got := targetDefaults.apply(
+				r.ko.Spec.Targets, tt.namespace, string(rm.awsAccountID), string(rm.awsRegion),
+				aws.ToString(r.ko.Status.TargetRoleARN),
+			)
+			assert.Equal(t, aws.ToString(got[0].RoleARN), tt.wantRoleARN)
+		})
+	}
+}
+
+func Test_resourceManager_crossAccountTargets(t *testing.T) {
+	ctx := newNamespaceContext(map[string]map[string]string{
+		"spoke": {AnnotationTargetRoleARN: spokeRoleARN},
+		"other": nil,
+	})
+	fake := testutil.NewEventBridge()
+	rm := newTestResourceManager(t, fake)
+
+	newRule := func(namespace string) *resource {
+		return &resource{ko: &svcapitypes.Rule{
+			ObjectMeta: metav1.ObjectMeta{Namespace: namespace},
+			Spec: svcapitypes.RuleSpec{
+				Name:         aws.String(ruleName),
+				EventPattern: aws.String(`{"source":["test"]}`),
+				Targets: []*svcapitypes.Target{
+					{ID: aws.String("spoke"), ARN: aws.String(spokeBusARN)},
+				},
+			},
+		}}
+	}
+
+	t.Run("rule is not created without a role", func(t *testing.T) {
+		_, err := rm.sdkCreate(ctx, newRule("other"))
+		var terminal *ackerr.TerminalError
+		assert.Assert(t, errors.As(err, &terminal), err)
+		assert.Equal(t, len(fake.Calls()), 0)
+	})
+
+	desired := newRule("spoke")
+	created, err := rm.sdkCreate(ctx, desired)
+	assert.NilError(t, err)
+	// the role isn't written to the spec
+	assert.DeepEqual(t, desired.ko.Spec, newRule("spoke").ko.Spec)
+	assert.Assert(t, created.ko.Spec.Targets[0].RoleARN == nil)
+
+	// the desired rule is read as stored in the cluster, without the role
+	latest, err := rm.sdkFind(ctx, newRule("spoke"))
+	assert.NilError(t, err)
+	assert.Equal(t, aws.ToString(latest.ko.Spec.Targets[0].RoleARN), spokeRoleARN)
+	assert.Equal(t, aws.ToString(latest.ko.Status.TargetRoleARN), spokeRoleARN)
+	assert.Assert(t, !newResourceDelta(newRule("spoke"), latest).DifferentAt("Spec.Targets"))
+
+	// a changed default role of the namespace is put again
+	c, err := clients.FromContext(ctx)
+	assert.NilError(t, err)
+	ns := &corev1.Namespace{}
+	assert.NilError(t, c.Client.Get(ctx, rtclient.ObjectKey{Name: "spoke"}, ns))
+	ns.Annotations[AnnotationTargetRoleARN] = explicitRoleARN
+	assert.NilError(t, c.Client.Update(ctx, ns))
+	latest, err = rm.sdkFind(ctx, newRule("spoke"))
+	assert.NilError(t, err)
+	assert.Equal(t, aws.ToString(latest.ko.Status.TargetRoleARN), explicitRoleARN)
+	assert.Assert(t, newResourceDelta(newRule("spoke"), latest).DifferentAt("Spec.Targets"))
+}
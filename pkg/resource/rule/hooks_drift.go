@@ -0,0 +1,136 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package rule
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	ackv1alpha1 "github.com/aws-controllers-k8s/runtime/apis/core/v1alpha1"
+	ackcompare "github.com/aws-controllers-k8s/runtime/pkg/compare"
+	ackcondition "github.com/aws-controllers-k8s/runtime/pkg/condition"
+	"github.com/aws/aws-sdk-go-v2/aws"
+	corev1 "k8s.io/api/core/v1"
+
+	"github.com/aws-controllers-k8s/eventbridge-controller/pkg/events"
+)
+
+// +kubebuilder:rbac:groups="",resources=events,verbs=create;patch
+
+const (
+	// AnnotationDriftMode selects how the controller handles differences
+	// between the desired and the observed state of a Rule. Set it to
+	// DriftModeReport to only report the differences in Status.Drift and a
+	// Kubernetes Event, without calling any mutating EventBridge API. Rules
+	// aren't created, updated or deleted in this mode.
+	//
+	// The read-only annotation of the ACK runtime also leaves the rule
+	// untouched, but the runtime then skips the update without passing the
+	// delta to the resource manager, so the drift can't be reported.
+	AnnotationDriftMode = "eventbridge.services.k8s.aws/drift-mode"
+	// DriftModeReport is the AnnotationDriftMode value for observe-only
+	// reconciliation
+	DriftModeReport = "report"
+
+	// driftDetectedReason is the reason of the Event and conditions set when
+	// drift is reported instead of reconciled
+	driftDetectedReason = "DriftDetected"
+)
+
+var errDriftReportCreate = errors.New(
+	"rule does not exist and is not created in drift report mode",
+)
+
+// driftReportEnabled returns true if the Rule is reconciled in observe-only
+// drift report mode
+func driftReportEnabled(r *resource) bool {
+	return r.ko.GetAnnotations()[AnnotationDriftMode] == DriftModeReport
+}
+
+// reportDrift records the differences in delta in the status of desired and
+// as a Kubernetes Event. The differences are not reconciled, the sub-resource
+// condition of every drifted path and ACK.ResourceSynced are set to False.
+func reportDrift(desired *resource, delta *ackcompare.Delta) *resource {
+	desired.ko.Status.Drift = driftFromDelta(delta)
+
+	for _, condType := range syncConditionTypes {
+		if driftedCondition(delta, condType) {
+			setCondition(
+				desired, condType, corev1.ConditionFalse,
+				driftDetectedReason, aws.String("drift not reconciled in drift report mode"),
+			)
+		}
+	}
+	ensureSyncConditions(desired)
+
+	message := fmt.Sprintf("drift detected, not reconciled in drift report mode: %s",
+		strings.Join(aws.ToStringSlice(desired.ko.Status.Drift), "; "))
+	ackcondition.SetSynced(desired, corev1.ConditionFalse, &message, aws.String(driftDetectedReason))
+	events.Recorder().Event(desired.ko, corev1.EventTypeWarning, driftDetectedReason, message)
+
+	return desired
+}
+
+// driftedCondition returns true if delta contains a difference reported by the
+// sub-resource condition of the given type
+func driftedCondition(delta *ackcompare.Delta, condType ackv1alpha1.ConditionType) bool {
+	switch condType {
+	case ConditionTypeTagsSynced:
+		return delta.DifferentAt("Spec.Tags")
+	case ConditionTypeTargetsSynced:
+		return delta.DifferentAt("Spec.Targets")
+	default:
+		return delta.DifferentExcept("Spec.Tags", "Spec.Targets")
+	}
+}
+
+// driftFromDelta returns one entry per differing path in delta, formatted as
+// "<path>: desired=<value>, actual=<value>"
+func driftFromDelta(delta *ackcompare.Delta) []*string {
+	var drift []*string
+	seen := make(map[string]bool)
+	for _, d := range delta.Differences {
+		path := pathString(d.Path)
+		if seen[path] {
+			continue
+		}
+		seen[path] = true
+		drift = append(drift, aws.String(fmt.Sprintf(
+			"%s: desired=%s, actual=%s", path, driftValue(d.A), driftValue(d.B),
+		)))
+	}
+	return drift
+}
+
+// pathString returns the dotted form of p. ackcompare.Path doesn't export
+// its parts but marshals them to JSON.
+func pathString(p ackcompare.Path) string {
+	var parts struct {
+		Parts []string
+	}
+	b, _ := p.MarshalJSON()
+	_ = json.Unmarshal(b, &parts)
+	return strings.Join(parts.Parts, ".")
+}
+
+// driftValue returns the JSON encoding of a compared value
+func driftValue(v interface{}) string {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Sprintf("%v", v)
+	}
+	return string(b)
+}
@@ -0,0 +1,83 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package rule
+
+import (
+	"context"
+	"errors"
+
+	ackcondition "github.com/aws-controllers-k8s/runtime/pkg/condition"
+	ackerr "github.com/aws-controllers-k8s/runtime/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+
+	"github.com/aws-controllers-k8s/eventbridge-controller/pkg/lifecycle"
+	"github.com/aws-controllers-k8s/eventbridge-controller/pkg/metrics"
+)
+
+// Operations of the resource manager which publish lifecycle events, aliased
+// so that the generated code doesn't need to import the lifecycle package
+const (
+	lifecycleCreate = lifecycle.OperationCreate
+	lifecycleUpdate = lifecycle.OperationUpdate
+	lifecycleDelete = lifecycle.OperationDelete
+)
+
+// publishLifecycleEvent publishes the outcome of creating, updating or
+// deleting the rule. r is the resource returned by the operation, or the
+// desired resource when it returned none. Errors which aren't terminal and
+// updates which didn't sync the rule, e.g. because drift is only reported,
+// have no outcome. The generation of a created or updated rule is recorded in
+// Status.ObservedGeneration, to tell later updates of the same generation
+// apart as drift corrections.
+func (rm *resourceManager) publishLifecycleEvent(
+	ctx context.Context,
+	op lifecycle.Operation,
+	desired *resource,
+	r *resource,
+	err error,
+) {
+	if r == nil {
+		r = desired
+	}
+	if r == nil || r.ko == nil {
+		return
+	}
+	var terminalErr *ackerr.TerminalError
+	if err != nil && !rm.terminalAWSError(err) && !errors.As(err, &terminalErr) {
+		return
+	}
+	if err == nil && op == lifecycleUpdate {
+		if synced := ackcondition.Synced(r); synced != nil && synced.Status != corev1.ConditionTrue {
+			return
+		}
+	}
+	res := lifecycle.Resource{
+		Kind:       metrics.KindRule,
+		Namespace:  r.ko.Namespace,
+		Name:       r.ko.Name,
+		Generation: r.ko.Generation,
+	}
+	if r.ko.Status.ACKResourceMetadata != nil && r.ko.Status.ACKResourceMetadata.ARN != nil {
+		res.ARN = string(*r.ko.Status.ACKResourceMetadata.ARN)
+	}
+	if r.ko.Status.ObservedGeneration != nil {
+		res.ObservedGeneration = *r.ko.Status.ObservedGeneration
+	}
+	lifecycle.Record(ctx, rm.clientcfg, res, op, err)
+
+	if err == nil && op != lifecycleDelete {
+		generation := r.ko.Generation
+		r.ko.Status.ObservedGeneration = &generation
+	}
+}
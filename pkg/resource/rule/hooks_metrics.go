@@ -0,0 +1,71 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package rule
+
+import (
+	"time"
+
+	ackcondition "github.com/aws-controllers-k8s/runtime/pkg/condition"
+	"github.com/aws/aws-sdk-go-v2/aws"
+	corev1 "k8s.io/api/core/v1"
+
+	svcapitypes "github.com/aws-controllers-k8s/eventbridge-controller/apis/v1alpha1"
+	"github.com/aws-controllers-k8s/eventbridge-controller/pkg/metrics"
+)
+
+// observeRule reports the latest state of the rule in the controller metrics
+// and starts measuring the sync of its generation. Rules which are being
+// deleted are no longer reported.
+func observeRule(ko *svcapitypes.Rule) {
+	if ko.DeletionTimestamp != nil {
+		metrics.Forget(metrics.KindRule, ko.Namespace, ko.Name)
+		return
+	}
+	targetARNs := make([]string, 0, len(ko.Spec.Targets))
+	for _, t := range ko.Spec.Targets {
+		targetARNs = append(targetARNs, aws.ToString(t.ARN))
+	}
+	metrics.ObserveRule(
+		ko.Namespace, ko.Name,
+		aws.ToString(ko.Spec.EventBusName), aws.ToString(ko.Spec.State),
+		targetARNs,
+	)
+	metrics.SyncStarted(metrics.KindRule, ko.Namespace, ko.Name, ko.Generation)
+}
+
+// observeRuleSynced observes the sync duration of the rule, unless it isn't
+// synced, e.g. because drift is only reported. Created rules are measured
+// from their creation.
+func observeRuleSynced(r *resource, created bool) {
+	if r == nil {
+		return
+	}
+	if synced := ackcondition.Synced(r); synced != nil && synced.Status != corev1.ConditionTrue {
+		return
+	}
+	var start time.Time
+	if created {
+		start = r.ko.CreationTimestamp.Time
+	}
+	metrics.Synced(metrics.KindRule, r.ko.Namespace, r.ko.Name, r.ko.Generation, start)
+}
+
+// failedEntryCodes returns the error codes of failed target entries
+func failedEntryCodes[T any](entries []T, code func(T) *string) []string {
+	codes := make([]string, len(entries))
+	for i, e := range entries {
+		codes[i] = aws.ToString(code(e))
+	}
+	return codes
+}
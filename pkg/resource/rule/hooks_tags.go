@@ -16,41 +16,12 @@ package rule
 import (
 	"context"
 
-	"github.com/aws-controllers-k8s/runtime/pkg/runtime/log"
-	svcsdk "github.com/aws/aws-sdk-go-v2/service/eventbridge"
-	svcsdktypes "github.com/aws/aws-sdk-go-v2/service/eventbridge/types"
-
-	svcapitypes "github.com/aws-controllers-k8s/eventbridge-controller/apis/v1alpha1"
 	pkgtags "github.com/aws-controllers-k8s/eventbridge-controller/pkg/tags"
 )
 
-// getTags retrieves a resource list of tags.
-func (rm *resourceManager) getTags(
-	ctx context.Context,
-	resourceARN string,
-) (tags []*svcapitypes.Tag, err error) {
-	rlog := log.FromContext(ctx)
-	exit := rlog.Trace("rm.getTags")
-	defer func() { exit(err) }()
-
-	var listTagsResponse *svcsdk.ListTagsForResourceOutput
-	listTagsResponse, err = rm.sdkapi.ListTagsForResource(
-		ctx,
-		&svcsdk.ListTagsForResourceInput{
-			ResourceARN: &resourceARN,
-		},
-	)
-	rm.metrics.RecordAPICall("GET", "ListTagsForResource", err)
-	if err != nil {
-		return nil, err
-	}
-	for _, tag := range listTagsResponse.Tags {
-		tags = append(tags, &svcapitypes.Tag{
-			Key:   tag.Key,
-			Value: tag.Value,
-		})
-	}
-	return tags, nil
+// tagsManager returns the manager of the tags of rules
+func (rm *resourceManager) tagsManager() *pkgtags.Manager {
+	return pkgtags.NewManager(rm.sdkapi, rm.metrics)
 }
 
 // syncTags synchronizes rule tags
@@ -58,61 +29,9 @@ func (rm *resourceManager) syncTags(
 	ctx context.Context,
 	desired *resource,
 	latest *resource,
-) (err error) {
-	rlog := log.FromContext(ctx)
-	exit := rlog.Trace("rm.syncTags")
-	defer func() { exit(err) }()
-
-	missing, extra := pkgtags.ComputeTagsDelta(desired.ko.Spec.Tags, latest.ko.Spec.Tags)
-
-	arn := (*string)(latest.ko.Status.ACKResourceMetadata.ARN)
-	if len(extra) > 0 {
-		_, err = rm.sdkapi.UntagResource(
-			ctx,
-			&svcsdk.UntagResourceInput{
-				ResourceARN: arn,
-				TagKeys:     sdkTagStringsFromResourceTags(extra),
-			})
-
-		rm.metrics.RecordAPICall("UPDATE", "UntagResource", err)
-		if err != nil {
-			return err
-		}
-	}
-
-	if len(missing) > 0 {
-		_, err = rm.sdkapi.TagResource(
-			ctx,
-			&svcsdk.TagResourceInput{
-				ResourceARN: arn,
-				Tags:        sdkTagsFromResourceTags(missing),
-			})
-
-		rm.metrics.RecordAPICall("UPDATE", "TagResource", err)
-		if err != nil {
-			return err
-		}
-	}
-	return nil
-}
-
-// sdkTagsFromResourceTags transforms a *svcapitypes.Tag array to a *svcsdk.Tag array.
-func sdkTagsFromResourceTags(rTags []*svcapitypes.Tag) []svcsdktypes.Tag {
-	tags := make([]svcsdktypes.Tag, len(rTags))
-	for i := range rTags {
-		tags[i] = svcsdktypes.Tag{
-			Key:   rTags[i].Key,
-			Value: rTags[i].Value,
-		}
-	}
-	return tags
-}
-
-// sdkTagStringsFromResourceTags transforms a *svcapitypes.Tag array to a string array.
-func sdkTagStringsFromResourceTags(rTags []*svcapitypes.Tag) []string {
-	tags := make([]string, len(rTags))
-	for i := range rTags {
-		tags[i] = *rTags[i].Key
-	}
-	return tags
+) error {
+	return rm.tagsManager().Sync(
+		ctx, string(*latest.ko.Status.ACKResourceMetadata.ARN),
+		desired.ko.Spec.Tags, latest.ko.Spec.Tags,
+	)
 }
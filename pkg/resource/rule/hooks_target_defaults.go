@@ -0,0 +1,172 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package rule
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/aws/arn"
+	flag "github.com/spf13/pflag"
+
+	svcapitypes "github.com/aws-controllers-k8s/eventbridge-controller/apis/v1alpha1"
+)
+
+const (
+	flagTargetMaximumRetryAttempts = "target-default-maximum-retry-attempts"
+	flagTargetMaximumEventAge      = "target-default-maximum-event-age-seconds"
+	flagTargetDeadLetterARN        = "target-default-dead-letter-arn"
+
+	// namespacePlaceholder is replaced with the namespace of the Rule in the
+	// default dead-letter queue ARN, like in the controller's resource tags
+	namespacePlaceholder = "%K8S_NAMESPACE%"
+)
+
+// targetPolicyDefaults are the retry policy and dead-letter queue set on the
+// targets which omit them
+type targetPolicyDefaults struct {
+	// maximumRetryAttempts is unset when negative
+	maximumRetryAttempts int64
+	// maximumEventAgeInSeconds is unset when zero
+	maximumEventAgeInSeconds int64
+	// deadLetterARN is the ARN of the dead-letter queue, which may contain
+	// the namespace placeholder
+	deadLetterARN string
+}
+
+// targetDefaults are configured by the controller flags. The ACK runtime
+// parses the command line flags, so they are registered when the package is
+// loaded.
+var targetDefaults = targetPolicyDefaults{maximumRetryAttempts: -1}
+
+func init() {
+	flag.Var(
+		&int64RangeValue{p: &targetDefaults.maximumRetryAttempts, min: 0, max: 185},
+		flagTargetMaximumRetryAttempts,
+		"The maximum retry attempts of Rule targets without a retry policy, between 0 and 185.",
+	)
+	flag.Var(
+		&int64RangeValue{p: &targetDefaults.maximumEventAgeInSeconds, min: 60, max: 86400},
+		flagTargetMaximumEventAge,
+		"The maximum event age, in seconds, of Rule targets without a retry policy, between 60 and 86400.",
+	)
+	flag.Var(
+		(*deadLetterARNValue)(&targetDefaults.deadLetterARN),
+		flagTargetDeadLetterARN,
+		"The ARN of the dead-letter queue of Rule targets without one. "+
+			namespacePlaceholder+" is replaced with the namespace of the Rule.",
+	)
+}
+
+// enabled returns whether any default is set
+func (d targetPolicyDefaults) enabled() bool {
+	return d.maximumRetryAttempts >= 0 || d.maximumEventAgeInSeconds > 0 || d.deadLetterARN != ""
+}
+
+// apply returns copies of the targets with the defaults set where the
+// targets omit them. A partially set retry policy gets the missing values.
+// Targets which send events to an event bus in another account or Region than
+// the given ones get the given default role of the namespace. Targets are
+// returned unchanged when no default is set.
+func (d targetPolicyDefaults) apply(
+	targets []*svcapitypes.Target,
+	namespace, accountID, region, roleARN string,
+) []*svcapitypes.Target {
+	if (!d.enabled() && roleARN == "") || len(targets) == 0 {
+		return targets
+	}
+	deadLetterARN := strings.ReplaceAll(d.deadLetterARN, namespacePlaceholder, namespace)
+
+	res := make([]*svcapitypes.Target, len(targets))
+	for i, t := range targets {
+		t = t.DeepCopy()
+		if d.maximumRetryAttempts >= 0 || d.maximumEventAgeInSeconds > 0 {
+			if t.RetryPolicy == nil {
+				t.RetryPolicy = &svcapitypes.RetryPolicy{}
+			}
+			if t.RetryPolicy.MaximumRetryAttempts == nil && d.maximumRetryAttempts >= 0 {
+				t.RetryPolicy.MaximumRetryAttempts = aws.Int64(d.maximumRetryAttempts)
+			}
+			if t.RetryPolicy.MaximumEventAgeInSeconds == nil && d.maximumEventAgeInSeconds > 0 {
+				t.RetryPolicy.MaximumEventAgeInSeconds = aws.Int64(d.maximumEventAgeInSeconds)
+			}
+		}
+		// a queue can't be its own dead-letter queue
+		if deadLetterARN != "" && aws.ToString(t.ARN) != deadLetterARN &&
+			(t.DeadLetterConfig == nil || t.DeadLetterConfig.ARN == nil) {
+			t.DeadLetterConfig = &svcapitypes.DeadLetterConfig{ARN: aws.String(deadLetterARN)}
+		}
+		if roleARN != "" && aws.ToString(t.RoleARN) == "" && isCrossAccountEventBus(aws.ToString(t.ARN), accountID, region) {
+			t.RoleARN = aws.String(roleARN)
+		}
+		res[i] = t
+	}
+	return res
+}
+
+// int64RangeValue is a flag value which must be within [min, max]. It is
+// unset until the flag is given.
+type int64RangeValue struct {
+	p        *int64
+	min, max int64
+	set      bool
+}
+
+func (v *int64RangeValue) String() string {
+	if v.p == nil || !v.set {
+		return ""
+	}
+	return strconv.FormatInt(*v.p, 10)
+}
+
+func (v *int64RangeValue) Set(s string) error {
+	i, err := strconv.ParseInt(s, 10, 64)
+	if err != nil {
+		return err
+	}
+	if i < v.min || i > v.max {
+		return fmt.Errorf("must be between %d and %d", v.min, v.max)
+	}
+	*v.p = i
+	v.set = true
+	return nil
+}
+
+func (v *int64RangeValue) Type() string {
+	return "int"
+}
+
+// deadLetterARNValue is a flag value which must be an ARN once the namespace
+// placeholder is replaced
+type deadLetterARNValue string
+
+func (v *deadLetterARNValue) String() string {
+	return string(*v)
+}
+
+func (v *deadLetterARNValue) Set(s string) error {
+	if s != "" {
+		if _, err := arn.Parse(strings.ReplaceAll(s, namespacePlaceholder, "namespace")); err != nil {
+			return fmt.Errorf("invalid ARN %q", s)
+		}
+	}
+	*v = deadLetterARNValue(s)
+	return nil
+}
+
+func (v *deadLetterARNValue) Type() string {
+	return "string"
+}
@@ -0,0 +1,220 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package rule
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/aws/arn"
+	"golang.org/x/exp/slices"
+
+	svcapitypes "github.com/aws-controllers-k8s/eventbridge-controller/apis/v1alpha1"
+)
+
+// targetKind is the type of the resource a target sends events to, as far as
+// it determines the parameter blocks of the target
+type targetKind string
+
+const (
+	targetKindSQSQueue          targetKind = "SQS queue"
+	targetKindSQSFIFOQueue      targetKind = "SQS FIFO queue"
+	targetKindECSCluster        targetKind = "ECS cluster"
+	targetKindBatchJobQueue     targetKind = "Batch job queue"
+	targetKindKinesisStream     targetKind = "Kinesis stream"
+	targetKindRedshift          targetKind = "Redshift cluster or workgroup"
+	targetKindSageMakerPipeline targetKind = "SageMaker pipeline"
+	targetKindRunCommand        targetKind = "Run Command document"
+	targetKindAPIDestination    targetKind = "API destination"
+	targetKindAPIGateway        targetKind = "API Gateway"
+)
+
+const (
+	// minTaskCount and maxTaskCount are the limits of ECSParameters.TaskCount
+	minTaskCount, maxTaskCount = 1, 10
+	// minArraySize and maxArraySize are the limits of
+	// BatchParameters.ArrayProperties.Size
+	minArraySize, maxArraySize = 2, 10000
+)
+
+// targetKindOf returns the kind of the target with the given ARN, and an
+// empty kind for targets without parameter blocks, e.g. Lambda functions
+func targetKindOf(targetARN string) targetKind {
+	a, err := arn.Parse(targetARN)
+	if err != nil {
+		return ""
+	}
+	switch {
+	case a.Service == "sqs" && strings.HasSuffix(a.Resource, ".fifo"):
+		return targetKindSQSFIFOQueue
+	case a.Service == "sqs":
+		return targetKindSQSQueue
+	case a.Service == "ecs" && strings.HasPrefix(a.Resource, "cluster/"):
+		return targetKindECSCluster
+	case a.Service == "batch" && strings.HasPrefix(a.Resource, "job-queue/"):
+		return targetKindBatchJobQueue
+	case a.Service == "kinesis" && strings.HasPrefix(a.Resource, "stream/"):
+		return targetKindKinesisStream
+	case a.Service == "redshift" && strings.HasPrefix(a.Resource, "cluster:"),
+		a.Service == "redshift-serverless" && strings.HasPrefix(a.Resource, "workgroup/"):
+		return targetKindRedshift
+	case a.Service == "sagemaker" && strings.HasPrefix(a.Resource, "pipeline/"):
+		return targetKindSageMakerPipeline
+	case a.Service == "ssm" && strings.HasPrefix(a.Resource, "document/"):
+		return targetKindRunCommand
+	case a.Service == "events" && strings.HasPrefix(a.Resource, "api-destination/"):
+		return targetKindAPIDestination
+	case a.Service == "execute-api":
+		return targetKindAPIGateway
+	}
+	return ""
+}
+
+// parameterBlocks are the parameter blocks of a target and the kinds of
+// targets which support them
+var parameterBlocks = []struct {
+	field string
+	isSet func(t *svcapitypes.Target) bool
+	kinds []targetKind
+}{
+	{
+		field: "ecsParameters",
+		isSet: func(t *svcapitypes.Target) bool { return t.ECSParameters != nil },
+		kinds: []targetKind{targetKindECSCluster},
+	},
+	{
+		field: "batchParameters",
+		isSet: func(t *svcapitypes.Target) bool { return t.BatchParameters != nil },
+		kinds: []targetKind{targetKindBatchJobQueue},
+	},
+	{
+		field: "kinesisParameters",
+		isSet: func(t *svcapitypes.Target) bool { return t.KinesisParameters != nil },
+		kinds: []targetKind{targetKindKinesisStream},
+	},
+	{
+		field: "sqsParameters",
+		isSet: func(t *svcapitypes.Target) bool { return t.SQSParameters != nil },
+		kinds: []targetKind{targetKindSQSQueue, targetKindSQSFIFOQueue},
+	},
+	{
+		field: "redshiftDataParameters",
+		isSet: func(t *svcapitypes.Target) bool { return t.RedshiftDataParameters != nil },
+		kinds: []targetKind{targetKindRedshift},
+	},
+	{
+		field: "sageMakerPipelineParameters",
+		isSet: func(t *svcapitypes.Target) bool { return t.SageMakerPipelineParameters != nil },
+		kinds: []targetKind{targetKindSageMakerPipeline},
+	},
+	{
+		field: "runCommandParameters",
+		isSet: func(t *svcapitypes.Target) bool { return t.RunCommandParameters != nil },
+		kinds: []targetKind{targetKindRunCommand},
+	},
+	{
+		field: "httpParameters",
+		isSet: func(t *svcapitypes.Target) bool { return t.HTTPParameters != nil },
+		kinds: []targetKind{targetKindAPIDestination, targetKindAPIGateway},
+	},
+}
+
+// roleRequired are the kinds of targets EventBridge can only invoke with a
+// role
+var roleRequired = []targetKind{targetKindECSCluster, targetKindBatchJobQueue, targetKindKinesisStream}
+
+// validateTargetParameters checks that the parameter blocks of the targets
+// match the kinds of their ARNs, and the values EventBridge validates within
+// the blocks
+func validateTargetParameters(targets []*svcapitypes.Target) error {
+	for i, t := range targets {
+		if t == nil {
+			continue
+		}
+		field := fmt.Sprintf("spec.targets[%d]", i)
+		kind := targetKindOf(aws.ToString(t.ARN))
+
+		for _, b := range parameterBlocks {
+			if b.isSet(t) && !slices.Contains(b.kinds, kind) {
+				return newValidationError(field+"."+b.field, "only supported for "+joinKinds(b.kinds)+" targets")
+			}
+		}
+		if slices.Contains(roleRequired, kind) && aws.ToString(t.RoleARN) == "" {
+			return newValidationError(field+".roleARN", fmt.Sprintf("required for %s targets", kind))
+		}
+
+		switch kind {
+		case targetKindSQSQueue:
+			if t.SQSParameters != nil && t.SQSParameters.MessageGroupID != nil {
+				return newValidationError(
+					field+".sqsParameters.messageGroupID",
+					fmt.Sprintf("only supported for %s targets", targetKindSQSFIFOQueue),
+				)
+			}
+		case targetKindSQSFIFOQueue:
+			if t.SQSParameters == nil || aws.ToString(t.SQSParameters.MessageGroupID) == "" {
+				return newValidationError(
+					field+".sqsParameters.messageGroupID",
+					fmt.Sprintf("required for %s targets", kind),
+				)
+			}
+		case targetKindECSCluster:
+			p := t.ECSParameters
+			if p == nil || aws.ToString(p.TaskDefinitionARN) == "" {
+				return newValidationError(
+					field+".ecsParameters.taskDefinitionARN",
+					fmt.Sprintf("required for %s targets", kind),
+				)
+			}
+			if c := p.TaskCount; c != nil && (*c < minTaskCount || *c > maxTaskCount) {
+				return newValidationError(
+					field+".ecsParameters.taskCount",
+					fmt.Sprintf("must be between %d and %d, got %d", minTaskCount, maxTaskCount, *c),
+				)
+			}
+		case targetKindBatchJobQueue:
+			p := t.BatchParameters
+			if p == nil || aws.ToString(p.JobDefinition) == "" || aws.ToString(p.JobName) == "" {
+				return newValidationError(
+					field+".batchParameters",
+					fmt.Sprintf("%q and %q are required for %s targets", "jobDefinition", "jobName", kind),
+				)
+			}
+			if p.ArrayProperties != nil {
+				if s := p.ArrayProperties.Size; s != nil && (*s < minArraySize || *s > maxArraySize) {
+					return newValidationError(
+						field+".batchParameters.arrayProperties.size",
+						fmt.Sprintf("must be between %d and %d, got %d", minArraySize, maxArraySize, *s),
+					)
+				}
+			}
+		case targetKindKinesisStream:
+			if p := t.KinesisParameters; p != nil && aws.ToString(p.PartitionKeyPath) == "" {
+				return newValidationError(field+".kinesisParameters.partitionKeyPath", "must be specified")
+			}
+		}
+	}
+	return nil
+}
+
+// joinKinds returns the kinds for an error message, e.g. "SQS queue and SQS
+// FIFO queue"
+func joinKinds(kinds []targetKind) string {
+	s := make([]string, len(kinds))
+	for i, k := range kinds {
+		s[i] = string(k)
+	}
+	return strings.Join(s, " and ")
+}
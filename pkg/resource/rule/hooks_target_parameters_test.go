@@ -0,0 +1,205 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package rule
+
+import (
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"gotest.tools/v3/assert"
+
+	svcapitypes "github.com/aws-controllers-k8s/eventbridge-controller/apis/v1alpha1"
+)
+
+func Test_validateTargetParameters(t *testing.T) {
+	const (
+		queueARN     = "arn:aws:sqs:us-west-2:123456789012:orders"
+		fifoQueueARN = "arn:aws:sqs:us-west-2:123456789012:orders.fifo"
+		clusterARN   = "arn:aws:ecs:us-west-2:123456789012:cluster/orders"
+		jobQueueARN  = "arn:aws:batch:us-west-2:123456789012:job-queue/orders"
+		streamARN    = "arn:aws:kinesis:us-west-2:123456789012:stream/orders"
+		roleARN      = "arn:aws:iam::123456789012:role/events"
+	)
+	ecsParameters := func(taskCount int64) *svcapitypes.ECSParameters {
+		return &svcapitypes.ECSParameters{
+			TaskDefinitionARN: aws.String("arn:aws:ecs:us-west-2:123456789012:task-definition/orders:1"),
+			TaskCount:         aws.Int64(taskCount),
+		}
+	}
+	batchParameters := func(size int64) *svcapitypes.BatchParameters {
+		return &svcapitypes.BatchParameters{
+			JobDefinition:   aws.String("orders"),
+			JobName:         aws.String("orders"),
+			ArrayProperties: &svcapitypes.BatchArrayProperties{Size: aws.Int64(size)},
+		}
+	}
+	tests := []struct {
+		name    string
+		target  svcapitypes.Target
+		wantErr string
+	}{
+		{
+			name:   "lambda function without parameters",
+			target: svcapitypes.Target{ARN: aws.String("arn:aws:lambda:us-west-2:123456789012:function:orders")},
+		},
+		{
+			name:   "queue",
+			target: svcapitypes.Target{ARN: aws.String(queueARN), SQSParameters: &svcapitypes.SQSParameters{}},
+		},
+		{
+			name: "message group of a queue",
+			target: svcapitypes.Target{
+				ARN:           aws.String(queueARN),
+				SQSParameters: &svcapitypes.SQSParameters{MessageGroupID: aws.String("orders")},
+			},
+			wantErr: `"spec.targets[0].sqsParameters.messageGroupID": only supported for SQS FIFO queue targets`,
+		},
+		{
+			name: "fifo queue",
+			target: svcapitypes.Target{
+				ARN:           aws.String(fifoQueueARN),
+				SQSParameters: &svcapitypes.SQSParameters{MessageGroupID: aws.String("orders")},
+			},
+		},
+		{
+			name:    "fifo queue without message group",
+			target:  svcapitypes.Target{ARN: aws.String(fifoQueueARN)},
+			wantErr: `"spec.targets[0].sqsParameters.messageGroupID": required for SQS FIFO queue targets`,
+		},
+		{
+			name:    "sqs parameters of a stream",
+			target:  svcapitypes.Target{ARN: aws.String(streamARN), RoleARN: aws.String(roleARN), SQSParameters: &svcapitypes.SQSParameters{}},
+			wantErr: `"spec.targets[0].sqsParameters": only supported for SQS queue and SQS FIFO queue targets`,
+		},
+		{
+			name:   "ecs cluster",
+			target: svcapitypes.Target{ARN: aws.String(clusterARN), RoleARN: aws.String(roleARN), ECSParameters: ecsParameters(10)},
+		},
+		{
+			name:    "ecs parameters of a queue",
+			target:  svcapitypes.Target{ARN: aws.String(queueARN), ECSParameters: ecsParameters(1)},
+			wantErr: `"spec.targets[0].ecsParameters": only supported for ECS cluster targets`,
+		},
+		{
+			name:    "ecs cluster without role",
+			target:  svcapitypes.Target{ARN: aws.String(clusterARN), ECSParameters: ecsParameters(1)},
+			wantErr: `"spec.targets[0].roleARN": required for ECS cluster targets`,
+		},
+		{
+			name:    "ecs cluster without task definition",
+			target:  svcapitypes.Target{ARN: aws.String(clusterARN), RoleARN: aws.String(roleARN)},
+			wantErr: `"spec.targets[0].ecsParameters.taskDefinitionARN": required for ECS cluster targets`,
+		},
+		{
+			name:    "ecs task count too low",
+			target:  svcapitypes.Target{ARN: aws.String(clusterARN), RoleARN: aws.String(roleARN), ECSParameters: ecsParameters(0)},
+			wantErr: `"spec.targets[0].ecsParameters.taskCount": must be between 1 and 10, got 0`,
+		},
+		{
+			name:    "ecs task count too high",
+			target:  svcapitypes.Target{ARN: aws.String(clusterARN), RoleARN: aws.String(roleARN), ECSParameters: ecsParameters(11)},
+			wantErr: `"spec.targets[0].ecsParameters.taskCount": must be between 1 and 10, got 11`,
+		},
+		{
+			name:   "batch job queue",
+			target: svcapitypes.Target{ARN: aws.String(jobQueueARN), RoleARN: aws.String(roleARN), BatchParameters: batchParameters(2)},
+		},
+		{
+			name:    "batch job queue without role",
+			target:  svcapitypes.Target{ARN: aws.String(jobQueueARN), BatchParameters: batchParameters(2)},
+			wantErr: `"spec.targets[0].roleARN": required for Batch job queue targets`,
+		},
+		{
+			name:    "batch job queue without job",
+			target:  svcapitypes.Target{ARN: aws.String(jobQueueARN), RoleARN: aws.String(roleARN)},
+			wantErr: `"spec.targets[0].batchParameters": "jobDefinition" and "jobName" are required for Batch job queue targets`,
+		},
+		{
+			name:    "batch array size out of range",
+			target:  svcapitypes.Target{ARN: aws.String(jobQueueARN), RoleARN: aws.String(roleARN), BatchParameters: batchParameters(1)},
+			wantErr: `"spec.targets[0].batchParameters.arrayProperties.size": must be between 2 and 10000, got 1`,
+		},
+		{
+			name: "kinesis stream",
+			target: svcapitypes.Target{
+				ARN:               aws.String(streamARN),
+				RoleARN:           aws.String(roleARN),
+				KinesisParameters: &svcapitypes.KinesisParameters{PartitionKeyPath: aws.String("$.id")},
+			},
+		},
+		{
+			name:    "kinesis stream without role",
+			target:  svcapitypes.Target{ARN: aws.String(streamARN)},
+			wantErr: `"spec.targets[0].roleARN": required for Kinesis stream targets`,
+		},
+		{
+			name: "kinesis stream without partition key path",
+			target: svcapitypes.Target{
+				ARN:               aws.String(streamARN),
+				RoleARN:           aws.String(roleARN),
+				KinesisParameters: &svcapitypes.KinesisParameters{},
+			},
+			wantErr: `"spec.targets[0].kinesisParameters.partitionKeyPath": must be specified`,
+		},
+		{
+			name: "api destination",
+			target: svcapitypes.Target{
+				ARN:            aws.String("arn:aws:events:us-west-2:123456789012:api-destination/orders/0123"),
+				HTTPParameters: &svcapitypes.HTTPParameters{},
+			},
+		},
+		{
+			name: "api gateway",
+			target: svcapitypes.Target{
+				ARN:            aws.String("arn:aws:execute-api:us-west-2:123456789012:a1b2c3/prod/POST/orders"),
+				HTTPParameters: &svcapitypes.HTTPParameters{},
+			},
+		},
+		{
+			name:    "http parameters of a queue",
+			target:  svcapitypes.Target{ARN: aws.String(queueARN), HTTPParameters: &svcapitypes.HTTPParameters{}},
+			wantErr: `"spec.targets[0].httpParameters": only supported for API destination and API Gateway targets`,
+		},
+		{
+			name: "redshift serverless workgroup",
+			target: svcapitypes.Target{
+				ARN:                    aws.String("arn:aws:redshift-serverless:us-west-2:123456789012:workgroup/0123"),
+				RedshiftDataParameters: &svcapitypes.RedshiftDataParameters{},
+			},
+		},
+		{
+			name: "sagemaker parameters of a run command document",
+			target: svcapitypes.Target{
+				ARN:                         aws.String("arn:aws:ssm:us-west-2::document/AWS-RunShellScript"),
+				SageMakerPipelineParameters: &svcapitypes.SageMakerPipelineParameters{},
+			},
+			wantErr: `"spec.targets[0].sageMakerPipelineParameters": only supported for SageMaker pipeline targets`,
+		},
+		{
+			name:    "parameters of an invalid arn",
+			target:  svcapitypes.Target{ARN: aws.String("orders"), RunCommandParameters: &svcapitypes.RunCommandParameters{}},
+			wantErr: `"spec.targets[0].runCommandParameters": only supported for Run Command document targets`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateTargetParameters([]*svcapitypes.Target{&tt.target})
+			if tt.wantErr != "" {
+				assert.ErrorContains(t, err, tt.wantErr)
+			} else {
+				assert.NilError(t, err)
+			}
+		})
+	}
+}
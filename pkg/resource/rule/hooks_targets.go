@@ -16,7 +16,9 @@ package rule
 import (
 	"context"
 	"errors"
+	"fmt"
 	"reflect"
+	"strings"
 
 	"github.com/aws-controllers-k8s/runtime/pkg/runtime/log"
 	ackrtlog "github.com/aws-controllers-k8s/runtime/pkg/runtime/log"
@@ -26,6 +28,7 @@ import (
 	svcsdktypes "github.com/aws/aws-sdk-go-v2/service/eventbridge/types"
 
 	"github.com/aws-controllers-k8s/eventbridge-controller/apis/v1alpha1"
+	"github.com/aws-controllers-k8s/eventbridge-controller/pkg/metrics"
 	pkgtags "github.com/aws-controllers-k8s/eventbridge-controller/pkg/tags"
 
 	svcapitypes "github.com/aws-controllers-k8s/eventbridge-controller/apis/v1alpha1"
@@ -50,8 +53,8 @@ func validateTargets(targets []*svcapitypes.Target) error {
 	return nil
 }
 
-// getTags retrieves a resource list of tags.
-func (rm *resourceManager) getTargets(ctx context.Context, rule, bus string) (targets []*svcapitypes.Target, err error) {
+// getTargets retrieves the targets of a rule.
+func (rm *resourceManager) getTargets(ctx context.Context, rule string, bus *string) (targets []*svcapitypes.Target, err error) {
 	rlog := log.FromContext(ctx)
 	exit := rlog.Trace("rm.getTargets")
 	defer func() { exit(err) }()
@@ -60,7 +63,7 @@ func (rm *resourceManager) getTargets(ctx context.Context, rule, bus string) (ta
 	listTargetsResponse, err = rm.sdkapi.ListTargetsByRule(
 		ctx,
 		&svcsdk.ListTargetsByRuleInput{
-			EventBusName: aws.String(bus),
+			EventBusName: rm.eventBusIdentifier(bus),
 			Rule:         aws.String(rule),
 		},
 	)
@@ -76,9 +79,12 @@ func (rm *resourceManager) getTargets(ctx context.Context, rule, bus string) (ta
 	return resourceTargetsFromSDKTargets(sdkTargets), nil
 }
 
-// syncTargets synchronizes rule targets
+// syncTargets synchronizes rule targets. The target defaults are applied to
+// the desired targets with the default target role of the namespace.
 func (rm *resourceManager) syncTargets(
 	ctx context.Context,
+	namespace string,
+	roleARN *string,
 	ruleName *string,
 	eventBus *string, // name or arn
 	desired, latest []*v1alpha1.Target,
@@ -87,7 +93,10 @@ func (rm *resourceManager) syncTargets(
 	exit := rlog.Trace("rm.syncTargets")
 	defer func() { exit(err) }()
 
-	added, removed := computeTargetsDelta(latest, desired)
+	added, removed := computeTargetsDelta(latest, targetDefaults.apply(
+		desired, namespace, string(rm.awsAccountID), string(rm.awsRegion), aws.ToString(roleARN),
+	))
+	eventBus = rm.eventBusIdentifier(eventBus)
 
 	if len(removed) > 0 {
 		// Convert []*string to []string
@@ -96,7 +105,8 @@ func (rm *resourceManager) syncTargets(
 			tagKeys[i] = *key
 		}
 
-		_, err = rm.sdkapi.RemoveTargets(
+		var resp *svcsdk.RemoveTargetsOutput
+		resp, err = rm.sdkapi.RemoveTargets(
 			ctx,
 			&svcsdk.RemoveTargetsInput{
 				// NOTE(a-hilaly,embano1): we might need to force the removal, in some cases?
@@ -109,6 +119,11 @@ func (rm *resourceManager) syncTargets(
 		if err != nil {
 			return err
 		}
+		if resp.FailedEntryCount > 0 {
+			metrics.RecordFailedTargetEntries("RemoveTargets", failedEntryCodes(resp.FailedEntries,
+				func(e svcsdktypes.RemoveTargetsResultEntry) *string { return e.ErrorCode }))
+			return newFailedTargetsError("remove", removeTargetsFailures(resp.FailedEntries))
+		}
 	}
 
 	if len(added) > 0 {
@@ -123,7 +138,8 @@ func (rm *resourceManager) syncTargets(
 			targets[i] = *t
 		}
 
-		_, err = rm.sdkapi.PutTargets(
+		var resp *svcsdk.PutTargetsOutput
+		resp, err = rm.sdkapi.PutTargets(
 			ctx,
 			&svcsdk.PutTargetsInput{
 				Rule:         ruleName,
@@ -134,10 +150,55 @@ func (rm *resourceManager) syncTargets(
 		if err != nil {
 			return err
 		}
+		if resp.FailedEntryCount > 0 {
+			metrics.RecordFailedTargetEntries("PutTargets", failedEntryCodes(resp.FailedEntries,
+				func(e svcsdktypes.PutTargetsResultEntry) *string { return e.ErrorCode }))
+			return newFailedTargetsError("put", putTargetsFailures(resp.FailedEntries))
+		}
 	}
 	return nil
 }
 
+// failedTargetsError is returned when the PutTargets or RemoveTargets API
+// accepted the request but could not process one or more target entries
+type failedTargetsError struct {
+	op       string
+	failures []string
+}
+
+func (e failedTargetsError) Error() string {
+	return fmt.Sprintf("failed to %s %d target(s): %s", e.op, len(e.failures), strings.Join(e.failures, "; "))
+}
+
+func newFailedTargetsError(op string, failures []string) failedTargetsError {
+	return failedTargetsError{
+		op:       op,
+		failures: failures,
+	}
+}
+
+// putTargetsFailures returns a human readable description of each failed
+// PutTargets entry
+func putTargetsFailures(entries []svcsdktypes.PutTargetsResultEntry) []string {
+	failures := make([]string, len(entries))
+	for i, e := range entries {
+		failures[i] = fmt.Sprintf("%s: %s (%s)",
+			aws.ToString(e.TargetId), aws.ToString(e.ErrorMessage), aws.ToString(e.ErrorCode))
+	}
+	return failures
+}
+
+// removeTargetsFailures returns a human readable description of each failed
+// RemoveTargets entry
+func removeTargetsFailures(entries []svcsdktypes.RemoveTargetsResultEntry) []string {
+	failures := make([]string, len(entries))
+	for i, e := range entries {
+		failures[i] = fmt.Sprintf("%s: %s (%s)",
+			aws.ToString(e.TargetId), aws.ToString(e.ErrorMessage), aws.ToString(e.ErrorCode))
+	}
+	return failures
+}
+
 // computeTargetsDelta computes the delta between the specified targets and
 // returns added and removed targets
 func computeTargetsDelta(
@@ -166,12 +227,17 @@ mainLoop:
 	return added, removed
 }
 
-// equalTargets returns true if two Tag arrays are equal regardless of the order
-// of their elements.
+// equalTargets returns true if the desired and latest targets are equal
+// regardless of the order of their elements. The target defaults of the
+// namespace, account and Region, and the default target role of the
+// namespace, are applied to the desired targets, since targets which omit
+// them are created with them, but not to the latest targets, so defaults
+// removed outside of the controller are put again.
 func equalTargets(
-	a []*svcapitypes.Target,
-	b []*svcapitypes.Target,
+	desired []*svcapitypes.Target,
+	latest []*svcapitypes.Target,
+	namespace, accountID, region, roleARN string,
 ) bool {
-	added, removed := computeTargetsDelta(a, b)
+	added, removed := computeTargetsDelta(latest, targetDefaults.apply(desired, namespace, accountID, region, roleARN))
 	return len(added) == 0 && len(removed) == 0
 }
@@ -17,9 +17,11 @@ import (
 	"testing"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
+	svcsdktypes "github.com/aws/aws-sdk-go-v2/service/eventbridge/types"
 	"gotest.tools/v3/assert"
 
 	svcapitypes "github.com/aws-controllers-k8s/eventbridge-controller/apis/v1alpha1"
+	"github.com/aws-controllers-k8s/eventbridge-controller/pkg/testutil"
 )
 
 const (
@@ -100,3 +102,117 @@ func Test_validateTargets(t *testing.T) {
 		})
 	}
 }
+
+func Test_failedTargetsError(t *testing.T) {
+	err := newFailedTargetsError("put", putTargetsFailures([]svcsdktypes.PutTargetsResultEntry{
+		{
+			TargetId:     aws.String("id1"),
+			ErrorCode:    aws.String("AccessDeniedException"),
+			ErrorMessage: aws.String("access denied"),
+		},
+	}))
+	assert.Error(t, err, "failed to put 1 target(s): id1: access denied (AccessDeniedException)")
+}
+
+func Test_targetPolicyDefaults_apply(t *testing.T) {
+	const dlq = "arn:aws:sqs:us-west-2:123456789012:orders-dlq"
+	defaults := targetPolicyDefaults{
+		maximumRetryAttempts:     3,
+		maximumEventAgeInSeconds: 3600,
+		deadLetterARN:            "arn:aws:sqs:us-west-2:123456789012:" + namespacePlaceholder + "-dlq",
+	}
+	tests := []struct {
+		name     string
+		defaults targetPolicyDefaults
+		target   *svcapitypes.Target
+		want     *svcapitypes.Target
+	}{
+		{
+			name:     "no defaults",
+			defaults: targetPolicyDefaults{maximumRetryAttempts: -1},
+			target:   &svcapitypes.Target{ID: aws.String("t"), ARN: aws.String("arn:t")},
+			want:     &svcapitypes.Target{ID: aws.String("t"), ARN: aws.String("arn:t")},
+		}, {
+			name:     "target without policies",
+			defaults: defaults,
+			target:   &svcapitypes.Target{ID: aws.String("t"), ARN: aws.String("arn:t")},
+			want: &svcapitypes.Target{
+				ID:  aws.String("t"),
+				ARN: aws.String("arn:t"),
+				RetryPolicy: &svcapitypes.RetryPolicy{
+					MaximumRetryAttempts:     aws.Int64(3),
+					MaximumEventAgeInSeconds: aws.Int64(3600),
+				},
+				DeadLetterConfig: &svcapitypes.DeadLetterConfig{ARN: aws.String(dlq)},
+			},
+		}, {
+			name:     "partial retry policy and own dead-letter queue",
+			defaults: defaults,
+			target: &svcapitypes.Target{
+				ID:               aws.String("t"),
+				ARN:              aws.String("arn:t"),
+				RetryPolicy:      &svcapitypes.RetryPolicy{MaximumRetryAttempts: aws.Int64(0)},
+				DeadLetterConfig: &svcapitypes.DeadLetterConfig{ARN: aws.String("arn:dlq")},
+			},
+			want: &svcapitypes.Target{
+				ID:  aws.String("t"),
+				ARN: aws.String("arn:t"),
+				RetryPolicy: &svcapitypes.RetryPolicy{
+					MaximumRetryAttempts:     aws.Int64(0),
+					MaximumEventAgeInSeconds: aws.Int64(3600),
+				},
+				DeadLetterConfig: &svcapitypes.DeadLetterConfig{ARN: aws.String("arn:dlq")},
+			},
+		}, {
+			name:     "dead-letter queue as target",
+			defaults: targetPolicyDefaults{maximumRetryAttempts: -1, deadLetterARN: dlq},
+			target:   &svcapitypes.Target{ID: aws.String("t"), ARN: aws.String(dlq)},
+			want:     &svcapitypes.Target{ID: aws.String("t"), ARN: aws.String(dlq)},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			target := tt.target.DeepCopy()
+			got := tt.defaults.apply([]*svcapitypes.Target{target}, "orders", testutil.DefaultAccountID, testutil.DefaultRegion, "")
+			assert.DeepEqual(t, got, []*svcapitypes.Target{tt.want})
+			assert.DeepEqual(t, target, tt.target)
+		})
+	}
+}
+
+func Test_equalTargets_targetDefaults(t *testing.T) {
+	defer func(d targetPolicyDefaults) { targetDefaults = d }(targetDefaults)
+	targetDefaults = targetPolicyDefaults{maximumRetryAttempts: 3}
+
+	desired := []*svcapitypes.Target{{ID: aws.String("t"), ARN: aws.String("arn:t")}}
+	latest := []*svcapitypes.Target{{
+		ID:          aws.String("t"),
+		ARN:         aws.String("arn:t"),
+		RetryPolicy: &svcapitypes.RetryPolicy{MaximumRetryAttempts: aws.Int64(3)},
+	}}
+	assert.Assert(t, equalTargets(desired, latest, "orders", testutil.DefaultAccountID, testutil.DefaultRegion, ""))
+
+	latest[0].RetryPolicy.MaximumRetryAttempts = aws.Int64(5)
+	assert.Assert(t, !equalTargets(desired, latest, "orders", testutil.DefaultAccountID, testutil.DefaultRegion, ""))
+
+	// the defaults aren't applied to the latest targets
+	latest[0].RetryPolicy = nil
+	assert.Assert(t, !equalTargets(desired, latest, "orders", testutil.DefaultAccountID, testutil.DefaultRegion, ""))
+}
+
+func Test_int64RangeValue(t *testing.T) {
+	var i int64 = -1
+	v := &int64RangeValue{p: &i, min: 0, max: 185}
+	assert.Equal(t, v.String(), "")
+	assert.Error(t, v.Set("186"), "must be between 0 and 185")
+	assert.ErrorContains(t, v.Set("three"), "invalid syntax")
+	assert.NilError(t, v.Set("0"))
+	assert.Equal(t, i, int64(0))
+	assert.Equal(t, v.String(), "0")
+}
+
+func Test_deadLetterARNValue(t *testing.T) {
+	var v deadLetterARNValue
+	assert.NilError(t, v.Set("arn:aws:sqs:us-west-2:123456789012:"+namespacePlaceholder+"-dlq"))
+	assert.Error(t, v.Set("dlq"), `invalid ARN "dlq"`)
+}
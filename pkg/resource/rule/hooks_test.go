@@ -16,6 +16,7 @@ package rule
 import (
 	"testing"
 
+	ackcompare "github.com/aws-controllers-k8s/runtime/pkg/compare"
 	"github.com/aws/aws-sdk-go/aws"
 
 	"github.com/aws-controllers-k8s/eventbridge-controller/apis/v1alpha1"
@@ -220,6 +221,10 @@ func Test_equalEventBusName(t *testing.T) {
 		defaultEventBusName = "default"
 		emptyString         = ""
 		customEventBusName  = "custom"
+		defaultEventBusARN  = "arn:aws:events:us-west-2:123456789012:event-bus/default"
+		customEventBusARN   = "arn:aws:events:us-west-2:123456789012:event-bus/custom"
+		otherAccountBusARN  = "arn:aws:events:us-west-2:444455556666:event-bus/custom"
+		otherRegionBusARN   = "arn:aws:events:us-east-1:123456789012:event-bus/custom"
 	)
 	type args struct {
 		desiredExpression *string
@@ -300,13 +305,152 @@ func Test_equalEventBusName(t *testing.T) {
 				latestExpression:  &customEventBusName,
 			},
 			want: false,
+		}, {
+			name: "equal: desired custom arn, latest custom value",
+			args: args{
+				desiredExpression: &customEventBusARN,
+				latestExpression:  &customEventBusName,
+			},
+			want: true,
+		}, {
+			name: "equal: desired custom value, latest custom arn",
+			args: args{
+				desiredExpression: &customEventBusName,
+				latestExpression:  &customEventBusARN,
+			},
+			want: true,
+		}, {
+			name: "equal: desired default arn, latest nil",
+			args: args{
+				desiredExpression: &defaultEventBusARN,
+				latestExpression:  nil,
+			},
+			want: true,
+		}, {
+			name: "not equal: desired custom arn, latest default",
+			args: args{
+				desiredExpression: &customEventBusARN,
+				latestExpression:  &defaultEventBusName,
+			},
+			want: false,
+		}, {
+			name: "not equal: arns of different accounts",
+			args: args{
+				desiredExpression: &customEventBusARN,
+				latestExpression:  &otherAccountBusARN,
+			},
+			want: false,
+		}, {
+			name: "not equal: desired arn of another account, latest custom value",
+			args: args{
+				desiredExpression: &otherAccountBusARN,
+				latestExpression:  &customEventBusName,
+			},
+			want: false,
+		}, {
+			name: "not equal: desired custom value, latest arn of another account",
+			args: args{
+				desiredExpression: &customEventBusName,
+				latestExpression:  &otherAccountBusARN,
+			},
+			want: false,
+		}, {
+			name: "not equal: desired custom value, latest arn of another region",
+			args: args{
+				desiredExpression: &customEventBusName,
+				latestExpression:  &otherRegionBusARN,
+			},
+			want: false,
 		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			if got := equalEventBusName(tt.args.desiredExpression, tt.args.latestExpression); got != tt.want {
+			if got := equalEventBusName(tt.args.desiredExpression, tt.args.latestExpression, "123456789012", "us-west-2"); got != tt.want {
 				t.Errorf("equalEventBusName() = %v, want %v", got, tt.want)
 			}
 		})
 	}
 }
+
+func Test_customPreCompare_tags(t *testing.T) {
+	tag := func(k, v string) *v1alpha1.Tag {
+		return &v1alpha1.Tag{Key: aws.String(k), Value: aws.String(v)}
+	}
+	tests := []struct {
+		name    string
+		desired []*v1alpha1.Tag
+		latest  []*v1alpha1.Tag
+		want    int
+	}{
+		{
+			name:    "equal",
+			desired: []*v1alpha1.Tag{tag("a", "1"), tag("b", "2")},
+			latest:  []*v1alpha1.Tag{tag("b", "2"), tag("a", "1")},
+		},
+		{
+			name:    "different length and value",
+			desired: []*v1alpha1.Tag{tag("a", "1"), tag("b", "2")},
+			latest:  []*v1alpha1.Tag{tag("a", "2")},
+			want:    1,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			desired := &resource{ko: &v1alpha1.Rule{Spec: v1alpha1.RuleSpec{Tags: tt.desired}}}
+			latest := &resource{ko: &v1alpha1.Rule{Spec: v1alpha1.RuleSpec{Tags: tt.latest}}}
+			delta := ackcompare.NewDelta()
+			customPreCompare(delta, desired, latest)
+
+			got := 0
+			for _, d := range delta.Differences {
+				if d.Path.Contains("Spec.Tags") {
+					got++
+				}
+			}
+			if got != tt.want {
+				t.Errorf("Spec.Tags differences = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_resourceManager_eventBusIdentifier(t *testing.T) {
+	rm := &resourceManager{awsAccountID: "123456789012", awsRegion: "us-west-2"}
+	tests := []struct {
+		name      string
+		nameOrARN *string
+		want      *string
+	}{
+		{name: "nil", want: nil},
+		{name: "default", nameOrARN: aws.String("default"), want: nil},
+		{name: "name", nameOrARN: aws.String("orders"), want: aws.String("orders")},
+		{
+			name:      "default event bus arn",
+			nameOrARN: aws.String("arn:aws:events:us-west-2:123456789012:event-bus/default"),
+			want:      nil,
+		},
+		{
+			name:      "arn in the account",
+			nameOrARN: aws.String("arn:aws:events:us-west-2:123456789012:event-bus/orders"),
+			want:      aws.String("orders"),
+		},
+		{
+			name:      "arn in another account",
+			nameOrARN: aws.String("arn:aws:events:us-west-2:444455556666:event-bus/default"),
+			want:      aws.String("arn:aws:events:us-west-2:444455556666:event-bus/default"),
+		},
+		{
+			name:      "arn in another region",
+			nameOrARN: aws.String("arn:aws:events:eu-west-1:123456789012:event-bus/orders"),
+			want:      aws.String("arn:aws:events:eu-west-1:123456789012:event-bus/orders"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := rm.eventBusIdentifier(tt.nameOrARN)
+			if aws.StringValue(got) != aws.StringValue(tt.want) || (got == nil) != (tt.want == nil) {
+				t.Errorf("eventBusIdentifier() = %v, want %v", aws.StringValue(got), aws.StringValue(tt.want))
+			}
+		})
+	}
+}
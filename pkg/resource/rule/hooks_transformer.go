@@ -0,0 +1,61 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package rule
+
+import (
+	"fmt"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+
+	svcapitypes "github.com/aws-controllers-k8s/eventbridge-controller/apis/v1alpha1"
+	"github.com/aws-controllers-k8s/eventbridge-controller/pkg/transformer"
+)
+
+// validateTargetInputs validates the input transformers of the targets. The
+// inputs rendered for the sample event aren't validated: failing to render
+// them isn't terminal, setInputPreviews reports the errors in
+// Status.InputPreviews.
+func validateTargetInputs(spec svcapitypes.RuleSpec) error {
+	for i, t := range spec.Targets {
+		if err := transformer.Validate(t.InputTransformer); err != nil {
+			return newValidationError(fmt.Sprintf("spec.targets[%d].inputTransformer", i), err.Error())
+		}
+	}
+	return nil
+}
+
+// setInputPreviews renders the input of every target for the sample event
+// into Status.InputPreviews. Targets whose input can't be rendered get the
+// error instead.
+func setInputPreviews(ko *svcapitypes.Rule) {
+	if ko.Spec.SampleEvent == nil || len(ko.Spec.Targets) == 0 {
+		ko.Status.InputPreviews = nil
+		return
+	}
+
+	ctx := transformer.Context{RuleName: aws.ToString(ko.Spec.Name)}
+	if md := ko.Status.ACKResourceMetadata; md != nil && md.ARN != nil {
+		ctx.RuleARN = string(*md.ARN)
+	}
+	previews := make(map[string]*string, len(ko.Spec.Targets))
+	for _, t := range ko.Spec.Targets {
+		input, err := transformer.RenderTarget(t, []byte(*ko.Spec.SampleEvent), ctx)
+		if err != nil {
+			previews[aws.ToString(t.ID)] = aws.String(fmt.Sprintf("error: %v", err))
+			continue
+		}
+		previews[aws.ToString(t.ID)] = aws.String(string(input))
+	}
+	ko.Status.InputPreviews = previews
+}
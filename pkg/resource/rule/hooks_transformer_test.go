@@ -0,0 +1,119 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package rule
+
+import (
+	"strings"
+	"testing"
+
+	ackv1alpha1 "github.com/aws-controllers-k8s/runtime/apis/core/v1alpha1"
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"gotest.tools/v3/assert"
+
+	svcapitypes "github.com/aws-controllers-k8s/eventbridge-controller/apis/v1alpha1"
+)
+
+const sampleOrderEvent = `{"detail-type": "OrderCreated", "detail": {"id": "o-1", "total": 10}}`
+
+func orderTransformer(template string) *svcapitypes.InputTransformer {
+	return &svcapitypes.InputTransformer{
+		InputPathsMap: map[string]*string{"id": aws.String("$.detail.id")},
+		InputTemplate: aws.String(template),
+	}
+}
+
+func Test_validateTargetInputs(t *testing.T) {
+	tests := []struct {
+		name    string
+		spec    svcapitypes.RuleSpec
+		wantErr string
+	}{
+		{
+			name: "valid transformer without sample event",
+			spec: svcapitypes.RuleSpec{Targets: []*svcapitypes.Target{
+				{InputTransformer: orderTransformer(`{"id": <id>}`)},
+			}},
+		},
+		{
+			name: "invalid transformer",
+			spec: svcapitypes.RuleSpec{Targets: []*svcapitypes.Target{
+				{},
+				{InputTransformer: orderTransformer(`{"id": <id>, "total": <total>}`)},
+			}},
+			wantErr: `invalid Spec: "spec.targets[1].inputTransformer": inputTemplate placeholder <total> is not declared in inputPathsMap`,
+		},
+		{
+			name: "invalid sample event",
+			spec: svcapitypes.RuleSpec{
+				SampleEvent: aws.String(`["not", "an", "event"]`),
+				Targets:     []*svcapitypes.Target{{InputPath: aws.String("$.detail.id")}},
+			},
+		},
+		{
+			name: "sample event not matching the input path",
+			spec: svcapitypes.RuleSpec{
+				SampleEvent: aws.String(sampleOrderEvent),
+				Targets: []*svcapitypes.Target{
+					{InputPath: aws.String("$.detail.id")},
+					{InputPath: aws.String("$.detail.customer")},
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateTargetInputs(tt.spec)
+			if tt.wantErr != "" {
+				assert.Error(t, err, tt.wantErr)
+				return
+			}
+			assert.NilError(t, err)
+		})
+	}
+}
+
+func Test_setInputPreviews(t *testing.T) {
+	arn := ackv1alpha1.AWSResourceName("arn:aws:events:us-west-2:123456789012:rule/orders")
+	ko := &svcapitypes.Rule{
+		Spec: svcapitypes.RuleSpec{
+			Name:        aws.String("orders"),
+			SampleEvent: aws.String(sampleOrderEvent),
+			Targets: []*svcapitypes.Target{
+				{ID: aws.String("event")},
+				{ID: aws.String("transformed"), InputTransformer: orderTransformer(`{"id": <id>, "rule": <aws.events.rule-arn>}`)},
+				{ID: aws.String("missing"), InputPath: aws.String("$.detail.customer")},
+			},
+		},
+		Status: svcapitypes.RuleStatus{
+			ACKResourceMetadata: &ackv1alpha1.ResourceMetadata{ARN: &arn},
+		},
+	}
+
+	setInputPreviews(ko)
+	assert.DeepEqual(t, ko.Status.InputPreviews, map[string]*string{
+		"event":       aws.String(`{"detail":{"id":"o-1","total":10},"detail-type":"OrderCreated"}`),
+		"transformed": aws.String(`{"id": "o-1", "rule": "arn:aws:events:us-west-2:123456789012:rule/orders"}`),
+		"missing":     aws.String(`error: inputPath "$.detail.customer" doesn't match the event`),
+	})
+
+	// render failures are reported in the previews
+	ko.Spec.SampleEvent = aws.String(`not an event`)
+	setInputPreviews(ko)
+	assert.Assert(t, strings.HasPrefix(aws.ToString(ko.Status.InputPreviews["event"]), "error: "))
+
+	// previews are removed with the sample event
+	ko.Spec.SampleEvent = nil
+	setInputPreviews(ko)
+	assert.Assert(t, ko.Status.InputPreviews == nil)
+}
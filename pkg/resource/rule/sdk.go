@@ -64,6 +64,9 @@ func (rm *resourceManager) sdkFind(
 	defer func() {
 		exit(err)
 	}()
+	if r, err = identifiersFromARN(r); err != nil {
+		return nil, err
+	}
 	// If any required fields in the input shape are missing, AWS resource is
 	// not created yet. Return NotFound here to indicate to callers that the
 	// resource isn't yet created.
@@ -135,9 +138,19 @@ func (rm *resourceManager) sdkFind(
 	}
 
 	rm.setStatusDefaults(ko)
+	keepEventBusName(r.ko, ko)
 	if err := rm.setResourceAdditionalFields(ctx, ko); err != nil {
 		return nil, err
 	}
+	// the default target role of the namespace is compared with the targets
+	if _, err := rm.resolveTargetRole(ctx, r, ko); err != nil {
+		return nil, err
+	}
+	setInputPreviews(ko)
+	// drift is reported again by sdkUpdate as long as it persists
+	ko.Status.Drift = nil
+	ensureSyncConditions(&resource{ko})
+	observeRule(ko)
 
 	return &resource{ko}, nil
 }
@@ -181,9 +194,18 @@ func (rm *resourceManager) sdkCreate(
 	defer func() {
 		exit(err)
 	}()
+	defer func() {
+		rm.publishLifecycleEvent(ctx, lifecycleCreate, desired, created, err)
+	}()
+	if driftReportEnabled(desired) {
+		return nil, ackerr.NewTerminalError(errDriftReportCreate)
+	}
 	if err = validateRuleSpec(desired.ko.Spec); err != nil {
 		return nil, ackerr.NewTerminalError(err)
 	}
+	if err = rm.checkCrossAccountTargetRoles(ctx, desired); err != nil {
+		return desired, err
+	}
 
 	input, err := rm.newCreateRequestPayload(ctx, desired)
 	if err != nil {
@@ -196,6 +218,9 @@ func (rm *resourceManager) sdkCreate(
 	_ = resp
 	resp, err = rm.sdkapi.PutRule(ctx, input)
 	rm.metrics.RecordAPICall("CREATE", "PutRule", err)
+	// tags are applied with PutRule on create
+	setSyncCondition(desired, ConditionTypeAttributesSynced, err)
+	setSyncCondition(desired, ConditionTypeTagsSynced, err)
 	if err != nil {
 		return nil, err
 	}
@@ -212,15 +237,21 @@ func (rm *resourceManager) sdkCreate(
 	}
 
 	rm.setStatusDefaults(ko)
+	setInputPreviews(ko)
+	// reads cached for a rule previously created with the same ARN are stale
+	invalidateReads(&resource{ko})
 	if len(ko.Spec.Targets) > 0 {
-		if err = rm.syncTargets(
+		err = rm.syncTargets(
 			ctx,
-			ko.Spec.Name, ko.Spec.EventBusName,
+			ko.Namespace, ko.Status.TargetRoleARN, ko.Spec.Name, ko.Spec.EventBusName,
 			ko.Spec.Targets, nil,
-		); err != nil {
-			return nil, err
+		)
+		setSyncCondition(&resource{ko}, ConditionTypeTargetsSynced, err)
+		if err != nil {
+			return &resource{ko}, err
 		}
 	}
+	observeRuleSynced(&resource{ko}, true)
 
 	return &resource{ko}, nil
 }
@@ -285,24 +316,48 @@ func (rm *resourceManager) sdkUpdate(
 	defer func() {
 		exit(err)
 	}()
+	defer invalidateReads(latest)
+	// the sync conditions set on desired are kept when the update fails
+	defer func() {
+		if err != nil && updated == nil {
+			updated = desired
+		}
+	}()
+	defer func() {
+		if err == nil {
+			observeRuleSynced(updated, false)
+		}
+		rm.publishLifecycleEvent(ctx, lifecycleUpdate, desired, updated, err)
+	}()
+	if driftReportEnabled(desired) {
+		return reportDrift(desired, delta), nil
+	}
 	if err = validateRuleSpec(desired.ko.Spec); err != nil {
 		return nil, ackerr.NewTerminalError(err)
 	}
+	if err = rm.checkCrossAccountTargetRoles(ctx, desired); err != nil {
+		return desired, err
+	}
 	if delta.DifferentAt("Spec.Tags") {
-		if err = rm.syncTags(ctx, desired, latest); err != nil {
-			return nil, err
+		err = rm.syncTags(ctx, desired, latest)
+		setSyncCondition(desired, ConditionTypeTagsSynced, err)
+		if err != nil {
+			return desired, err
 		}
 	}
 	if delta.DifferentAt("Spec.Targets") {
-		if err = rm.syncTargets(
+		err = rm.syncTargets(
 			ctx,
-			desired.ko.Spec.Name, desired.ko.Spec.EventBusName,
+			desired.ko.Namespace, desired.ko.Status.TargetRoleARN, desired.ko.Spec.Name, desired.ko.Spec.EventBusName,
 			desired.ko.Spec.Targets, latest.ko.Spec.Targets,
-		); err != nil {
-			return nil, err
+		)
+		setSyncCondition(desired, ConditionTypeTargetsSynced, err)
+		if err != nil {
+			return desired, err
 		}
 	}
 	if !delta.DifferentExcept("Spec.Tags", "Spec.Targets") {
+		ensureSyncConditions(desired)
 		return desired, nil
 	}
 
@@ -317,6 +372,10 @@ func (rm *resourceManager) sdkUpdate(
 	_ = resp
 	resp, err = rm.sdkapi.PutRule(ctx, input)
 	rm.metrics.RecordAPICall("UPDATE", "PutRule", err)
+	setSyncCondition(desired, ConditionTypeAttributesSynced, err)
+	if err == nil {
+		ensureSyncConditions(desired)
+	}
 	if err != nil {
 		return nil, err
 	}
@@ -394,10 +453,15 @@ func (rm *resourceManager) sdkDelete(
 	defer func() {
 		exit(err)
 	}()
+	if driftReportEnabled(r) {
+		// the rule is left in place, like with the retain deletion policy
+		return nil, nil
+	}
+	defer invalidateReads(r)
 	if len(r.ko.Spec.Targets) > 0 {
 		if err = rm.syncTargets(
 			ctx,
-			r.ko.Spec.Name, r.ko.Spec.EventBusName,
+			r.ko.Namespace, nil, r.ko.Spec.Name, r.ko.Spec.EventBusName,
 			nil, r.ko.Spec.Targets,
 		); err != nil {
 			return nil, err
@@ -412,6 +476,7 @@ func (rm *resourceManager) sdkDelete(
 	_ = resp
 	resp, err = rm.sdkapi.DeleteRule(ctx, input)
 	rm.metrics.RecordAPICall("DELETE", "DeleteRule", err)
+	rm.publishLifecycleEvent(ctx, lifecycleDelete, r, nil, err)
 	return nil, err
 }
 
@@ -553,7 +618,8 @@ func (rm *resourceManager) terminalAWSError(err error) bool {
 	}
 }
 
-// sdkTargetsFromResourceTargets converts the given Kubernetes resource targets to AWS service targets
+// sdkTargetsFromResourceTargets converts the given Kubernetes resource targets
+// to AWS service targets
 func sdkTargetsFromResourceTargets(
 	targets []*svcapitypes.Target,
 ) ([]*svcsdktypes.Target, error) {
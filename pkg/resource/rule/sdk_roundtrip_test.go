@@ -0,0 +1,222 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package rule
+
+import (
+	"context"
+	"fmt"
+	"math/rand"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	svcsdk "github.com/aws/aws-sdk-go-v2/service/eventbridge"
+	"github.com/google/go-cmp/cmp"
+
+	svcapitypes "github.com/aws-controllers-k8s/eventbridge-controller/apis/v1alpha1"
+	"github.com/aws-controllers-k8s/eventbridge-controller/pkg/testutil"
+)
+
+func Test_targetsRoundTrip(t *testing.T) {
+	testutil.CheckProperty(t, func(rnd *rand.Rand) error {
+		var targets []*svcapitypes.Target
+		testutil.Fill(&targets, rnd)
+
+		sdkTargets, err := sdkTargetsFromResourceTargets(targets)
+		if err != nil {
+			return err
+		}
+		if diff := cmp.Diff(targets, resourceTargetsFromSDKTargets(sdkTargets)); diff != "" {
+			return fmt.Errorf("targets differ (-want +got):\n%s", diff)
+		}
+		return nil
+	})
+}
+
+func Test_resourceManager_specRoundTrip(t *testing.T) {
+	ctx := context.Background()
+	rm := newTestResourceManager(t, testutil.NewEventBridge())
+
+	testutil.CheckProperty(t, func(rnd *rand.Rand) error {
+		var spec svcapitypes.RuleSpec
+		testutil.Fill(&spec, rnd)
+		randomizeValidRuleSpec(&spec, rnd)
+
+		created, err := rm.sdkCreate(ctx, &resource{ko: &svcapitypes.Rule{Spec: spec}})
+		if err != nil {
+			return err
+		}
+		latest, err := rm.sdkFind(ctx, created)
+		if err != nil {
+			return err
+		}
+		if _, err := rm.sdkDelete(ctx, latest); err != nil {
+			return err
+		}
+
+		sortRuleSpec(&latest.ko.Spec)
+		if diff := cmp.Diff(spec, latest.ko.Spec); diff != "" {
+			return fmt.Errorf("spec differs (-want +got):\n%s", diff)
+		}
+		return nil
+	})
+}
+
+// randomizeValidRuleSpec replaces the randomly filled fields of spec the API
+// validates, and drops the references which are resolved by the runtime.
+func randomizeValidRuleSpec(spec *svcapitypes.RuleSpec, rnd *rand.Rand) {
+	spec.EventBusRef = nil
+	spec.RoleRef = nil
+	// scheduled rules are only supported on the default event bus
+	spec.EventBusName = aws.String("default")
+	spec.EventPattern = aws.String(fmt.Sprintf(`{"source":[%q]}`, testutil.RandomString(rnd, 8)))
+	spec.State = aws.String([]string{"ENABLED", "DISABLED"}[rnd.Intn(2)])
+	spec.SampleEvent = nil
+	for i, target := range spec.Targets {
+		target.ID = aws.String(fmt.Sprintf("target-%d", i))
+		randomizeValidTargetParameters(target, rnd)
+		if it := target.InputTransformer; it != nil {
+			// the template must use valid paths of the declared placeholders
+			var fields []string
+			for key := range it.InputPathsMap {
+				it.InputPathsMap[key] = aws.String("$.detail." + key)
+				fields = append(fields, fmt.Sprintf("%q: <%s>", key, key))
+			}
+			sort.Strings(fields)
+			it.InputTemplate = aws.String("{" + strings.Join(fields, ", ") + "}")
+		}
+	}
+	for i, tag := range spec.Tags {
+		tag.Key = aws.String(fmt.Sprintf("key-%d", i))
+	}
+	sortRuleSpec(spec)
+}
+
+// randomizeValidTargetParameters keeps one randomly filled parameter block of
+// target and sets an ARN of the kind of target supporting it
+func randomizeValidTargetParameters(target *svcapitypes.Target, rnd *rand.Rand) {
+	filled := *target
+	target.ECSParameters = nil
+	target.BatchParameters = nil
+	target.KinesisParameters = nil
+	target.SQSParameters = nil
+	target.RedshiftDataParameters = nil
+	target.SageMakerPipelineParameters = nil
+	target.RunCommandParameters = nil
+	target.HTTPParameters = nil
+
+	const prefix = "arn:aws:%s:us-west-2:123456789012:%s"
+	switch rnd.Intn(8) {
+	case 0:
+		target.ARN = aws.String(fmt.Sprintf(prefix, "ecs", "cluster/test"))
+		target.ECSParameters = filled.ECSParameters
+		target.ECSParameters.TaskCount = aws.Int64(minTaskCount + rnd.Int63n(maxTaskCount-minTaskCount+1))
+	case 1:
+		target.ARN = aws.String(fmt.Sprintf(prefix, "batch", "job-queue/test"))
+		target.BatchParameters = filled.BatchParameters
+		if p := target.BatchParameters.ArrayProperties; p != nil {
+			p.Size = aws.Int64(minArraySize + rnd.Int63n(maxArraySize-minArraySize+1))
+		}
+	case 2:
+		target.ARN = aws.String(fmt.Sprintf(prefix, "kinesis", "stream/test"))
+		target.KinesisParameters = filled.KinesisParameters
+	case 3:
+		target.ARN = aws.String(fmt.Sprintf(prefix, "sqs", "test.fifo"))
+		target.SQSParameters = filled.SQSParameters
+	case 4:
+		target.ARN = aws.String(fmt.Sprintf(prefix, "redshift", "cluster:test"))
+		target.RedshiftDataParameters = filled.RedshiftDataParameters
+	case 5:
+		target.ARN = aws.String(fmt.Sprintf(prefix, "sagemaker", "pipeline/test"))
+		target.SageMakerPipelineParameters = filled.SageMakerPipelineParameters
+	case 6:
+		target.ARN = aws.String(fmt.Sprintf(prefix, "ssm", "document/test"))
+		target.RunCommandParameters = filled.RunCommandParameters
+	default:
+		target.ARN = aws.String(fmt.Sprintf(prefix, "events", "api-destination/test/id"))
+		target.HTTPParameters = filled.HTTPParameters
+	}
+}
+
+// sortRuleSpec sorts the targets and tags of spec, the API doesn't guarantee
+// their order
+func sortRuleSpec(spec *svcapitypes.RuleSpec) {
+	sort.Slice(spec.Targets, func(i, j int) bool {
+		return *spec.Targets[i].ID < *spec.Targets[j].ID
+	})
+	sort.Slice(spec.Tags, func(i, j int) bool {
+		return *spec.Tags[i].Key < *spec.Tags[j].Key
+	})
+}
+
+func Test_resourceManager_eventBusRoundTrip(t *testing.T) {
+	ctx := context.Background()
+	const busARN = "arn:aws:events:us-west-2:123456789012:event-bus/orders"
+	forms := map[string]*string{"name": aws.String("orders"), "arn": aws.String(busARN)}
+
+	for createdWith, createBus := range forms {
+		for updatedWith, updateBus := range forms {
+			t.Run(createdWith+" to "+updatedWith, func(t *testing.T) {
+				fake := testutil.NewEventBridge()
+				rm := newTestResourceManager(t, fake)
+				client := svcsdk.NewFromConfig(fake.Config())
+				_, err := client.CreateEventBus(ctx, &svcsdk.CreateEventBusInput{Name: aws.String("orders")})
+				if err != nil {
+					t.Fatal(err)
+				}
+
+				desired := &resource{ko: &svcapitypes.Rule{Spec: svcapitypes.RuleSpec{
+					Name:         aws.String(ruleName),
+					EventBusName: createBus,
+					EventPattern: aws.String(`{"source":["test"]}`),
+					Targets:      []*svcapitypes.Target{newTestTarget("t1")},
+				}}}
+				created, err := rm.sdkCreate(ctx, desired)
+				if err != nil {
+					t.Fatal(err)
+				}
+				latest, err := rm.sdkFind(ctx, created)
+				if err != nil {
+					t.Fatal(err)
+				}
+				if got := aws.ToString(latest.ko.Spec.EventBusName); got != aws.ToString(createBus) {
+					t.Errorf("EventBusName = %q, want %q", got, aws.ToString(createBus))
+				}
+
+				desired = latest.DeepCopy().(*resource)
+				desired.ko.Spec.EventBusName = updateBus
+				desired.ko.Spec.Targets = []*svcapitypes.Target{newTestTarget("t2")}
+				delta := newResourceDelta(desired, latest)
+				if delta.DifferentAt("Spec.EventBusName") {
+					t.Errorf("unexpected delta at Spec.EventBusName: %v", delta.Differences)
+				}
+				if _, err := rm.sdkUpdate(ctx, desired, latest, delta); err != nil {
+					t.Fatal(err)
+				}
+
+				latest, err = rm.sdkFind(ctx, desired)
+				if err != nil {
+					t.Fatal(err)
+				}
+				if len(latest.ko.Spec.Targets) != 1 || aws.ToString(latest.ko.Spec.Targets[0].ID) != "t2" {
+					t.Errorf("targets were not updated: %v", latest.ko.Spec.Targets)
+				}
+				if _, err := rm.sdkDelete(ctx, latest); err != nil {
+					t.Fatal(err)
+				}
+			})
+		}
+	}
+}
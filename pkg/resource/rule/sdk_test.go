@@ -0,0 +1,431 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package rule
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	ackv1alpha1 "github.com/aws-controllers-k8s/runtime/apis/core/v1alpha1"
+	ackcondition "github.com/aws-controllers-k8s/runtime/pkg/condition"
+	ackcfg "github.com/aws-controllers-k8s/runtime/pkg/config"
+	ackerr "github.com/aws-controllers-k8s/runtime/pkg/errors"
+	ackmetrics "github.com/aws-controllers-k8s/runtime/pkg/metrics"
+	"github.com/aws/aws-sdk-go-v2/aws"
+	svcsdk "github.com/aws/aws-sdk-go-v2/service/eventbridge"
+	svcsdktypes "github.com/aws/aws-sdk-go-v2/service/eventbridge/types"
+	"github.com/go-logr/logr"
+	"gotest.tools/v3/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+
+	svcapitypes "github.com/aws-controllers-k8s/eventbridge-controller/apis/v1alpha1"
+	"github.com/aws-controllers-k8s/eventbridge-controller/pkg/events"
+	"github.com/aws-controllers-k8s/eventbridge-controller/pkg/testutil"
+)
+
+func newTestResourceManager(t *testing.T, fake *testutil.EventBridge) *resourceManager {
+	t.Helper()
+	rm, err := newResourceManager(
+		ackcfg.Config{}, fake.Config(), logr.Discard(),
+		ackmetrics.NewMetrics("eventbridge"), nil,
+		testutil.DefaultAccountID, testutil.DefaultRegion,
+	)
+	assert.NilError(t, err)
+	// the fake reuses the ARNs of other tests
+	tagsCache.Reset()
+	targetsCache.Reset()
+	return rm
+}
+
+func assertCondition(t *testing.T, r *resource, condType ackv1alpha1.ConditionType, want corev1.ConditionStatus) {
+	t.Helper()
+	c := ackcondition.FirstOfType(r, condType)
+	assert.Assert(t, c != nil, "missing condition %s", condType)
+	assert.Equal(t, c.Status, want, "condition %s", condType)
+}
+
+func newTestTarget(id string) *svcapitypes.Target {
+	return &svcapitypes.Target{
+		ID:  aws.String(id),
+		ARN: aws.String("arn:aws:sqs:us-west-2:123456789012:" + id),
+	}
+}
+
+func Test_resourceManager_lifecycle(t *testing.T) {
+	ctx := context.Background()
+	fake := testutil.NewEventBridge()
+	rm := newTestResourceManager(t, fake)
+
+	desired := &resource{ko: &svcapitypes.Rule{
+		Spec: svcapitypes.RuleSpec{
+			Name:         aws.String(ruleName),
+			EventPattern: aws.String(`{"source":["test"]}`),
+			Tags: []*svcapitypes.Tag{
+				{Key: aws.String("team"), Value: aws.String("a")},
+			},
+			Targets: []*svcapitypes.Target{newTestTarget("t1")},
+		},
+	}}
+
+	_, err := rm.sdkFind(ctx, desired)
+	assert.Equal(t, err, ackerr.NotFound)
+
+	created, err := rm.sdkCreate(ctx, desired)
+	assert.NilError(t, err)
+	assert.Equal(t,
+		string(*created.ko.Status.ACKResourceMetadata.ARN),
+		"arn:aws:events:us-west-2:123456789012:rule/"+ruleName,
+	)
+	assertCondition(t, created, ConditionTypeTargetsSynced, corev1.ConditionTrue)
+
+	latest, err := rm.sdkFind(ctx, created)
+	assert.NilError(t, err)
+	assert.Equal(t, aws.ToString(latest.ko.Spec.EventBusName), "default")
+	assert.Equal(t, aws.ToString(latest.ko.Spec.State), "ENABLED")
+	assert.Equal(t, len(latest.ko.Spec.Tags), 1)
+	assert.Equal(t, len(latest.ko.Spec.Targets), 1)
+	for _, condType := range syncConditionTypes {
+		assertCondition(t, latest, condType, corev1.ConditionTrue)
+	}
+
+	desired = latest.DeepCopy().(*resource)
+	desired.ko.Status.Conditions = nil
+	desired.ko.Spec.EventPattern = aws.String(`{"source":["updated"]}`)
+	desired.ko.Spec.Tags = []*svcapitypes.Tag{
+		{Key: aws.String("team"), Value: aws.String("b")},
+	}
+	desired.ko.Spec.Targets = []*svcapitypes.Target{newTestTarget("t2")}
+	delta := newResourceDelta(desired, latest)
+	assert.Assert(t, delta.DifferentAt("Spec.EventPattern"))
+
+	_, err = rm.sdkUpdate(ctx, desired, latest, delta)
+	assert.NilError(t, err)
+
+	latest, err = rm.sdkFind(ctx, desired)
+	assert.NilError(t, err)
+	assert.Equal(t, aws.ToString(latest.ko.Spec.EventPattern), `{"source":["updated"]}`)
+	assert.Equal(t, aws.ToString(latest.ko.Spec.Tags[0].Value), "b")
+	assert.Equal(t, len(latest.ko.Spec.Targets), 1)
+	assert.Equal(t, aws.ToString(latest.ko.Spec.Targets[0].ID), "t2")
+
+	_, err = rm.sdkDelete(ctx, latest)
+	assert.NilError(t, err)
+	_, err = rm.sdkFind(ctx, latest)
+	assert.Equal(t, err, ackerr.NotFound)
+}
+
+func Test_resourceManager_readCache(t *testing.T) {
+	ctx := context.Background()
+	fake := testutil.NewEventBridge()
+	rm := newTestResourceManager(t, fake)
+
+	desired := &resource{ko: &svcapitypes.Rule{
+		Spec: svcapitypes.RuleSpec{
+			Name:         aws.String(ruleName),
+			EventPattern: aws.String(`{"source":["test"]}`),
+			Tags:         []*svcapitypes.Tag{{Key: aws.String("team"), Value: aws.String("a")}},
+			Targets:      []*svcapitypes.Target{newTestTarget("t1")},
+		},
+	}}
+	created, err := rm.sdkCreate(ctx, desired)
+	assert.NilError(t, err)
+
+	fake.ResetCalls()
+	latest, err := rm.sdkFind(ctx, created)
+	assert.NilError(t, err)
+	assert.DeepEqual(t, fake.Calls(), []string{"DescribeRule", "ListTagsForResource", "ListTargetsByRule"})
+
+	fake.ResetCalls()
+	cached, err := rm.sdkFind(ctx, created)
+	assert.NilError(t, err)
+	assert.DeepEqual(t, fake.Calls(), []string{"DescribeRule"})
+	assert.DeepEqual(t, cached.ko.Spec.Tags, latest.ko.Spec.Tags)
+	assert.DeepEqual(t, cached.ko.Spec.Targets, latest.ko.Spec.Targets)
+
+	// a new generation is read again
+	desired = latest.DeepCopy().(*resource)
+	desired.ko.Generation++
+	fake.ResetCalls()
+	_, err = rm.sdkFind(ctx, desired)
+	assert.NilError(t, err)
+	assert.DeepEqual(t, fake.Calls(), []string{"DescribeRule", "ListTagsForResource", "ListTargetsByRule"})
+
+	// writes invalidate the cached reads
+	desired.ko.Spec.Tags = []*svcapitypes.Tag{{Key: aws.String("team"), Value: aws.String("b")}}
+	_, err = rm.sdkUpdate(ctx, desired, latest, newResourceDelta(desired, latest))
+	assert.NilError(t, err)
+	latest, err = rm.sdkFind(ctx, desired)
+	assert.NilError(t, err)
+	assert.Equal(t, aws.ToString(latest.ko.Spec.Tags[0].Value), "b")
+}
+
+func Test_resourceManager_syncConditions(t *testing.T) {
+	ctx := context.Background()
+	fake := testutil.NewEventBridge()
+	rm := newTestResourceManager(t, fake)
+
+	desired := &resource{ko: &svcapitypes.Rule{
+		Spec: svcapitypes.RuleSpec{
+			Name:         aws.String(ruleName),
+			EventPattern: aws.String(`{"source":["test"]}`),
+			Targets:      []*svcapitypes.Target{newTestTarget("t1")},
+		},
+	}}
+	created, err := rm.sdkCreate(ctx, desired)
+	assert.NilError(t, err)
+	latest, err := rm.sdkFind(ctx, created)
+	assert.NilError(t, err)
+
+	t.Run("failed target entry", func(t *testing.T) {
+		fake.FailTarget("t2", "AccessDeniedException", "denied")
+		defer fake.ClearTargetFailures()
+
+		desired := latest.DeepCopy().(*resource)
+		desired.ko.Status.Conditions = nil
+		desired.ko.Spec.Targets = append(desired.ko.Spec.Targets, newTestTarget("t2"))
+
+		updated, err := rm.sdkUpdate(ctx, desired, latest, newResourceDelta(desired, latest))
+		assert.ErrorContains(t, err, "t2: denied (AccessDeniedException)")
+		assertCondition(t, updated, ConditionTypeTargetsSynced, corev1.ConditionFalse)
+	})
+
+	t.Run("PutRule error", func(t *testing.T) {
+		fake.FailNext("PutRule", "ThrottlingException", "Rate exceeded")
+
+		desired := latest.DeepCopy().(*resource)
+		desired.ko.Status.Conditions = nil
+		desired.ko.Spec.Description = aws.String("updated")
+
+		updated, err := rm.sdkUpdate(ctx, desired, latest, newResourceDelta(desired, latest))
+		assert.ErrorContains(t, err, "Rate exceeded")
+		assertCondition(t, updated, ConditionTypeAttributesSynced, corev1.ConditionFalse)
+	})
+}
+
+func Test_resourceManager_observedGeneration(t *testing.T) {
+	ctx := context.Background()
+	fake := testutil.NewEventBridge()
+	rm := newTestResourceManager(t, fake)
+
+	desired := &resource{ko: &svcapitypes.Rule{
+		ObjectMeta: metav1.ObjectMeta{Generation: 1},
+		Spec: svcapitypes.RuleSpec{
+			Name:         aws.String(ruleName),
+			EventPattern: aws.String(`{"source":["test"]}`),
+		},
+	}}
+	created, err := rm.sdkCreate(ctx, desired)
+	assert.NilError(t, err)
+	assert.Equal(t, aws.ToInt64(created.ko.Status.ObservedGeneration), int64(1))
+
+	latest, err := rm.sdkFind(ctx, created)
+	assert.NilError(t, err)
+	assert.Equal(t, aws.ToInt64(latest.ko.Status.ObservedGeneration), int64(1))
+
+	t.Run("failed update", func(t *testing.T) {
+		fake.FailNext("PutRule", "ThrottlingException", "Rate exceeded")
+
+		desired := latest.DeepCopy().(*resource)
+		desired.ko.Generation = 2
+		desired.ko.Spec.Description = aws.String("updated")
+
+		updated, err := rm.sdkUpdate(ctx, desired, latest, newResourceDelta(desired, latest))
+		assert.ErrorContains(t, err, "Rate exceeded")
+		assert.Equal(t, aws.ToInt64(updated.ko.Status.ObservedGeneration), int64(1))
+	})
+
+	t.Run("update", func(t *testing.T) {
+		desired := latest.DeepCopy().(*resource)
+		desired.ko.Generation = 2
+		desired.ko.Spec.Description = aws.String("updated")
+
+		updated, err := rm.sdkUpdate(ctx, desired, latest, newResourceDelta(desired, latest))
+		assert.NilError(t, err)
+		assert.Equal(t, aws.ToInt64(updated.ko.Status.ObservedGeneration), int64(2))
+	})
+}
+
+func Test_resourceManager_targetDefaults(t *testing.T) {
+	defer func(d targetPolicyDefaults) { targetDefaults = d }(targetDefaults)
+	targetDefaults = targetPolicyDefaults{
+		maximumRetryAttempts:     3,
+		maximumEventAgeInSeconds: 3600,
+		deadLetterARN:            "arn:aws:sqs:us-west-2:123456789012:" + namespacePlaceholder + "-dlq",
+	}
+
+	ctx := context.Background()
+	rm := newTestResourceManager(t, testutil.NewEventBridge())
+
+	desired := &resource{ko: &svcapitypes.Rule{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "orders"},
+		Spec: svcapitypes.RuleSpec{
+			Name:         aws.String(ruleName),
+			EventPattern: aws.String(`{"source":["test"]}`),
+			Targets:      []*svcapitypes.Target{newTestTarget("t1")},
+		},
+	}}
+	created, err := rm.sdkCreate(ctx, desired)
+	assert.NilError(t, err)
+	latest, err := rm.sdkFind(ctx, created)
+	assert.NilError(t, err)
+
+	target := latest.ko.Spec.Targets[0]
+	assert.Equal(t, aws.ToInt64(target.RetryPolicy.MaximumRetryAttempts), int64(3))
+	assert.Equal(t, aws.ToInt64(target.RetryPolicy.MaximumEventAgeInSeconds), int64(3600))
+	assert.Equal(t, aws.ToString(target.DeadLetterConfig.ARN), "arn:aws:sqs:us-west-2:123456789012:orders-dlq")
+
+	// the defaults don't show as drift of the desired targets
+	delta := newResourceDelta(desired, latest)
+	assert.Assert(t, !delta.DifferentAt("Spec.Targets"))
+}
+
+func Test_resourceManager_driftReport(t *testing.T) {
+	ctx := context.Background()
+	fake := testutil.NewEventBridge()
+	rm := newTestResourceManager(t, fake)
+	recorder := record.NewFakeRecorder(10)
+	events.SetRecorder(recorder)
+	defer events.SetRecorder(nil)
+
+	desired := &resource{ko: &svcapitypes.Rule{
+		ObjectMeta: metav1.ObjectMeta{
+			Annotations: map[string]string{AnnotationDriftMode: DriftModeReport},
+		},
+		Spec: svcapitypes.RuleSpec{
+			Name:         aws.String(ruleName),
+			EventPattern: aws.String(`{"source":["test"]}`),
+			State:        aws.String("ENABLED"),
+			Targets:      []*svcapitypes.Target{newTestTarget("t1")},
+		},
+	}}
+
+	t.Run("rule is not created", func(t *testing.T) {
+		_, err := rm.sdkCreate(ctx, desired)
+		assert.ErrorContains(t, err, errDriftReportCreate.Error())
+		assert.Equal(t, len(fake.Calls()), 0)
+	})
+
+	// the rule was created and modified out-of-band, e.g. in the console
+	client := svcsdk.NewFromConfig(fake.Config())
+	_, err := client.PutRule(ctx, &svcsdk.PutRuleInput{
+		Name:         aws.String(ruleName),
+		EventPattern: aws.String(`{"source":["console"]}`),
+	})
+	assert.NilError(t, err)
+
+	latest, err := rm.sdkFind(ctx, desired)
+	assert.NilError(t, err)
+	assert.Assert(t, latest.ko.Status.Drift == nil)
+
+	fake.ResetCalls()
+	updated, err := rm.sdkUpdate(ctx, desired, latest, newResourceDelta(desired, latest))
+	assert.NilError(t, err)
+	assert.Equal(t, len(fake.Calls()), 0, "no API calls in drift report mode")
+
+	drift := aws.ToStringSlice(updated.ko.Status.Drift)
+	assert.DeepEqual(t, drift, []string{
+		`Spec.Targets: desired=[{"arn":"arn:aws:sqs:us-west-2:123456789012:t1","id":"t1"}], actual=null`,
+		`Spec.EventPattern: desired="{\"source\":[\"test\"]}", actual="{\"source\":[\"console\"]}"`,
+	})
+	assertCondition(t, updated, ConditionTypeAttributesSynced, corev1.ConditionFalse)
+	assertCondition(t, updated, ConditionTypeTargetsSynced, corev1.ConditionFalse)
+	assertCondition(t, updated, ConditionTypeTagsSynced, corev1.ConditionTrue)
+	assertCondition(t, updated, ackv1alpha1.ConditionTypeResourceSynced, corev1.ConditionFalse)
+
+	event := <-recorder.Events
+	assert.Assert(t, strings.HasPrefix(event, "Warning DriftDetected drift detected"), event)
+	assert.Assert(t, strings.Contains(event, "Spec.Targets"), event)
+
+	t.Run("rule is not deleted", func(t *testing.T) {
+		fake.ResetCalls()
+		_, err := rm.sdkDelete(ctx, latest)
+		assert.NilError(t, err)
+		assert.Equal(t, len(fake.Calls()), 0)
+		_, err = rm.sdkFind(ctx, desired)
+		assert.NilError(t, err)
+	})
+}
+
+func Test_resourceManager_adoptByARN(t *testing.T) {
+	ctx := context.Background()
+	// the event bus and the rule are in another account than the controller
+	fake := testutil.NewEventBridge(testutil.WithAccountID("444455556666"))
+	rm := newTestResourceManager(t, fake)
+
+	client := svcsdk.NewFromConfig(fake.Config())
+	_, err := client.CreateEventBus(ctx, &svcsdk.CreateEventBusInput{Name: aws.String("orders")})
+	assert.NilError(t, err)
+	_, err = client.PutRule(ctx, &svcsdk.PutRuleInput{
+		Name:         aws.String(ruleName),
+		EventBusName: aws.String("orders"),
+		EventPattern: aws.String(`{"source":["test"]}`),
+	})
+	assert.NilError(t, err)
+	_, err = client.PutTargets(ctx, &svcsdk.PutTargetsInput{
+		Rule:         aws.String(ruleName),
+		EventBusName: aws.String("orders"),
+		Targets: []svcsdktypes.Target{
+			{Id: aws.String("t1"), Arn: aws.String("arn:aws:sqs:us-west-2:444455556666:t1")},
+		},
+	})
+	assert.NilError(t, err)
+
+	const busARN = "arn:aws:events:us-west-2:444455556666:event-bus/orders"
+	desired := &resource{ko: &svcapitypes.Rule{}}
+	ruleARN := ackv1alpha1.AWSResourceName("arn:aws:events:us-west-2:444455556666:rule/orders/" + ruleName)
+	assert.NilError(t, desired.SetIdentifiers(&ackv1alpha1.AWSIdentifiers{NameOrID: string(ruleARN)}))
+
+	latest, err := rm.sdkFind(ctx, desired)
+	assert.NilError(t, err)
+	assert.Equal(t, aws.ToString(latest.ko.Spec.Name), ruleName)
+	assert.Equal(t, aws.ToString(latest.ko.Spec.EventBusName), busARN)
+	assert.Equal(t, *latest.ko.Status.ACKResourceMetadata.ARN, ruleARN)
+	assert.Equal(t, len(latest.ko.Spec.Targets), 1)
+
+	// the event bus is kept by ARN across reconciliations
+	desired = latest.DeepCopy().(*resource)
+	latest, err = rm.sdkFind(ctx, desired)
+	assert.NilError(t, err)
+	assert.Equal(t, aws.ToString(latest.ko.Spec.EventBusName), busARN)
+	assert.Assert(t, !newResourceDelta(desired, latest).DifferentAt("Spec.EventBusName"))
+
+	// a rule of the same name on an event bus of the same name in another
+	// account is a different rule
+	desired = &resource{ko: &svcapitypes.Rule{}}
+	ruleARN = ackv1alpha1.AWSResourceName("arn:aws:events:us-west-2:111122223333:rule/orders/" + ruleName)
+	assert.NilError(t, desired.SetIdentifiers(&ackv1alpha1.AWSIdentifiers{NameOrID: string(ruleARN)}))
+	_, err = rm.sdkFind(ctx, desired)
+	assert.Equal(t, err, ackerr.NotFound)
+}
+
+func Test_resourceManager_ARNFromName(t *testing.T) {
+	rm := newTestResourceManager(t, testutil.NewEventBridge())
+	rm.awsPartition = "aws"
+	tests := []struct {
+		name string
+		in   string
+		want string
+	}{
+		{name: "rule on the default event bus", in: "order-created", want: "arn:aws:events:us-west-2:123456789012:rule/order-created"},
+		{name: "rule on a custom event bus", in: "orders/order-created", want: "arn:aws:events:us-west-2:123456789012:rule/orders/order-created"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, rm.ARNFromName(tt.in), tt.want)
+		})
+	}
+}
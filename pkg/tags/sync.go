@@ -0,0 +1,208 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package tags
+
+import (
+	"context"
+	"strings"
+
+	ackcompare "github.com/aws-controllers-k8s/runtime/pkg/compare"
+	"github.com/aws-controllers-k8s/runtime/pkg/runtime/log"
+	"github.com/aws/aws-sdk-go-v2/aws"
+	svcsdk "github.com/aws/aws-sdk-go-v2/service/eventbridge"
+	svcsdktypes "github.com/aws/aws-sdk-go-v2/service/eventbridge/types"
+
+	svcapitypes "github.com/aws-controllers-k8s/eventbridge-controller/apis/v1alpha1"
+)
+
+const (
+	// systemTagPrefix prefixes the keys of the tags managed by AWS, which
+	// can't be changed
+	systemTagPrefix = "aws:"
+	// maxTagsPerCall is the maximum number of tags of a TagResource or
+	// UntagResource call
+	maxTagsPerCall = 50
+)
+
+// API is the EventBridge API used to manage the tags of a resource
+type API interface {
+	ListTagsForResource(
+		ctx context.Context,
+		input *svcsdk.ListTagsForResourceInput,
+		opts ...func(*svcsdk.Options),
+	) (*svcsdk.ListTagsForResourceOutput, error)
+	TagResource(
+		ctx context.Context,
+		input *svcsdk.TagResourceInput,
+		opts ...func(*svcsdk.Options),
+	) (*svcsdk.TagResourceOutput, error)
+	UntagResource(
+		ctx context.Context,
+		input *svcsdk.UntagResourceInput,
+		opts ...func(*svcsdk.Options),
+	) (*svcsdk.UntagResourceOutput, error)
+}
+
+// MetricsRecorder records the API calls of a resource manager, e.g.
+// *ackmetrics.Metrics
+type MetricsRecorder interface {
+	RecordAPICall(opType string, opID string, err error)
+}
+
+// Manager reads and synchronizes the tags of EventBridge resources by ARN
+type Manager struct {
+	client  API
+	metrics MetricsRecorder
+}
+
+// NewManager returns a Manager calling the client and recording its calls
+// in the metrics
+func NewManager(client API, metrics MetricsRecorder) *Manager {
+	return &Manager{
+		client:  client,
+		metrics: metrics,
+	}
+}
+
+// IsSystemTag returns true if the tag key is reserved for AWS
+func IsSystemTag(key *string) bool {
+	return strings.HasPrefix(aws.ToString(key), systemTagPrefix)
+}
+
+// Get retrieves the tags of the resource, without the tags managed by AWS
+func (m *Manager) Get(ctx context.Context, resourceARN string) (tags []*svcapitypes.Tag, err error) {
+	rlog := log.FromContext(ctx)
+	exit := rlog.Trace("tags.Get")
+	defer func() { exit(err) }()
+
+	var resp *svcsdk.ListTagsForResourceOutput
+	resp, err = m.client.ListTagsForResource(ctx, &svcsdk.ListTagsForResourceInput{
+		ResourceARN: aws.String(resourceARN),
+	})
+	m.metrics.RecordAPICall("GET", "ListTagsForResource", err)
+	if err != nil {
+		return nil, err
+	}
+	for _, tag := range resp.Tags {
+		if IsSystemTag(tag.Key) {
+			continue
+		}
+		tags = append(tags, &svcapitypes.Tag{
+			Key:   tag.Key,
+			Value: tag.Value,
+		})
+	}
+	return tags, nil
+}
+
+// Sync updates the latest tags of the resource to the desired tags, in
+// batches. Tags managed by AWS are left unchanged.
+func (m *Manager) Sync(
+	ctx context.Context,
+	resourceARN string,
+	desired []*svcapitypes.Tag,
+	latest []*svcapitypes.Tag,
+) (err error) {
+	rlog := log.FromContext(ctx)
+	exit := rlog.Trace("tags.Sync")
+	defer func() { exit(err) }()
+
+	missing, extra := ComputeTagsDelta(WithoutSystemTags(desired), WithoutSystemTags(latest))
+
+	for _, batch := range batches(extra) {
+		_, err = m.client.UntagResource(ctx, &svcsdk.UntagResourceInput{
+			ResourceARN: aws.String(resourceARN),
+			TagKeys:     sdkTagKeys(batch),
+		})
+		m.metrics.RecordAPICall("UPDATE", "UntagResource", err)
+		if err != nil {
+			return err
+		}
+	}
+
+	for _, batch := range batches(missing) {
+		_, err = m.client.TagResource(ctx, &svcsdk.TagResourceInput{
+			ResourceARN: aws.String(resourceARN),
+			Tags:        sdkTags(batch),
+		})
+		m.metrics.RecordAPICall("UPDATE", "TagResource", err)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Compare adds the tags to the delta unless they are equal regardless of
+// their order
+func Compare(delta *ackcompare.Delta, desired, latest []*svcapitypes.Tag) {
+	if len(desired) != len(latest) || !EqualTags(desired, latest) {
+		delta.Add("Spec.Tags", desired, latest)
+	}
+}
+
+// Copy returns a deep copy of the tags
+func Copy(tags []*svcapitypes.Tag) []*svcapitypes.Tag {
+	if tags == nil {
+		return nil
+	}
+	out := make([]*svcapitypes.Tag, len(tags))
+	for i, t := range tags {
+		out[i] = t.DeepCopy()
+	}
+	return out
+}
+
+// sdkTags transforms a *svcapitypes.Tag array to a svcsdktypes.Tag array
+func sdkTags(tags []*svcapitypes.Tag) []svcsdktypes.Tag {
+	out := make([]svcsdktypes.Tag, len(tags))
+	for i, t := range tags {
+		out[i] = svcsdktypes.Tag{
+			Key:   t.Key,
+			Value: t.Value,
+		}
+	}
+	return out
+}
+
+// sdkTagKeys returns the keys of the tags
+func sdkTagKeys(tags []*svcapitypes.Tag) []string {
+	keys := make([]string, len(tags))
+	for i, t := range tags {
+		keys[i] = aws.ToString(t.Key)
+	}
+	return keys
+}
+
+// WithoutSystemTags returns the tags without the tags reserved for AWS
+func WithoutSystemTags(tags []*svcapitypes.Tag) []*svcapitypes.Tag {
+	var out []*svcapitypes.Tag
+	for _, t := range tags {
+		if !IsSystemTag(t.Key) {
+			out = append(out, t)
+		}
+	}
+	return out
+}
+
+// batches splits the tags into batches of at most maxTagsPerCall tags
+func batches(tags []*svcapitypes.Tag) [][]*svcapitypes.Tag {
+	var out [][]*svcapitypes.Tag
+	for len(tags) > 0 {
+		n := min(len(tags), maxTagsPerCall)
+		out = append(out, tags[:n])
+		tags = tags[n:]
+	}
+	return out
+}
@@ -0,0 +1,249 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package tags
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"testing"
+
+	ackcompare "github.com/aws-controllers-k8s/runtime/pkg/compare"
+	"github.com/aws/aws-sdk-go-v2/aws"
+	svcsdk "github.com/aws/aws-sdk-go-v2/service/eventbridge"
+	svcsdktypes "github.com/aws/aws-sdk-go-v2/service/eventbridge/types"
+	"gotest.tools/v3/assert"
+
+	svcapitypes "github.com/aws-controllers-k8s/eventbridge-controller/apis/v1alpha1"
+)
+
+const testARN = "arn:aws:events:us-west-2:123456789012:rule/test"
+
+// fakeAPI is a tagging API storing the tags of a single resource
+type fakeAPI struct {
+	tags  map[string]string
+	calls []string
+	err   error
+}
+
+func (f *fakeAPI) ListTagsForResource(
+	_ context.Context,
+	_ *svcsdk.ListTagsForResourceInput,
+	_ ...func(*svcsdk.Options),
+) (*svcsdk.ListTagsForResourceOutput, error) {
+	f.calls = append(f.calls, "ListTagsForResource")
+	if f.err != nil {
+		return nil, f.err
+	}
+	keys := make([]string, 0, len(f.tags))
+	for k := range f.tags {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	out := &svcsdk.ListTagsForResourceOutput{}
+	for _, k := range keys {
+		out.Tags = append(out.Tags, svcsdktypes.Tag{Key: aws.String(k), Value: aws.String(f.tags[k])})
+	}
+	return out, nil
+}
+
+func (f *fakeAPI) TagResource(
+	_ context.Context,
+	input *svcsdk.TagResourceInput,
+	_ ...func(*svcsdk.Options),
+) (*svcsdk.TagResourceOutput, error) {
+	f.calls = append(f.calls, fmt.Sprintf("TagResource(%d)", len(input.Tags)))
+	if f.err != nil {
+		return nil, f.err
+	}
+	for _, t := range input.Tags {
+		f.tags[aws.ToString(t.Key)] = aws.ToString(t.Value)
+	}
+	return &svcsdk.TagResourceOutput{}, nil
+}
+
+func (f *fakeAPI) UntagResource(
+	_ context.Context,
+	input *svcsdk.UntagResourceInput,
+	_ ...func(*svcsdk.Options),
+) (*svcsdk.UntagResourceOutput, error) {
+	f.calls = append(f.calls, fmt.Sprintf("UntagResource(%d)", len(input.TagKeys)))
+	if f.err != nil {
+		return nil, f.err
+	}
+	for _, k := range input.TagKeys {
+		delete(f.tags, k)
+	}
+	return &svcsdk.UntagResourceOutput{}, nil
+}
+
+type fakeMetrics struct {
+	calls []string
+}
+
+func (m *fakeMetrics) RecordAPICall(opType string, opID string, err error) {
+	m.calls = append(m.calls, opType+" "+opID)
+}
+
+func tag(k, v string) *svcapitypes.Tag {
+	return &svcapitypes.Tag{Key: aws.String(k), Value: aws.String(v)}
+}
+
+func manyTags(n int) []*svcapitypes.Tag {
+	tags := make([]*svcapitypes.Tag, n)
+	for i := range tags {
+		tags[i] = tag(fmt.Sprintf("k%02d", i), "v")
+	}
+	return tags
+}
+
+func TestManager_Get(t *testing.T) {
+	tests := []struct {
+		name    string
+		tags    map[string]string
+		err     error
+		want    []*svcapitypes.Tag
+		wantErr string
+	}{
+		{
+			name: "no tags",
+			tags: map[string]string{},
+		},
+		{
+			name: "system tags are filtered",
+			tags: map[string]string{"team": "a", "aws:cloudformation:stack-name": "stack"},
+			want: []*svcapitypes.Tag{tag("team", "a")},
+		},
+		{
+			name:    "error",
+			err:     errors.New("boom"),
+			wantErr: "boom",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			api := &fakeAPI{tags: tt.tags, err: tt.err}
+			metrics := &fakeMetrics{}
+			got, err := NewManager(api, metrics).Get(context.Background(), testARN)
+			assert.DeepEqual(t, metrics.calls, []string{"GET ListTagsForResource"})
+			if tt.wantErr != "" {
+				assert.ErrorContains(t, err, tt.wantErr)
+				return
+			}
+			assert.NilError(t, err)
+			assert.DeepEqual(t, got, tt.want)
+		})
+	}
+}
+
+func TestManager_Sync(t *testing.T) {
+	tests := []struct {
+		name      string
+		tags      map[string]string
+		desired   []*svcapitypes.Tag
+		latest    []*svcapitypes.Tag
+		err       error
+		wantCalls []string
+		wantTags  map[string]string
+		wantErr   string
+	}{
+		{
+			name:     "in sync",
+			tags:     map[string]string{"team": "a"},
+			desired:  []*svcapitypes.Tag{tag("team", "a")},
+			latest:   []*svcapitypes.Tag{tag("team", "a")},
+			wantTags: map[string]string{"team": "a"},
+		},
+		{
+			name:      "tags are added, updated and removed",
+			tags:      map[string]string{"team": "a", "env": "dev"},
+			desired:   []*svcapitypes.Tag{tag("team", "b"), tag("owner", "me")},
+			latest:    []*svcapitypes.Tag{tag("team", "a"), tag("env", "dev")},
+			wantCalls: []string{"UntagResource(1)", "TagResource(2)"},
+			wantTags:  map[string]string{"team": "b", "owner": "me"},
+		},
+		{
+			name:      "system tags are not removed",
+			tags:      map[string]string{"aws:cloudformation:stack-name": "stack"},
+			desired:   []*svcapitypes.Tag{tag("team", "a")},
+			latest:    []*svcapitypes.Tag{tag("aws:cloudformation:stack-name", "stack")},
+			wantCalls: []string{"TagResource(1)"},
+			wantTags:  map[string]string{"aws:cloudformation:stack-name": "stack", "team": "a"},
+		},
+		{
+			name:      "tags are batched",
+			tags:      map[string]string{},
+			desired:   manyTags(maxTagsPerCall + 1),
+			wantCalls: []string{"TagResource(50)", "TagResource(1)"},
+		},
+		{
+			name:      "error",
+			tags:      map[string]string{"team": "a"},
+			latest:    []*svcapitypes.Tag{tag("team", "a")},
+			err:       errors.New("boom"),
+			wantCalls: []string{"UntagResource(1)"},
+			wantErr:   "boom",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			api := &fakeAPI{tags: tt.tags, err: tt.err}
+			err := NewManager(api, &fakeMetrics{}).Sync(context.Background(), testARN, tt.desired, tt.latest)
+			assert.DeepEqual(t, api.calls, tt.wantCalls)
+			if tt.wantErr != "" {
+				assert.ErrorContains(t, err, tt.wantErr)
+				return
+			}
+			assert.NilError(t, err)
+			if tt.wantTags != nil {
+				assert.DeepEqual(t, api.tags, tt.wantTags)
+			}
+		})
+	}
+}
+
+func TestCompare(t *testing.T) {
+	tests := []struct {
+		name    string
+		desired []*svcapitypes.Tag
+		latest  []*svcapitypes.Tag
+		want    int
+	}{
+		{
+			name:    "equal regardless of order",
+			desired: []*svcapitypes.Tag{tag("a", "1"), tag("b", "2")},
+			latest:  []*svcapitypes.Tag{tag("b", "2"), tag("a", "1")},
+		},
+		{
+			name:    "different length",
+			desired: []*svcapitypes.Tag{tag("a", "1"), tag("b", "2")},
+			latest:  []*svcapitypes.Tag{tag("a", "1")},
+			want:    1,
+		},
+		{
+			name:    "different value",
+			desired: []*svcapitypes.Tag{tag("a", "1")},
+			latest:  []*svcapitypes.Tag{tag("a", "2")},
+			want:    1,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			delta := ackcompare.NewDelta()
+			Compare(delta, tt.desired, tt.latest)
+			assert.Equal(t, len(delta.Differences), tt.want)
+		})
+	}
+}
@@ -0,0 +1,212 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package testutil
+
+import (
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	svcsdk "github.com/aws/aws-sdk-go-v2/service/eventbridge"
+	svcsdktypes "github.com/aws/aws-sdk-go-v2/service/eventbridge/types"
+)
+
+type archive struct {
+	name           string
+	arn            string
+	eventSourceArn *string
+	description    *string
+	eventPattern   *string
+	retentionDays  *int32
+	state          svcsdktypes.ArchiveState
+	stateReason    *string
+	created        time.Time
+	transition     *transition
+}
+
+// archiveOutput is the wire representation of an archive, shared by the
+// DescribeArchive and ListArchives responses
+type archiveOutput struct {
+	ArchiveArn     *string                  `json:"ArchiveArn,omitempty"`
+	ArchiveName    *string                  `json:"ArchiveName,omitempty"`
+	EventSourceArn *string                  `json:"EventSourceArn,omitempty"`
+	Description    *string                  `json:"Description,omitempty"`
+	EventPattern   *string                  `json:"EventPattern,omitempty"`
+	RetentionDays  *int32                   `json:"RetentionDays,omitempty"`
+	State          svcsdktypes.ArchiveState `json:"State,omitempty"`
+	StateReason    *string                  `json:"StateReason,omitempty"`
+	CreationTime   *epochTime               `json:"CreationTime,omitempty"`
+	EventCount     int64                    `json:"EventCount"`
+	SizeBytes      int64                    `json:"SizeBytes"`
+}
+
+func (a *archive) output() archiveOutput {
+	return archiveOutput{
+		ArchiveArn:     aws.String(a.arn),
+		ArchiveName:    aws.String(a.name),
+		EventSourceArn: a.eventSourceArn,
+		Description:    a.description,
+		EventPattern:   a.eventPattern,
+		RetentionDays:  a.retentionDays,
+		State:          a.state,
+		StateReason:    a.stateReason,
+		CreationTime:   newEpochTime(a.created),
+	}
+}
+
+// SetArchiveState forces the state of an archive, e.g. to simulate a
+// CREATE_FAILED archive. Any pending transition is discarded.
+func (eb *EventBridge) SetArchiveState(name string, state svcsdktypes.ArchiveState, reason string) {
+	eb.mu.Lock()
+	defer eb.mu.Unlock()
+
+	if a, ok := eb.archives[name]; ok {
+		a.state = state
+		a.stateReason = aws.String(reason)
+		a.transition = nil
+	}
+}
+
+func (eb *EventBridge) settleArchive(name string, a *archive) {
+	if !a.transition.advance() {
+		return
+	}
+	if a.transition.remove {
+		delete(eb.archives, name)
+		return
+	}
+	a.state = svcsdktypes.ArchiveState(a.transition.to)
+	a.stateReason = nil
+	a.transition = nil
+}
+
+func (eb *EventBridge) getArchive(name *string) (*archive, *APIError) {
+	a, ok := eb.archives[aws.ToString(name)]
+	if !ok {
+		return nil, notFound("Archive %s does not exist.", aws.ToString(name))
+	}
+	return a, nil
+}
+
+func (eb *EventBridge) createArchive(in *svcsdk.CreateArchiveInput) (any, *APIError) {
+	name := aws.ToString(in.ArchiveName)
+	if name == "" {
+		return nil, validation("1 validation error detected: Value null at 'archiveName' failed to satisfy constraint: Member must not be null")
+	}
+	if _, ok := eb.archives[name]; ok {
+		return nil, alreadyExists("Archive %s already exists.", name)
+	}
+	if !eb.busARNExists(aws.ToString(in.EventSourceArn)) {
+		return nil, notFound("Event bus %s does not exist.", aws.ToString(in.EventSourceArn))
+	}
+	if err := validEventPattern(in.EventPattern); err != nil {
+		return nil, err
+	}
+
+	a := &archive{
+		name:           name,
+		arn:            eb.arn("archive/" + name),
+		eventSourceArn: in.EventSourceArn,
+		description:    in.Description,
+		eventPattern:   in.EventPattern,
+		retentionDays:  in.RetentionDays,
+		state:          svcsdktypes.ArchiveStateCreating,
+		created:        time.Now(),
+		transition:     eb.newTransition(string(svcsdktypes.ArchiveStateEnabled), false),
+	}
+	eb.archives[name] = a
+
+	return map[string]any{
+		"ArchiveArn":   a.arn,
+		"State":        a.state,
+		"CreationTime": newEpochTime(a.created),
+	}, nil
+}
+
+func (eb *EventBridge) describeArchive(in *svcsdk.DescribeArchiveInput) (any, *APIError) {
+	a, err := eb.getArchive(in.ArchiveName)
+	if err != nil {
+		return nil, err
+	}
+	if a.transition != nil {
+		eb.settleArchive(a.name, a)
+		if a, err = eb.getArchive(in.ArchiveName); err != nil {
+			return nil, err
+		}
+	}
+	return a.output(), nil
+}
+
+func (eb *EventBridge) updateArchive(in *svcsdk.UpdateArchiveInput) (any, *APIError) {
+	a, err := eb.getArchive(in.ArchiveName)
+	if err != nil {
+		return nil, err
+	}
+	if a.transition != nil {
+		return nil, illegalStatus("Archive %s is in state %s and can't be updated.", a.name, a.state)
+	}
+	if err := validEventPattern(in.EventPattern); err != nil {
+		return nil, err
+	}
+	a.description = in.Description
+	a.eventPattern = in.EventPattern
+	a.retentionDays = in.RetentionDays
+	a.state = svcsdktypes.ArchiveStateUpdating
+	a.transition = eb.newTransition(string(svcsdktypes.ArchiveStateEnabled), false)
+
+	return map[string]any{
+		"ArchiveArn":   a.arn,
+		"State":        a.state,
+		"CreationTime": newEpochTime(a.created),
+	}, nil
+}
+
+func (eb *EventBridge) deleteArchive(in *svcsdk.DeleteArchiveInput) (any, *APIError) {
+	if _, err := eb.getArchive(in.ArchiveName); err != nil {
+		return nil, err
+	}
+	delete(eb.archives, aws.ToString(in.ArchiveName))
+	return struct{}{}, nil
+}
+
+func (eb *EventBridge) listArchives(in *svcsdk.ListArchivesInput) (any, *APIError) {
+	archives := []archiveOutput{}
+	for _, name := range sortedKeys(eb.archives) {
+		a := eb.archives[name]
+		if !strings.HasPrefix(name, aws.ToString(in.NamePrefix)) {
+			continue
+		}
+		if in.EventSourceArn != nil && aws.ToString(a.eventSourceArn) != *in.EventSourceArn {
+			continue
+		}
+		if in.State != "" && a.state != in.State {
+			continue
+		}
+		archives = append(archives, a.output())
+	}
+	page, next, err := paginate(archives, in.NextToken, aws.ToInt32(in.Limit))
+	if err != nil {
+		return nil, err
+	}
+	return map[string]any{
+		"Archives":  page,
+		"NextToken": next,
+	}, nil
+}
+
+// busARNExists returns true if an event bus with the given ARN exists
+func (eb *EventBridge) busARNExists(arn string) bool {
+	_, ok := eb.buses[arn]
+	return ok
+}
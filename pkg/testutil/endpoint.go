@@ -0,0 +1,258 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package testutil
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	svcsdk "github.com/aws/aws-sdk-go-v2/service/eventbridge"
+	svcsdktypes "github.com/aws/aws-sdk-go-v2/service/eventbridge/types"
+)
+
+type endpoint struct {
+	name              string
+	arn               string
+	id                string
+	description       *string
+	roleArn           *string
+	eventBuses        []svcsdktypes.EndpointEventBus
+	routingConfig     *svcsdktypes.RoutingConfig
+	replicationConfig *svcsdktypes.ReplicationConfig
+	state             svcsdktypes.EndpointState
+	stateReason       *string
+	created           time.Time
+	lastModified      time.Time
+	transition        *transition
+}
+
+// endpointOutput is the wire representation of an endpoint, shared by the
+// Create, Describe, Update and ListEndpoints responses
+type endpointOutput struct {
+	Arn               *string                        `json:"Arn,omitempty"`
+	Name              *string                        `json:"Name,omitempty"`
+	EndpointId        *string                        `json:"EndpointId,omitempty"`
+	EndpointUrl       *string                        `json:"EndpointUrl,omitempty"`
+	Description       *string                        `json:"Description,omitempty"`
+	RoleArn           *string                        `json:"RoleArn,omitempty"`
+	EventBuses        []svcsdktypes.EndpointEventBus `json:"EventBuses,omitempty"`
+	RoutingConfig     *svcsdktypes.RoutingConfig     `json:"RoutingConfig,omitempty"`
+	ReplicationConfig *svcsdktypes.ReplicationConfig `json:"ReplicationConfig,omitempty"`
+	State             svcsdktypes.EndpointState      `json:"State,omitempty"`
+	StateReason       *string                        `json:"StateReason,omitempty"`
+	CreationTime      *epochTime                     `json:"CreationTime,omitempty"`
+	LastModifiedTime  *epochTime                     `json:"LastModifiedTime,omitempty"`
+}
+
+func (e *endpoint) output() endpointOutput {
+	return endpointOutput{
+		Arn:               aws.String(e.arn),
+		Name:              aws.String(e.name),
+		EndpointId:        aws.String(e.id),
+		EndpointUrl:       aws.String(fmt.Sprintf("https://%s.endpoint.events.amazonaws.com", e.id)),
+		Description:       e.description,
+		RoleArn:           e.roleArn,
+		EventBuses:        e.eventBuses,
+		RoutingConfig:     e.routingConfig,
+		ReplicationConfig: e.replicationConfig,
+		State:             e.state,
+		StateReason:       e.stateReason,
+		CreationTime:      newEpochTime(e.created),
+		LastModifiedTime:  newEpochTime(e.lastModified),
+	}
+}
+
+// SetEndpointState forces the state of an endpoint, e.g. to simulate an
+// UPDATE_FAILED endpoint. Any pending transition is discarded.
+func (eb *EventBridge) SetEndpointState(name string, state svcsdktypes.EndpointState, reason string) {
+	eb.mu.Lock()
+	defer eb.mu.Unlock()
+
+	if e, ok := eb.endpoints[name]; ok {
+		e.state = state
+		e.stateReason = aws.String(reason)
+		e.transition = nil
+	}
+}
+
+func (eb *EventBridge) settleEndpoint(name string, e *endpoint) {
+	if !e.transition.advance() {
+		return
+	}
+	if e.transition.remove {
+		delete(eb.endpoints, name)
+		return
+	}
+	e.state = svcsdktypes.EndpointState(e.transition.to)
+	e.stateReason = nil
+	e.transition = nil
+}
+
+func (eb *EventBridge) getEndpoint(name *string) (*endpoint, *APIError) {
+	e, ok := eb.endpoints[aws.ToString(name)]
+	if !ok {
+		return nil, notFound("Endpoint %s does not exist.", aws.ToString(name))
+	}
+	return e, nil
+}
+
+// validEndpointConfig returns a ValidationException if the endpoint doesn't
+// reference exactly two event buses or has no routing configuration
+func validEndpointConfig(
+	buses []svcsdktypes.EndpointEventBus,
+	routing *svcsdktypes.RoutingConfig,
+) *APIError {
+	if len(buses) != 2 {
+		return validation("An endpoint requires exactly 2 event buses, got %d.", len(buses))
+	}
+	if routing == nil || routing.FailoverConfig == nil {
+		return validation("1 validation error detected: Value null at 'routingConfig' failed to satisfy constraint: Member must not be null")
+	}
+	return nil
+}
+
+func (eb *EventBridge) createEndpoint(in *svcsdk.CreateEndpointInput) (any, *APIError) {
+	name := aws.ToString(in.Name)
+	if name == "" {
+		return nil, validation("1 validation error detected: Value null at 'name' failed to satisfy constraint: Member must not be null")
+	}
+	if _, ok := eb.endpoints[name]; ok {
+		return nil, alreadyExists("Endpoint %s already exists.", name)
+	}
+	if err := validEndpointConfig(in.EventBuses, in.RoutingConfig); err != nil {
+		return nil, err
+	}
+
+	replication := in.ReplicationConfig
+	if replication == nil {
+		// the API defaults replication to ENABLED and always returns it
+		replication = &svcsdktypes.ReplicationConfig{State: svcsdktypes.ReplicationStateEnabled}
+	}
+
+	eb.endpointCount++
+	now := time.Now()
+	e := &endpoint{
+		name:              name,
+		arn:               eb.arn("endpoint/" + name),
+		id:                fmt.Sprintf("ep%05d.fake", eb.endpointCount),
+		description:       in.Description,
+		roleArn:           in.RoleArn,
+		eventBuses:        in.EventBuses,
+		routingConfig:     in.RoutingConfig,
+		replicationConfig: replication,
+		state:             svcsdktypes.EndpointStateCreating,
+		created:           now,
+		lastModified:      now,
+		transition:        eb.newTransition(string(svcsdktypes.EndpointStateActive), false),
+	}
+	eb.endpoints[name] = e
+
+	return e.output(), nil
+}
+
+func (eb *EventBridge) describeEndpoint(in *svcsdk.DescribeEndpointInput) (any, *APIError) {
+	if in.HomeRegion != nil && *in.HomeRegion != eb.region {
+		return nil, notFound("Endpoint %s does not exist in region %s.", aws.ToString(in.Name), *in.HomeRegion)
+	}
+	e, err := eb.getEndpoint(in.Name)
+	if err != nil {
+		return nil, err
+	}
+	if e.transition != nil {
+		eb.settleEndpoint(e.name, e)
+		if e, err = eb.getEndpoint(in.Name); err != nil {
+			return nil, err
+		}
+	}
+	return e.output(), nil
+}
+
+func (eb *EventBridge) updateEndpoint(in *svcsdk.UpdateEndpointInput) (any, *APIError) {
+	e, err := eb.getEndpoint(in.Name)
+	if err != nil {
+		return nil, err
+	}
+	if e.transition != nil {
+		return nil, illegalStatus("Endpoint %s is in state %s and can't be updated.", e.name, e.state)
+	}
+
+	buses := e.eventBuses
+	if in.EventBuses != nil {
+		buses = in.EventBuses
+	}
+	routing := e.routingConfig
+	if in.RoutingConfig != nil {
+		routing = in.RoutingConfig
+	}
+	if err := validEndpointConfig(buses, routing); err != nil {
+		return nil, err
+	}
+
+	e.eventBuses = buses
+	e.routingConfig = routing
+	if in.Description != nil {
+		e.description = in.Description
+	}
+	if in.RoleArn != nil {
+		e.roleArn = in.RoleArn
+	}
+	if in.ReplicationConfig != nil {
+		e.replicationConfig = in.ReplicationConfig
+	}
+	e.state = svcsdktypes.EndpointStateUpdating
+	e.lastModified = time.Now()
+	e.transition = eb.newTransition(string(svcsdktypes.EndpointStateActive), false)
+
+	return e.output(), nil
+}
+
+func (eb *EventBridge) deleteEndpoint(in *svcsdk.DeleteEndpointInput) (any, *APIError) {
+	e, err := eb.getEndpoint(in.Name)
+	if err != nil {
+		return nil, err
+	}
+	if e.state == svcsdktypes.EndpointStateDeleting {
+		return struct{}{}, nil
+	}
+	if e.transition != nil {
+		return nil, illegalStatus("Endpoint %s is in state %s and can't be deleted.", e.name, e.state)
+	}
+	e.state = svcsdktypes.EndpointStateDeleting
+	e.transition = eb.newTransition("", true)
+	if eb.transitionReads == 0 {
+		delete(eb.endpoints, e.name)
+	}
+	return struct{}{}, nil
+}
+
+func (eb *EventBridge) listEndpoints(in *svcsdk.ListEndpointsInput) (any, *APIError) {
+	endpoints := []endpointOutput{}
+	if in.HomeRegion == nil || *in.HomeRegion == eb.region {
+		for _, name := range sortedKeys(eb.endpoints) {
+			if strings.HasPrefix(name, aws.ToString(in.NamePrefix)) {
+				endpoints = append(endpoints, eb.endpoints[name].output())
+			}
+		}
+	}
+	page, next, err := paginate(endpoints, in.NextToken, aws.ToInt32(in.MaxResults))
+	if err != nil {
+		return nil, err
+	}
+	return map[string]any{
+		"Endpoints": page,
+		"NextToken": next,
+	}, nil
+}
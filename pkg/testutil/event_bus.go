@@ -0,0 +1,182 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package testutil
+
+import (
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	svcsdk "github.com/aws/aws-sdk-go-v2/service/eventbridge"
+	svcsdktypes "github.com/aws/aws-sdk-go-v2/service/eventbridge/types"
+)
+
+type eventBus struct {
+	name             string
+	arn              string
+	description      *string
+	kmsKeyIdentifier *string
+	deadLetterConfig *svcsdktypes.DeadLetterConfig
+	logConfig        *svcsdktypes.LogConfig
+	created          time.Time
+	lastModified     time.Time
+}
+
+// eventBusOutput is the wire representation of an event bus, shared by the
+// DescribeEventBus and ListEventBuses responses
+type eventBusOutput struct {
+	Arn              *string                       `json:"Arn,omitempty"`
+	Name             *string                       `json:"Name,omitempty"`
+	Description      *string                       `json:"Description,omitempty"`
+	KmsKeyIdentifier *string                       `json:"KmsKeyIdentifier,omitempty"`
+	DeadLetterConfig *svcsdktypes.DeadLetterConfig `json:"DeadLetterConfig,omitempty"`
+	LogConfig        *svcsdktypes.LogConfig        `json:"LogConfig,omitempty"`
+	CreationTime     *epochTime                    `json:"CreationTime,omitempty"`
+	LastModifiedTime *epochTime                    `json:"LastModifiedTime,omitempty"`
+}
+
+func (b *eventBus) output() eventBusOutput {
+	return eventBusOutput{
+		Arn:              aws.String(b.arn),
+		Name:             aws.String(b.name),
+		Description:      b.description,
+		KmsKeyIdentifier: b.kmsKeyIdentifier,
+		DeadLetterConfig: b.deadLetterConfig,
+		LogConfig:        b.logConfig,
+		CreationTime:     newEpochTime(b.created),
+		LastModifiedTime: newEpochTime(b.lastModified),
+	}
+}
+
+// busARN returns the ARN of the event bus referenced by a name or ARN,
+// defaulting to the default event bus. The buses are keyed by ARN, so ARNs of
+// event buses in other accounts or Regions than the fake's don't match any.
+func (eb *EventBridge) busARN(nameOrARN *string) string {
+	s := aws.ToString(nameOrARN)
+	if s == "" {
+		s = defaultBusName
+	}
+	if strings.HasPrefix(s, "arn:") {
+		return s
+	}
+	return eb.arn("event-bus/" + s)
+}
+
+func (eb *EventBridge) getEventBus(nameOrARN *string) (*eventBus, *APIError) {
+	b, ok := eb.buses[eb.busARN(nameOrARN)]
+	if !ok {
+		return nil, notFound("Event bus %s does not exist.", aws.ToString(nameOrARN))
+	}
+	return b, nil
+}
+
+func (eb *EventBridge) createEventBus(in *svcsdk.CreateEventBusInput) (any, *APIError) {
+	name := aws.ToString(in.Name)
+	if name == "" {
+		return nil, validation("1 validation error detected: Value null at 'name' failed to satisfy constraint: Member must not be null")
+	}
+	if _, ok := eb.buses[eb.busARN(&name)]; ok {
+		return nil, alreadyExists("Event bus %s already exists.", name)
+	}
+	if err := validTags(in.Tags); err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	b := &eventBus{
+		name:             name,
+		arn:              eb.arn("event-bus/" + name),
+		description:      in.Description,
+		kmsKeyIdentifier: in.KmsKeyIdentifier,
+		deadLetterConfig: in.DeadLetterConfig,
+		logConfig:        in.LogConfig,
+		created:          now,
+		lastModified:     now,
+	}
+	eb.buses[b.arn] = b
+	eb.addTags(b.arn, in.Tags)
+
+	return map[string]any{
+		"EventBusArn":      b.arn,
+		"Description":      b.description,
+		"KmsKeyIdentifier": b.kmsKeyIdentifier,
+		"DeadLetterConfig": b.deadLetterConfig,
+		"LogConfig":        b.logConfig,
+	}, nil
+}
+
+func (eb *EventBridge) describeEventBus(in *svcsdk.DescribeEventBusInput) (any, *APIError) {
+	b, err := eb.getEventBus(in.Name)
+	if err != nil {
+		return nil, err
+	}
+	return b.output(), nil
+}
+
+func (eb *EventBridge) updateEventBus(in *svcsdk.UpdateEventBusInput) (any, *APIError) {
+	b, err := eb.getEventBus(in.Name)
+	if err != nil {
+		return nil, err
+	}
+	b.description = in.Description
+	b.kmsKeyIdentifier = in.KmsKeyIdentifier
+	b.deadLetterConfig = in.DeadLetterConfig
+	b.logConfig = in.LogConfig
+	b.lastModified = time.Now()
+
+	return map[string]any{
+		"Arn":              b.arn,
+		"Name":             b.name,
+		"Description":      b.description,
+		"KmsKeyIdentifier": b.kmsKeyIdentifier,
+		"DeadLetterConfig": b.deadLetterConfig,
+		"LogConfig":        b.logConfig,
+	}, nil
+}
+
+func (eb *EventBridge) deleteEventBus(in *svcsdk.DeleteEventBusInput) (any, *APIError) {
+	b, ok := eb.buses[eb.busARN(in.Name)]
+	if !ok {
+		// DeleteEventBus succeeds for event buses which do not exist
+		return struct{}{}, nil
+	}
+	if b.name == defaultBusName {
+		return nil, validation("Cannot delete event bus default.")
+	}
+	for key := range eb.rules {
+		if key.bus == b.name {
+			return nil, validation("Cannot delete event bus %s because it still has rules.", b.name)
+		}
+	}
+	delete(eb.buses, b.arn)
+	delete(eb.tags, b.arn)
+	return struct{}{}, nil
+}
+
+func (eb *EventBridge) listEventBuses(in *svcsdk.ListEventBusesInput) (any, *APIError) {
+	buses := []eventBusOutput{}
+	for _, arn := range sortedKeys(eb.buses) {
+		if b := eb.buses[arn]; strings.HasPrefix(b.name, aws.ToString(in.NamePrefix)) {
+			buses = append(buses, b.output())
+		}
+	}
+	page, next, err := paginate(buses, in.NextToken, aws.ToInt32(in.Limit))
+	if err != nil {
+		return nil, err
+	}
+	return map[string]any{
+		"EventBuses": page,
+		"NextToken":  next,
+	}, nil
+}
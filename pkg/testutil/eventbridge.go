@@ -0,0 +1,489 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+// Package testutil provides an in-memory fake of the Amazon EventBridge
+// control plane API for testing the resource managers without network access.
+// The fake also implements the CloudWatch Logs log delivery operations the
+// controller uses to deliver the logs of event buses.
+package testutil
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	svcsdktypes "github.com/aws/aws-sdk-go-v2/service/eventbridge/types"
+)
+
+const (
+	// DefaultRegion is the region used by the fake unless overridden with
+	// WithRegion
+	DefaultRegion = "us-west-2"
+	// DefaultAccountID is the account used by the fake unless overridden
+	// with WithAccountID
+	DefaultAccountID = "123456789012"
+
+	defaultBusName = "default"
+	targetPrefix   = "AWSEvents."
+)
+
+// EventBridge is a stateful, in-memory fake of the Amazon EventBridge control
+// plane API. It speaks the awsJson1_1 protocol used by the aws-sdk-go-v2
+// eventbridge client, so a client created from Config() talks to the fake
+// without any network access. EventBridge also implements http.Handler and can
+// be served with httptest for tools which only take an endpoint URL.
+//
+// Archives and endpoints model the asynchronous state machines of the real
+// API: they stay in a transitional state (e.g. CREATING) for a configurable
+// number of Describe calls before they settle.
+type EventBridge struct {
+	mu sync.Mutex
+
+	partition string
+	region    string
+	accountID string
+
+	// transitionReads is the number of Describe calls an archive or endpoint
+	// reports a transitional state before it settles
+	transitionReads int
+
+	buses     map[string]*eventBus
+	rules     map[ruleKey]*rule
+	archives  map[string]*archive
+	endpoints map[string]*endpoint
+	tags      map[string]map[string]string
+	// events are the events put on each event bus
+	events map[string][]svcsdktypes.PutEventsRequestEntry
+
+	// deliverySources, deliveryDestinations and deliveries are the
+	// CloudWatch Logs log delivery resources, by name and ID
+	deliverySources      map[string]*deliverySource
+	deliveryDestinations map[string]*deliveryDestination
+	deliveries           map[string]*delivery
+
+	// failures contains queued errors per operation, returned in order
+	failures map[string][]*APIError
+	// targetFailures contains PutTargets entry failures per target ID
+	targetFailures map[string]*APIError
+	calls          []string
+	endpointCount  int
+	eventCount     int
+	deliveryCount  int
+}
+
+// Option configures an EventBridge fake
+type Option func(*EventBridge)
+
+// WithRegion sets the region used for ARNs and endpoint home regions
+func WithRegion(region string) Option {
+	return func(eb *EventBridge) {
+		eb.region = region
+	}
+}
+
+// WithAccountID sets the account ID used for ARNs
+func WithAccountID(id string) Option {
+	return func(eb *EventBridge) {
+		eb.accountID = id
+	}
+}
+
+// WithTransitionReads sets the number of Describe calls an archive or endpoint
+// stays in a transitional state. The default is 1, zero settles on the first
+// Describe call.
+func WithTransitionReads(n int) Option {
+	return func(eb *EventBridge) {
+		eb.transitionReads = n
+	}
+}
+
+// NewEventBridge returns an EventBridge fake containing only the default event
+// bus
+func NewEventBridge(opts ...Option) *EventBridge {
+	eb := &EventBridge{
+		partition:       "aws",
+		region:          DefaultRegion,
+		accountID:       DefaultAccountID,
+		transitionReads: 1,
+		buses:           map[string]*eventBus{},
+		rules:           map[ruleKey]*rule{},
+		archives:        map[string]*archive{},
+		endpoints:       map[string]*endpoint{},
+		tags:            map[string]map[string]string{},
+		events:          map[string][]svcsdktypes.PutEventsRequestEntry{},
+		failures:        map[string][]*APIError{},
+		targetFailures:  map[string]*APIError{},
+
+		deliverySources:      map[string]*deliverySource{},
+		deliveryDestinations: map[string]*deliveryDestination{},
+		deliveries:           map[string]*delivery{},
+	}
+	for _, opt := range opts {
+		opt(eb)
+	}
+
+	now := time.Now()
+	defaultBus := &eventBus{
+		name:         defaultBusName,
+		arn:          eb.arn("event-bus/" + defaultBusName),
+		created:      now,
+		lastModified: now,
+	}
+	eb.buses[defaultBus.arn] = defaultBus
+	return eb
+}
+
+// Config returns an aws.Config whose HTTP client is served by the fake. Retries
+// are disabled so injected errors surface immediately.
+func (eb *EventBridge) Config() aws.Config {
+	return aws.Config{
+		Region:      eb.region,
+		Credentials: aws.AnonymousCredentials{},
+		HTTPClient:  eb,
+		Retryer: func() aws.Retryer {
+			return aws.NopRetryer{}
+		},
+	}
+}
+
+// Do implements aws.HTTPClient
+func (eb *EventBridge) Do(req *http.Request) (*http.Response, error) {
+	rec := httptest.NewRecorder()
+	eb.ServeHTTP(rec, req)
+	return rec.Result(), nil
+}
+
+// ServeHTTP implements http.Handler
+func (eb *EventBridge) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	op := strings.TrimPrefix(req.Header.Get("X-Amz-Target"), targetPrefix)
+	op = strings.TrimPrefix(op, logsTargetPrefix)
+	body, err := io.ReadAll(req.Body)
+	if err != nil {
+		writeError(w, newError(http.StatusBadRequest, "SerializationException", err.Error()))
+		return
+	}
+
+	out, apiErr := eb.handle(op, body)
+	if apiErr != nil {
+		writeError(w, apiErr)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/x-amz-json-1.1")
+	w.WriteHeader(http.StatusOK)
+	_ = json.NewEncoder(w).Encode(out)
+}
+
+func (eb *EventBridge) handle(op string, body []byte) (any, *APIError) {
+	eb.mu.Lock()
+	defer eb.mu.Unlock()
+
+	eb.calls = append(eb.calls, op)
+	if queued := eb.failures[op]; len(queued) > 0 {
+		eb.failures[op] = queued[1:]
+		return nil, queued[0]
+	}
+
+	h, ok := eb.handlers()[op]
+	if !ok {
+		return nil, newError(http.StatusBadRequest, "UnknownOperationException",
+			fmt.Sprintf("operation %q is not supported by the fake", op))
+	}
+	return h(body)
+}
+
+type handlerFunc func(body []byte) (any, *APIError)
+
+// handle decodes the request body into a new input value and invokes fn
+func handle[T any](fn func(in *T) (any, *APIError)) handlerFunc {
+	return func(body []byte) (any, *APIError) {
+		in := new(T)
+		if len(body) > 0 {
+			if err := json.Unmarshal(body, in); err != nil {
+				return nil, newError(http.StatusBadRequest, "SerializationException", err.Error())
+			}
+		}
+		return fn(in)
+	}
+}
+
+func (eb *EventBridge) handlers() map[string]handlerFunc {
+	return map[string]handlerFunc{
+		"CreateEventBus":      handle(eb.createEventBus),
+		"DescribeEventBus":    handle(eb.describeEventBus),
+		"UpdateEventBus":      handle(eb.updateEventBus),
+		"DeleteEventBus":      handle(eb.deleteEventBus),
+		"ListEventBuses":      handle(eb.listEventBuses),
+		"PutRule":             handle(eb.putRule),
+		"DescribeRule":        handle(eb.describeRule),
+		"DeleteRule":          handle(eb.deleteRule),
+		"ListRules":           handle(eb.listRules),
+		"PutTargets":          handle(eb.putTargets),
+		"RemoveTargets":       handle(eb.removeTargets),
+		"ListTargetsByRule":   handle(eb.listTargetsByRule),
+		"TagResource":         handle(eb.tagResource),
+		"UntagResource":       handle(eb.untagResource),
+		"ListTagsForResource": handle(eb.listTagsForResource),
+		"CreateArchive":       handle(eb.createArchive),
+		"DescribeArchive":     handle(eb.describeArchive),
+		"UpdateArchive":       handle(eb.updateArchive),
+		"DeleteArchive":       handle(eb.deleteArchive),
+		"ListArchives":        handle(eb.listArchives),
+		"CreateEndpoint":      handle(eb.createEndpoint),
+		"DescribeEndpoint":    handle(eb.describeEndpoint),
+		"UpdateEndpoint":      handle(eb.updateEndpoint),
+		"DeleteEndpoint":      handle(eb.deleteEndpoint),
+		"ListEndpoints":       handle(eb.listEndpoints),
+		"PutEvents":           handle(eb.putEvents),
+
+		"PutDeliverySource":         handle(eb.putDeliverySource),
+		"DeleteDeliverySource":      handle(eb.deleteDeliverySource),
+		"PutDeliveryDestination":    handle(eb.putDeliveryDestination),
+		"GetDeliveryDestination":    handle(eb.getDeliveryDestination),
+		"DeleteDeliveryDestination": handle(eb.deleteDeliveryDestination),
+		"CreateDelivery":            handle(eb.createDelivery),
+		"DescribeDeliveries":        handle(eb.describeDeliveries),
+		"DeleteDelivery":            handle(eb.deleteDelivery),
+	}
+}
+
+// FailNext queues an error which is returned by the next call of the given
+// operation, e.g. FailNext("PutTargets", "ThrottlingException", "slow down").
+// Multiple errors for the same operation are returned in order.
+func (eb *EventBridge) FailNext(operation, code, message string) {
+	eb.mu.Lock()
+	defer eb.mu.Unlock()
+
+	status := http.StatusBadRequest
+	if code == "InternalException" {
+		status = http.StatusInternalServerError
+	}
+	eb.failures[operation] = append(eb.failures[operation], newError(status, code, message))
+}
+
+// FailTarget makes PutTargets report the target with the given ID as a failed
+// entry until ClearTargetFailures is called
+func (eb *EventBridge) FailTarget(targetID, code, message string) {
+	eb.mu.Lock()
+	defer eb.mu.Unlock()
+
+	eb.targetFailures[targetID] = newError(http.StatusBadRequest, code, message)
+}
+
+// ClearTargetFailures removes all target failures set with FailTarget
+func (eb *EventBridge) ClearTargetFailures() {
+	eb.mu.Lock()
+	defer eb.mu.Unlock()
+
+	eb.targetFailures = map[string]*APIError{}
+}
+
+// Calls returns the names of all operations invoked so far, in order
+func (eb *EventBridge) Calls() []string {
+	eb.mu.Lock()
+	defer eb.mu.Unlock()
+
+	return append([]string(nil), eb.calls...)
+}
+
+// ResetCalls clears the recorded operations
+func (eb *EventBridge) ResetCalls() {
+	eb.mu.Lock()
+	defer eb.mu.Unlock()
+
+	eb.calls = nil
+}
+
+// Settle completes all pending archive and endpoint state transitions
+func (eb *EventBridge) Settle() {
+	eb.mu.Lock()
+	defer eb.mu.Unlock()
+
+	for name, a := range eb.archives {
+		if a.transition != nil {
+			a.transition.reads = 0
+			eb.settleArchive(name, a)
+		}
+	}
+	for name, e := range eb.endpoints {
+		if e.transition != nil {
+			e.transition.reads = 0
+			eb.settleEndpoint(name, e)
+		}
+	}
+}
+
+// SetTags replaces the tags of the resource with the given ARN without any
+// validation, e.g. to seed AWS managed "aws:" tags
+func (eb *EventBridge) SetTags(arn string, tags map[string]string) {
+	eb.mu.Lock()
+	defer eb.mu.Unlock()
+
+	eb.tags[arn] = map[string]string{}
+	for k, v := range tags {
+		eb.tags[arn][k] = v
+	}
+}
+
+// arn returns the ARN of an EventBridge resource in the fake's account and
+// region, e.g. arn("event-bus/my-bus")
+func (eb *EventBridge) arn(resource string) string {
+	return fmt.Sprintf("arn:%s:events:%s:%s:%s", eb.partition, eb.region, eb.accountID, resource)
+}
+
+// transition describes a pending asynchronous state change
+type transition struct {
+	// to is the state the resource settles in
+	to string
+	// reads is the number of Describe calls left before the resource settles
+	reads int
+	// remove deletes the resource once settled
+	remove bool
+}
+
+func (eb *EventBridge) newTransition(to string, remove bool) *transition {
+	return &transition{to: to, reads: eb.transitionReads, remove: remove}
+}
+
+// advance consumes one Describe call and returns true if the transition is due
+func (t *transition) advance() bool {
+	if t.reads <= 0 {
+		return true
+	}
+	t.reads--
+	return false
+}
+
+// APIError is an error returned by the fake in the awsJson1_1 error format
+type APIError struct {
+	status  int
+	code    string
+	message string
+}
+
+func (e *APIError) Error() string {
+	return fmt.Sprintf("%s: %s", e.code, e.message)
+}
+
+func newError(status int, code, message string) *APIError {
+	return &APIError{status: status, code: code, message: message}
+}
+
+func notFound(format string, args ...any) *APIError {
+	return newError(http.StatusBadRequest, "ResourceNotFoundException", fmt.Sprintf(format, args...))
+}
+
+func alreadyExists(format string, args ...any) *APIError {
+	return newError(http.StatusBadRequest, "ResourceAlreadyExistsException", fmt.Sprintf(format, args...))
+}
+
+func validation(format string, args ...any) *APIError {
+	return newError(http.StatusBadRequest, "ValidationException", fmt.Sprintf(format, args...))
+}
+
+func illegalStatus(format string, args ...any) *APIError {
+	return newError(http.StatusBadRequest, "IllegalStatusException", fmt.Sprintf(format, args...))
+}
+
+func writeError(w http.ResponseWriter, e *APIError) {
+	w.Header().Set("Content-Type", "application/x-amz-json-1.1")
+	w.Header().Set("X-Amzn-ErrorType", e.code)
+	w.WriteHeader(e.status)
+	_ = json.NewEncoder(w).Encode(map[string]string{
+		"__type":  e.code,
+		"message": e.message,
+	})
+}
+
+// epochTime is a timestamp serialized as fractional epoch seconds, the
+// awsJson1_1 timestamp format
+type epochTime time.Time
+
+func (t epochTime) MarshalJSON() ([]byte, error) {
+	secs := float64(time.Time(t).UnixMilli()) / 1000
+	return []byte(strconv.FormatFloat(secs, 'f', 3, 64)), nil
+}
+
+func newEpochTime(t time.Time) *epochTime {
+	if t.IsZero() {
+		return nil
+	}
+	et := epochTime(t)
+	return &et
+}
+
+// validEventPattern returns an InvalidEventPatternException if the pattern is
+// not a JSON object
+func validEventPattern(pattern *string) *APIError {
+	if pattern == nil {
+		return nil
+	}
+	var v map[string]any
+	if err := json.Unmarshal([]byte(*pattern), &v); err != nil {
+		return newError(http.StatusBadRequest, "InvalidEventPatternException",
+			fmt.Sprintf("Event pattern is not valid. Reason: %s", err))
+	}
+	return nil
+}
+
+// paginate returns the page of items starting at the index encoded in token
+// and the token of the next page, if any
+func paginate[T any](items []T, token *string, limit int32) ([]T, *string, *APIError) {
+	start := 0
+	if token != nil && *token != "" {
+		i, err := strconv.Atoi(*token)
+		if err != nil || i < 0 || i > len(items) {
+			return nil, nil, validation("The NextToken provided is invalid.")
+		}
+		start = i
+	}
+	end := len(items)
+	if limit > 0 && start+int(limit) < end {
+		end = start + int(limit)
+	}
+	var next *string
+	if end < len(items) {
+		next = aws.String(strconv.Itoa(end))
+	}
+	return items[start:end], next, nil
+}
+
+// sortedKeys returns the keys of m in lexical order
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// sdkTags returns the tags of the resource with the given ARN sorted by key
+func (eb *EventBridge) sdkTags(arn string) []svcsdktypes.Tag {
+	tags := []svcsdktypes.Tag{}
+	for _, k := range sortedKeys(eb.tags[arn]) {
+		tags = append(tags, svcsdktypes.Tag{
+			Key:   aws.String(k),
+			Value: aws.String(eb.tags[arn][k]),
+		})
+	}
+	return tags
+}
@@ -0,0 +1,500 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package testutil
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/cloudwatchlogs"
+	logstypes "github.com/aws/aws-sdk-go-v2/service/cloudwatchlogs/types"
+	svcsdk "github.com/aws/aws-sdk-go-v2/service/eventbridge"
+	svcsdktypes "github.com/aws/aws-sdk-go-v2/service/eventbridge/types"
+	"github.com/aws/smithy-go"
+	"gotest.tools/v3/assert"
+)
+
+const testPattern = `{"source":["test"]}`
+
+func errorCode(err error) string {
+	var apiErr smithy.APIError
+	if errors.As(err, &apiErr) {
+		return apiErr.ErrorCode()
+	}
+	return ""
+}
+
+func Test_EventBridge_EventBus(t *testing.T) {
+	ctx := context.Background()
+	eb := NewEventBridge()
+	client := svcsdk.NewFromConfig(eb.Config())
+
+	created, err := client.CreateEventBus(ctx, &svcsdk.CreateEventBusInput{
+		Name:        aws.String("bus"),
+		Description: aws.String("test"),
+		Tags:        []svcsdktypes.Tag{{Key: aws.String("k"), Value: aws.String("v")}},
+	})
+	assert.NilError(t, err)
+	assert.Equal(t, aws.ToString(created.EventBusArn), "arn:aws:events:us-west-2:123456789012:event-bus/bus")
+
+	_, err = client.CreateEventBus(ctx, &svcsdk.CreateEventBusInput{Name: aws.String("bus")})
+	assert.Equal(t, errorCode(err), "ResourceAlreadyExistsException")
+
+	described, err := client.DescribeEventBus(ctx, &svcsdk.DescribeEventBusInput{Name: aws.String("bus")})
+	assert.NilError(t, err)
+	assert.Equal(t, aws.ToString(described.Description), "test")
+	assert.Assert(t, described.CreationTime != nil)
+
+	_, err = client.DescribeEventBus(ctx, &svcsdk.DescribeEventBusInput{Name: created.EventBusArn})
+	assert.NilError(t, err)
+	for _, other := range []string{
+		"arn:aws:events:us-west-2:444455556666:event-bus/bus",
+		"arn:aws:events:us-east-1:123456789012:event-bus/bus",
+	} {
+		_, err = client.DescribeEventBus(ctx, &svcsdk.DescribeEventBusInput{Name: aws.String(other)})
+		assert.Equal(t, errorCode(err), "ResourceNotFoundException", other)
+		_, err = client.DescribeRule(ctx, &svcsdk.DescribeRuleInput{Name: aws.String("rule"), EventBusName: aws.String(other)})
+		assert.Equal(t, errorCode(err), "ResourceNotFoundException", other)
+	}
+
+	tags, err := client.ListTagsForResource(ctx, &svcsdk.ListTagsForResourceInput{ResourceARN: created.EventBusArn})
+	assert.NilError(t, err)
+	assert.Equal(t, len(tags.Tags), 1)
+
+	_, err = client.PutRule(ctx, &svcsdk.PutRuleInput{
+		Name:         aws.String("rule"),
+		EventBusName: aws.String("bus"),
+		EventPattern: aws.String(testPattern),
+	})
+	assert.NilError(t, err)
+	_, err = client.DeleteEventBus(ctx, &svcsdk.DeleteEventBusInput{Name: aws.String("bus")})
+	assert.Equal(t, errorCode(err), "ValidationException")
+
+	_, err = client.DeleteRule(ctx, &svcsdk.DeleteRuleInput{Name: aws.String("rule"), EventBusName: aws.String("bus")})
+	assert.NilError(t, err)
+	_, err = client.DeleteEventBus(ctx, &svcsdk.DeleteEventBusInput{Name: aws.String("bus")})
+	assert.NilError(t, err)
+	_, err = client.DescribeEventBus(ctx, &svcsdk.DescribeEventBusInput{Name: aws.String("bus")})
+	assert.Equal(t, errorCode(err), "ResourceNotFoundException")
+}
+
+func Test_EventBridge_Rule(t *testing.T) {
+	ctx := context.Background()
+	eb := NewEventBridge()
+	client := svcsdk.NewFromConfig(eb.Config())
+
+	tests := []struct {
+		name     string
+		input    *svcsdk.PutRuleInput
+		wantCode string
+		wantARN  string
+	}{
+		{
+			name: "rule on default bus",
+			input: &svcsdk.PutRuleInput{
+				Name:         aws.String("rule"),
+				EventPattern: aws.String(testPattern),
+			},
+			wantARN: "arn:aws:events:us-west-2:123456789012:rule/rule",
+		},
+		{
+			name: "missing event pattern and schedule expression",
+			input: &svcsdk.PutRuleInput{
+				Name: aws.String("rule"),
+			},
+			wantCode: "ValidationException",
+		},
+		{
+			name: "invalid event pattern",
+			input: &svcsdk.PutRuleInput{
+				Name:         aws.String("rule"),
+				EventPattern: aws.String("{"),
+			},
+			wantCode: "InvalidEventPatternException",
+		},
+		{
+			name: "unknown event bus",
+			input: &svcsdk.PutRuleInput{
+				Name:         aws.String("rule"),
+				EventBusName: aws.String("missing"),
+				EventPattern: aws.String(testPattern),
+			},
+			wantCode: "ResourceNotFoundException",
+		},
+		{
+			name: "reserved tag key",
+			input: &svcsdk.PutRuleInput{
+				Name:         aws.String("tagged"),
+				EventPattern: aws.String(testPattern),
+				Tags:         []svcsdktypes.Tag{{Key: aws.String("aws:owner"), Value: aws.String("v")}},
+			},
+			wantCode: "ValidationException",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp, err := client.PutRule(ctx, tt.input)
+			if tt.wantCode != "" {
+				assert.Equal(t, errorCode(err), tt.wantCode)
+				return
+			}
+			assert.NilError(t, err)
+			assert.Equal(t, aws.ToString(resp.RuleArn), tt.wantARN)
+		})
+	}
+
+	t.Run("schedule expression on custom bus", func(t *testing.T) {
+		_, err := client.CreateEventBus(ctx, &svcsdk.CreateEventBusInput{Name: aws.String("bus")})
+		assert.NilError(t, err)
+		_, err = client.PutRule(ctx, &svcsdk.PutRuleInput{
+			Name:               aws.String("scheduled"),
+			EventBusName:       aws.String("bus"),
+			ScheduleExpression: aws.String("rate(5 minutes)"),
+		})
+		assert.Equal(t, errorCode(err), "ValidationException")
+	})
+
+	t.Run("rule on custom bus by ARN", func(t *testing.T) {
+		resp, err := client.PutRule(ctx, &svcsdk.PutRuleInput{
+			Name:         aws.String("rule"),
+			EventBusName: aws.String("arn:aws:events:us-west-2:123456789012:event-bus/bus"),
+			EventPattern: aws.String(testPattern),
+		})
+		assert.NilError(t, err)
+		assert.Equal(t, aws.ToString(resp.RuleArn), "arn:aws:events:us-west-2:123456789012:rule/bus/rule")
+
+		described, err := client.DescribeRule(ctx, &svcsdk.DescribeRuleInput{
+			Name:         aws.String("rule"),
+			EventBusName: aws.String("bus"),
+		})
+		assert.NilError(t, err)
+		assert.Equal(t, aws.ToString(described.EventBusName), "bus")
+		assert.Equal(t, described.State, svcsdktypes.RuleStateEnabled)
+	})
+}
+
+func Test_EventBridge_Targets(t *testing.T) {
+	ctx := context.Background()
+	eb := NewEventBridge()
+	client := svcsdk.NewFromConfig(eb.Config())
+
+	_, err := client.PutRule(ctx, &svcsdk.PutRuleInput{
+		Name:         aws.String("rule"),
+		EventPattern: aws.String(testPattern),
+	})
+	assert.NilError(t, err)
+
+	ecsTarget := svcsdktypes.Target{
+		Id:  aws.String("ecs"),
+		Arn: aws.String("arn:aws:ecs:us-west-2:123456789012:cluster/test"),
+		EcsParameters: &svcsdktypes.EcsParameters{
+			TaskDefinitionArn: aws.String("arn:aws:ecs:us-west-2:123456789012:task-definition/test"),
+			NetworkConfiguration: &svcsdktypes.NetworkConfiguration{
+				AwsvpcConfiguration: &svcsdktypes.AwsVpcConfiguration{
+					Subnets: []string{"subnet-1"},
+				},
+			},
+		},
+	}
+	put, err := client.PutTargets(ctx, &svcsdk.PutTargetsInput{
+		Rule:    aws.String("rule"),
+		Targets: []svcsdktypes.Target{ecsTarget},
+	})
+	assert.NilError(t, err)
+	assert.Equal(t, put.FailedEntryCount, int32(0))
+
+	listed, err := client.ListTargetsByRule(ctx, &svcsdk.ListTargetsByRuleInput{Rule: aws.String("rule")})
+	assert.NilError(t, err)
+	assert.Equal(t, len(listed.Targets), 1)
+	assert.DeepEqual(t,
+		listed.Targets[0].EcsParameters.NetworkConfiguration.AwsvpcConfiguration.Subnets,
+		[]string{"subnet-1"},
+	)
+
+	_, err = client.DeleteRule(ctx, &svcsdk.DeleteRuleInput{Name: aws.String("rule")})
+	assert.Equal(t, errorCode(err), "ValidationException")
+
+	eb.FailTarget("failing", "AccessDeniedException", "denied")
+	put, err = client.PutTargets(ctx, &svcsdk.PutTargetsInput{
+		Rule: aws.String("rule"),
+		Targets: []svcsdktypes.Target{{
+			Id:  aws.String("failing"),
+			Arn: aws.String("arn:aws:sqs:us-west-2:123456789012:queue"),
+		}},
+	})
+	assert.NilError(t, err)
+	assert.Equal(t, put.FailedEntryCount, int32(1))
+	assert.Equal(t, aws.ToString(put.FailedEntries[0].ErrorCode), "AccessDeniedException")
+
+	targets := []svcsdktypes.Target{}
+	for i := 0; i < maxTargetsPerRule; i++ {
+		targets = append(targets, svcsdktypes.Target{
+			Id:  aws.String(fmt.Sprintf("t%d", i)),
+			Arn: aws.String("arn:aws:sqs:us-west-2:123456789012:queue"),
+		})
+	}
+	_, err = client.PutTargets(ctx, &svcsdk.PutTargetsInput{Rule: aws.String("rule"), Targets: targets})
+	assert.Equal(t, errorCode(err), "LimitExceededException")
+
+	_, err = client.RemoveTargets(ctx, &svcsdk.RemoveTargetsInput{Rule: aws.String("rule"), Ids: []string{"ecs"}})
+	assert.NilError(t, err)
+	_, err = client.DeleteRule(ctx, &svcsdk.DeleteRuleInput{Name: aws.String("rule")})
+	assert.NilError(t, err)
+	_, err = client.DeleteRule(ctx, &svcsdk.DeleteRuleInput{Name: aws.String("rule")})
+	assert.NilError(t, err)
+}
+
+func Test_EventBridge_Archive(t *testing.T) {
+	ctx := context.Background()
+	eb := NewEventBridge(WithTransitionReads(1))
+	client := svcsdk.NewFromConfig(eb.Config())
+
+	created, err := client.CreateArchive(ctx, &svcsdk.CreateArchiveInput{
+		ArchiveName:    aws.String("archive"),
+		EventSourceArn: aws.String("arn:aws:events:us-west-2:123456789012:event-bus/default"),
+	})
+	assert.NilError(t, err)
+	assert.Equal(t, created.State, svcsdktypes.ArchiveStateCreating)
+
+	_, err = client.UpdateArchive(ctx, &svcsdk.UpdateArchiveInput{ArchiveName: aws.String("archive")})
+	assert.Equal(t, errorCode(err), "IllegalStatusException")
+
+	for _, want := range []svcsdktypes.ArchiveState{
+		svcsdktypes.ArchiveStateCreating,
+		svcsdktypes.ArchiveStateEnabled,
+	} {
+		described, err := client.DescribeArchive(ctx, &svcsdk.DescribeArchiveInput{ArchiveName: aws.String("archive")})
+		assert.NilError(t, err)
+		assert.Equal(t, described.State, want)
+	}
+
+	updated, err := client.UpdateArchive(ctx, &svcsdk.UpdateArchiveInput{
+		ArchiveName:   aws.String("archive"),
+		RetentionDays: aws.Int32(7),
+	})
+	assert.NilError(t, err)
+	assert.Equal(t, updated.State, svcsdktypes.ArchiveStateUpdating)
+	eb.Settle()
+
+	described, err := client.DescribeArchive(ctx, &svcsdk.DescribeArchiveInput{ArchiveName: aws.String("archive")})
+	assert.NilError(t, err)
+	assert.Equal(t, described.State, svcsdktypes.ArchiveStateEnabled)
+	assert.Equal(t, aws.ToInt32(described.RetentionDays), int32(7))
+
+	_, err = client.CreateArchive(ctx, &svcsdk.CreateArchiveInput{
+		ArchiveName:    aws.String("orphan"),
+		EventSourceArn: aws.String("arn:aws:events:us-west-2:123456789012:event-bus/missing"),
+	})
+	assert.Equal(t, errorCode(err), "ResourceNotFoundException")
+}
+
+func Test_EventBridge_Endpoint(t *testing.T) {
+	ctx := context.Background()
+	eb := NewEventBridge()
+	client := svcsdk.NewFromConfig(eb.Config())
+
+	input := &svcsdk.CreateEndpointInput{
+		Name: aws.String("endpoint"),
+		EventBuses: []svcsdktypes.EndpointEventBus{
+			{EventBusArn: aws.String("arn:aws:events:us-west-2:123456789012:event-bus/bus")},
+			{EventBusArn: aws.String("arn:aws:events:us-east-1:123456789012:event-bus/bus")},
+		},
+		RoutingConfig: &svcsdktypes.RoutingConfig{
+			FailoverConfig: &svcsdktypes.FailoverConfig{
+				Primary:   &svcsdktypes.Primary{HealthCheck: aws.String("arn:aws:route53:::healthcheck/id")},
+				Secondary: &svcsdktypes.Secondary{Route: aws.String("us-east-1")},
+			},
+		},
+	}
+	created, err := client.CreateEndpoint(ctx, input)
+	assert.NilError(t, err)
+	assert.Equal(t, created.State, svcsdktypes.EndpointStateCreating)
+
+	described, err := client.DescribeEndpoint(ctx, &svcsdk.DescribeEndpointInput{Name: aws.String("endpoint")})
+	assert.NilError(t, err)
+	assert.Equal(t, described.State, svcsdktypes.EndpointStateCreating)
+	described, err = client.DescribeEndpoint(ctx, &svcsdk.DescribeEndpointInput{Name: aws.String("endpoint")})
+	assert.NilError(t, err)
+	assert.Equal(t, described.State, svcsdktypes.EndpointStateActive)
+	assert.Equal(t, aws.ToString(described.RoutingConfig.FailoverConfig.Secondary.Route), "us-east-1")
+
+	input.EventBuses = input.EventBuses[:1]
+	input.Name = aws.String("invalid")
+	_, err = client.CreateEndpoint(ctx, input)
+	assert.Equal(t, errorCode(err), "ValidationException")
+
+	_, err = client.DeleteEndpoint(ctx, &svcsdk.DeleteEndpointInput{Name: aws.String("endpoint")})
+	assert.NilError(t, err)
+	described, err = client.DescribeEndpoint(ctx, &svcsdk.DescribeEndpointInput{Name: aws.String("endpoint")})
+	assert.NilError(t, err)
+	assert.Equal(t, described.State, svcsdktypes.EndpointStateDeleting)
+	_, err = client.DescribeEndpoint(ctx, &svcsdk.DescribeEndpointInput{Name: aws.String("endpoint")})
+	assert.Equal(t, errorCode(err), "ResourceNotFoundException")
+}
+
+func Test_EventBridge_PutEvents(t *testing.T) {
+	ctx := context.Background()
+	eb := NewEventBridge()
+	client := svcsdk.NewFromConfig(eb.Config())
+
+	resp, err := client.PutEvents(ctx, &svcsdk.PutEventsInput{
+		Entries: []svcsdktypes.PutEventsRequestEntry{
+			{Source: aws.String("test"), DetailType: aws.String("a"), Detail: aws.String("{}")},
+			{EventBusName: aws.String("missing"), Source: aws.String("test"), DetailType: aws.String("b"), Detail: aws.String("{}")},
+		},
+	})
+	assert.NilError(t, err)
+	assert.Equal(t, resp.FailedEntryCount, int32(1))
+	assert.Equal(t, aws.ToString(resp.Entries[0].EventId), "event-1")
+	assert.Equal(t, aws.ToString(resp.Entries[1].ErrorCode), "NotFoundException")
+
+	events := eb.Events("default")
+	assert.Equal(t, len(events), 1)
+	assert.Equal(t, aws.ToString(events[0].DetailType), "a")
+
+	_, err = client.PutEvents(ctx, &svcsdk.PutEventsInput{
+		Entries: make([]svcsdktypes.PutEventsRequestEntry, 11),
+	})
+	assert.Equal(t, errorCode(err), "ValidationException")
+}
+
+func Test_EventBridge_LogDeliveries(t *testing.T) {
+	ctx := context.Background()
+	eb := NewEventBridge()
+	client := svcsdk.NewFromConfig(eb.Config())
+	logs := cloudwatchlogs.NewFromConfig(eb.Config())
+	logGroup := "arn:aws:logs:us-west-2:123456789012:log-group:bus"
+
+	created, err := client.CreateEventBus(ctx, &svcsdk.CreateEventBusInput{
+		Name:      aws.String("bus"),
+		LogConfig: &svcsdktypes.LogConfig{Level: svcsdktypes.LevelInfo},
+	})
+	assert.NilError(t, err)
+	assert.Equal(t, created.LogConfig.Level, svcsdktypes.LevelInfo)
+	_, err = client.UpdateEventBus(ctx, &svcsdk.UpdateEventBusInput{
+		Name:      aws.String("bus"),
+		LogConfig: &svcsdktypes.LogConfig{Level: svcsdktypes.LevelError, IncludeDetail: svcsdktypes.IncludeDetailFull},
+	})
+	assert.NilError(t, err)
+	described, err := client.DescribeEventBus(ctx, &svcsdk.DescribeEventBusInput{Name: aws.String("bus")})
+	assert.NilError(t, err)
+	assert.Equal(t, described.LogConfig.Level, svcsdktypes.LevelError)
+	assert.Equal(t, described.LogConfig.IncludeDetail, svcsdktypes.IncludeDetailFull)
+
+	_, err = logs.PutDeliverySource(ctx, &cloudwatchlogs.PutDeliverySourceInput{
+		Name:        aws.String("source"),
+		ResourceArn: aws.String("arn:aws:events:us-west-2:123456789012:event-bus/missing"),
+		LogType:     aws.String("INFO_LOGS"),
+	})
+	assert.Equal(t, errorCode(err), "ResourceNotFoundException")
+	_, err = logs.PutDeliverySource(ctx, &cloudwatchlogs.PutDeliverySourceInput{
+		Name:        aws.String("source"),
+		ResourceArn: created.EventBusArn,
+		LogType:     aws.String("INFO_LOGS"),
+	})
+	assert.NilError(t, err)
+	destination, err := logs.PutDeliveryDestination(ctx, &cloudwatchlogs.PutDeliveryDestinationInput{
+		Name: aws.String("destination"),
+		DeliveryDestinationConfiguration: &logstypes.DeliveryDestinationConfiguration{
+			DestinationResourceArn: aws.String(logGroup),
+		},
+	})
+	assert.NilError(t, err)
+	delivery, err := logs.CreateDelivery(ctx, &cloudwatchlogs.CreateDeliveryInput{
+		DeliverySourceName:     aws.String("source"),
+		DeliveryDestinationArn: destination.DeliveryDestination.Arn,
+	})
+	assert.NilError(t, err)
+	_, err = logs.CreateDelivery(ctx, &cloudwatchlogs.CreateDeliveryInput{
+		DeliverySourceName:     aws.String("source"),
+		DeliveryDestinationArn: destination.DeliveryDestination.Arn,
+	})
+	assert.Equal(t, errorCode(err), "ConflictException")
+	assert.DeepEqual(t, eb.LogDeliveries(aws.ToString(created.EventBusArn)), map[string][]string{
+		"INFO_LOGS": {logGroup},
+	})
+
+	deliveries, err := logs.DescribeDeliveries(ctx, &cloudwatchlogs.DescribeDeliveriesInput{})
+	assert.NilError(t, err)
+	assert.Equal(t, len(deliveries.Deliveries), 1)
+	assert.Equal(t, aws.ToString(deliveries.Deliveries[0].DeliverySourceName), "source")
+	got, err := logs.GetDeliveryDestination(ctx, &cloudwatchlogs.GetDeliveryDestinationInput{Name: aws.String("destination")})
+	assert.NilError(t, err)
+	assert.Equal(t, aws.ToString(got.DeliveryDestination.DeliveryDestinationConfiguration.DestinationResourceArn), logGroup)
+
+	// sources and destinations can't be deleted while they have deliveries
+	_, err = logs.DeleteDeliverySource(ctx, &cloudwatchlogs.DeleteDeliverySourceInput{Name: aws.String("source")})
+	assert.Equal(t, errorCode(err), "ConflictException")
+	_, err = logs.DeleteDeliveryDestination(ctx, &cloudwatchlogs.DeleteDeliveryDestinationInput{Name: aws.String("destination")})
+	assert.Equal(t, errorCode(err), "ConflictException")
+	_, err = logs.DeleteDelivery(ctx, &cloudwatchlogs.DeleteDeliveryInput{Id: delivery.Delivery.Id})
+	assert.NilError(t, err)
+	_, err = logs.DeleteDeliverySource(ctx, &cloudwatchlogs.DeleteDeliverySourceInput{Name: aws.String("source")})
+	assert.NilError(t, err)
+	_, err = logs.DeleteDeliveryDestination(ctx, &cloudwatchlogs.DeleteDeliveryDestinationInput{Name: aws.String("destination")})
+	assert.NilError(t, err)
+	sources, destinations := eb.DeliveryResources()
+	assert.Equal(t, sources, 0)
+	assert.Equal(t, destinations, 0)
+}
+
+func Test_EventBridge_FailNext(t *testing.T) {
+	ctx := context.Background()
+	eb := NewEventBridge()
+	client := svcsdk.NewFromConfig(eb.Config())
+
+	eb.FailNext("DescribeEventBus", "ThrottlingException", "Rate exceeded")
+	_, err := client.DescribeEventBus(ctx, &svcsdk.DescribeEventBusInput{})
+	assert.Equal(t, errorCode(err), "ThrottlingException")
+	assert.ErrorContains(t, err, "Rate exceeded")
+
+	_, err = client.DescribeEventBus(ctx, &svcsdk.DescribeEventBusInput{})
+	assert.NilError(t, err)
+	assert.DeepEqual(t, eb.Calls(), []string{"DescribeEventBus", "DescribeEventBus"})
+
+	eb.ResetCalls()
+	assert.Equal(t, len(eb.Calls()), 0)
+}
+
+func Test_EventBridge_Pagination(t *testing.T) {
+	ctx := context.Background()
+	eb := NewEventBridge()
+	client := svcsdk.NewFromConfig(eb.Config())
+
+	for i := 0; i < 5; i++ {
+		_, err := client.PutRule(ctx, &svcsdk.PutRuleInput{
+			Name:         aws.String(fmt.Sprintf("rule-%d", i)),
+			EventPattern: aws.String(testPattern),
+		})
+		assert.NilError(t, err)
+	}
+
+	names := []string{}
+	var token *string
+	for {
+		resp, err := client.ListRules(ctx, &svcsdk.ListRulesInput{Limit: aws.Int32(2), NextToken: token})
+		assert.NilError(t, err)
+		for _, r := range resp.Rules {
+			names = append(names, aws.ToString(r.Name))
+		}
+		if resp.NextToken == nil {
+			break
+		}
+		token = resp.NextToken
+	}
+	assert.DeepEqual(t, names, []string{"rule-0", "rule-1", "rule-2", "rule-3", "rule-4"})
+}
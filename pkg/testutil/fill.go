@@ -0,0 +1,121 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package testutil
+
+import (
+	"math/rand"
+	"reflect"
+	"testing"
+	"testing/quick"
+)
+
+const (
+	// maxFillLength is the maximum number of elements Fill puts in a slice or
+	// map
+	maxFillLength = 3
+	// maxFillDepth guards against infinite recursion on recursive types
+	maxFillDepth = 16
+	// maxFillInt keeps integers within the range of the int32 fields used by
+	// the SDK
+	maxFillInt = 1000
+
+	fillAlphabet = "abcdefghijklmnopqrstuvwxyz0123456789"
+
+	// propertyChecks is the number of random inputs CheckProperty tries
+	propertyChecks = 200
+)
+
+// CheckProperty runs prop against random inputs generated from seeds chosen
+// by testing/quick. prop returns a non-nil error if the property doesn't
+// hold, the failing seed is reported so the input can be reproduced.
+func CheckProperty(t testing.TB, prop func(rnd *rand.Rand) error) {
+	t.Helper()
+	f := func(seed int64) bool {
+		if err := prop(rand.New(rand.NewSource(seed))); err != nil {
+			t.Errorf("seed %d: %v", seed, err)
+			return false
+		}
+		return true
+	}
+	if err := quick.Check(f, &quick.Config{MaxCount: propertyChecks}); err != nil {
+		t.Error(err)
+	}
+}
+
+// Fill sets every exported field reachable from the pointer v to random
+// data: pointers are allocated, slices and maps get between one and three
+// elements and strings are non-empty. Use it to check that conversions
+// between the Kubernetes and the SDK types don't drop fields.
+func Fill(v any, rnd *rand.Rand) {
+	rv := reflect.ValueOf(v)
+	if rv.Kind() != reflect.Pointer || rv.IsNil() {
+		panic("testutil.Fill: v must be a non-nil pointer")
+	}
+	fill(rv.Elem(), rnd, 0)
+}
+
+func fill(v reflect.Value, rnd *rand.Rand, depth int) {
+	if depth > maxFillDepth || !v.CanSet() {
+		return
+	}
+	switch v.Kind() {
+	case reflect.Pointer:
+		p := reflect.New(v.Type().Elem())
+		fill(p.Elem(), rnd, depth+1)
+		v.Set(p)
+	case reflect.Struct:
+		for i := 0; i < v.NumField(); i++ {
+			if v.Type().Field(i).IsExported() {
+				fill(v.Field(i), rnd, depth+1)
+			}
+		}
+	case reflect.Slice:
+		n := 1 + rnd.Intn(maxFillLength)
+		s := reflect.MakeSlice(v.Type(), n, n)
+		for i := 0; i < n; i++ {
+			fill(s.Index(i), rnd, depth+1)
+		}
+		v.Set(s)
+	case reflect.Map:
+		n := 1 + rnd.Intn(maxFillLength)
+		m := reflect.MakeMapWithSize(v.Type(), n)
+		for i := 0; i < n; i++ {
+			key := reflect.New(v.Type().Key()).Elem()
+			fill(key, rnd, depth+1)
+			val := reflect.New(v.Type().Elem()).Elem()
+			fill(val, rnd, depth+1)
+			m.SetMapIndex(key, val)
+		}
+		v.Set(m)
+	case reflect.String:
+		v.SetString(RandomString(rnd, 1+rnd.Intn(12)))
+	case reflect.Bool:
+		v.SetBool(rnd.Intn(2) == 1)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		v.SetInt(rnd.Int63n(maxFillInt))
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		v.SetUint(uint64(rnd.Int63n(maxFillInt)))
+	case reflect.Float32, reflect.Float64:
+		v.SetFloat(float64(rnd.Int63n(maxFillInt)))
+	}
+}
+
+// RandomString returns a random lower case alphanumeric string of length n
+func RandomString(rnd *rand.Rand, n int) string {
+	b := make([]byte, n)
+	for i := range b {
+		b[i] = fillAlphabet[rnd.Intn(len(fillAlphabet))]
+	}
+	return string(b)
+}
@@ -0,0 +1,40 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package testutil
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"gotest.tools/v3/assert"
+
+	svcapitypes "github.com/aws-controllers-k8s/eventbridge-controller/apis/v1alpha1"
+)
+
+func TestFill(t *testing.T) {
+	var target svcapitypes.Target
+	Fill(&target, rand.New(rand.NewSource(1)))
+
+	assert.Assert(t, target.ID != nil && *target.ID != "")
+	assert.Assert(t, target.ECSParameters.NetworkConfiguration.AWSVPCConfiguration != nil)
+	assert.Assert(t, len(target.HTTPParameters.HeaderParameters) > 0)
+	assert.Assert(t, len(target.RunCommandParameters.RunCommandTargets) > 0)
+
+	// the same seed produces the same value
+	var again svcapitypes.Target
+	Fill(&again, rand.New(rand.NewSource(1)))
+	assert.DeepEqual(t, target, again)
+	assert.Assert(t, !cmp.Equal(target, svcapitypes.Target{}))
+}
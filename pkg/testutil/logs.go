@@ -0,0 +1,307 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package testutil
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/cloudwatchlogs"
+)
+
+// logsTargetPrefix is the prefix of the X-Amz-Target of the CloudWatch Logs
+// API, whose log delivery operations the fake implements for the delivery of
+// event bus logs
+const logsTargetPrefix = "Logs_20140328."
+
+type deliverySource struct {
+	name        string
+	arn         string
+	resourceARN string
+	logType     string
+}
+
+type deliveryDestination struct {
+	name        string
+	arn         string
+	resourceARN string
+}
+
+type delivery struct {
+	id             string
+	arn            string
+	sourceName     string
+	destinationARN string
+}
+
+// deliverySourceOutput is the wire representation of a delivery source.
+// CloudWatch Logs uses lowerCamel member names.
+type deliverySourceOutput struct {
+	Name         *string  `json:"name,omitempty"`
+	Arn          *string  `json:"arn,omitempty"`
+	ResourceArns []string `json:"resourceArns,omitempty"`
+	LogType      *string  `json:"logType,omitempty"`
+	Service      *string  `json:"service,omitempty"`
+}
+
+type deliveryDestinationConfigurationOutput struct {
+	DestinationResourceArn *string `json:"destinationResourceArn,omitempty"`
+}
+
+type deliveryDestinationOutput struct {
+	Name                             *string                                 `json:"name,omitempty"`
+	Arn                              *string                                 `json:"arn,omitempty"`
+	DeliveryDestinationType          *string                                 `json:"deliveryDestinationType,omitempty"`
+	DeliveryDestinationConfiguration *deliveryDestinationConfigurationOutput `json:"deliveryDestinationConfiguration,omitempty"`
+}
+
+type deliveryOutput struct {
+	Id                      *string `json:"id,omitempty"`
+	Arn                     *string `json:"arn,omitempty"`
+	DeliverySourceName      *string `json:"deliverySourceName,omitempty"`
+	DeliveryDestinationArn  *string `json:"deliveryDestinationArn,omitempty"`
+	DeliveryDestinationType *string `json:"deliveryDestinationType,omitempty"`
+}
+
+func (s *deliverySource) output() deliverySourceOutput {
+	return deliverySourceOutput{
+		Name:         aws.String(s.name),
+		Arn:          aws.String(s.arn),
+		ResourceArns: []string{s.resourceARN},
+		LogType:      aws.String(s.logType),
+		Service:      aws.String("events"),
+	}
+}
+
+func (d *deliveryDestination) output() deliveryDestinationOutput {
+	return deliveryDestinationOutput{
+		Name:                    aws.String(d.name),
+		Arn:                     aws.String(d.arn),
+		DeliveryDestinationType: aws.String(destinationType(d.resourceARN)),
+		DeliveryDestinationConfiguration: &deliveryDestinationConfigurationOutput{
+			DestinationResourceArn: aws.String(d.resourceARN),
+		},
+	}
+}
+
+// destinationType returns the type of a delivery destination from the service
+// of its resource
+func destinationType(resourceARN string) string {
+	switch {
+	case strings.Contains(resourceARN, ":firehose:"):
+		return "FH"
+	case strings.Contains(resourceARN, ":s3:"):
+		return "S3"
+	default:
+		return "CWL"
+	}
+}
+
+// logsARN returns the ARN of a CloudWatch Logs resource in the fake's account
+// and region, e.g. logsARN("delivery-source:my-source")
+func (eb *EventBridge) logsARN(resource string) string {
+	return fmt.Sprintf("arn:%s:logs:%s:%s:%s", eb.partition, eb.region, eb.accountID, resource)
+}
+
+func conflict(format string, args ...any) *APIError {
+	return newError(http.StatusBadRequest, "ConflictException", fmt.Sprintf(format, args...))
+}
+
+// busExists returns whether the event bus with the given ARN exists
+func (eb *EventBridge) busExists(busARN string) bool {
+	for _, b := range eb.buses {
+		if b.arn == busARN {
+			return true
+		}
+	}
+	return false
+}
+
+func (eb *EventBridge) putDeliverySource(in *cloudwatchlogs.PutDeliverySourceInput) (any, *APIError) {
+	name := aws.ToString(in.Name)
+	if name == "" || aws.ToString(in.ResourceArn) == "" || aws.ToString(in.LogType) == "" {
+		return nil, validation("name, resourceArn and logType are required")
+	}
+	if !eb.busExists(aws.ToString(in.ResourceArn)) {
+		return nil, notFound("Resource %s does not exist.", aws.ToString(in.ResourceArn))
+	}
+	if s, ok := eb.deliverySources[name]; ok && s.resourceARN != aws.ToString(in.ResourceArn) {
+		return nil, conflict("Delivery source %s already exists for another resource.", name)
+	}
+	s := &deliverySource{
+		name:        name,
+		arn:         eb.logsARN("delivery-source:" + name),
+		resourceARN: aws.ToString(in.ResourceArn),
+		logType:     aws.ToString(in.LogType),
+	}
+	eb.deliverySources[name] = s
+	return map[string]any{"deliverySource": s.output()}, nil
+}
+
+func (eb *EventBridge) deleteDeliverySource(in *cloudwatchlogs.DeleteDeliverySourceInput) (any, *APIError) {
+	name := aws.ToString(in.Name)
+	if _, ok := eb.deliverySources[name]; !ok {
+		return nil, notFound("Delivery source %s does not exist.", name)
+	}
+	for _, d := range eb.deliveries {
+		if d.sourceName == name {
+			return nil, conflict("Delivery source %s is used by delivery %s.", name, d.id)
+		}
+	}
+	delete(eb.deliverySources, name)
+	return struct{}{}, nil
+}
+
+func (eb *EventBridge) putDeliveryDestination(in *cloudwatchlogs.PutDeliveryDestinationInput) (any, *APIError) {
+	name := aws.ToString(in.Name)
+	if name == "" || in.DeliveryDestinationConfiguration == nil ||
+		aws.ToString(in.DeliveryDestinationConfiguration.DestinationResourceArn) == "" {
+		return nil, validation("name and deliveryDestinationConfiguration are required")
+	}
+	d := &deliveryDestination{
+		name:        name,
+		arn:         eb.logsARN("delivery-destination:" + name),
+		resourceARN: aws.ToString(in.DeliveryDestinationConfiguration.DestinationResourceArn),
+	}
+	eb.deliveryDestinations[name] = d
+	return map[string]any{"deliveryDestination": d.output()}, nil
+}
+
+func (eb *EventBridge) getDeliveryDestination(in *cloudwatchlogs.GetDeliveryDestinationInput) (any, *APIError) {
+	name := aws.ToString(in.Name)
+	d, ok := eb.deliveryDestinations[name]
+	if !ok {
+		return nil, notFound("Delivery destination %s does not exist.", name)
+	}
+	return map[string]any{"deliveryDestination": d.output()}, nil
+}
+
+func (eb *EventBridge) deleteDeliveryDestination(in *cloudwatchlogs.DeleteDeliveryDestinationInput) (any, *APIError) {
+	name := aws.ToString(in.Name)
+	d, ok := eb.deliveryDestinations[name]
+	if !ok {
+		return nil, notFound("Delivery destination %s does not exist.", name)
+	}
+	for _, del := range eb.deliveries {
+		if del.destinationARN == d.arn {
+			return nil, conflict("Delivery destination %s is used by delivery %s.", name, del.id)
+		}
+	}
+	delete(eb.deliveryDestinations, name)
+	return struct{}{}, nil
+}
+
+func (eb *EventBridge) createDelivery(in *cloudwatchlogs.CreateDeliveryInput) (any, *APIError) {
+	sourceName := aws.ToString(in.DeliverySourceName)
+	if _, ok := eb.deliverySources[sourceName]; !ok {
+		return nil, notFound("Delivery source %s does not exist.", sourceName)
+	}
+	destinationARN := aws.ToString(in.DeliveryDestinationArn)
+	var destination *deliveryDestination
+	for _, d := range eb.deliveryDestinations {
+		if d.arn == destinationARN {
+			destination = d
+		}
+	}
+	if destination == nil {
+		return nil, notFound("Delivery destination %s does not exist.", destinationARN)
+	}
+	for _, d := range eb.deliveries {
+		if d.sourceName == sourceName && d.destinationARN == destinationARN {
+			return nil, conflict("Delivery from %s to %s already exists.", sourceName, destinationARN)
+		}
+	}
+	eb.deliveryCount++
+	id := fmt.Sprintf("delivery%012d", eb.deliveryCount)
+	d := &delivery{
+		id:             id,
+		arn:            eb.logsARN("delivery:" + id),
+		sourceName:     sourceName,
+		destinationARN: destinationARN,
+	}
+	eb.deliveries[id] = d
+	return map[string]any{"delivery": eb.deliveryOutput(d)}, nil
+}
+
+func (eb *EventBridge) deliveryOutput(d *delivery) deliveryOutput {
+	out := deliveryOutput{
+		Id:                     aws.String(d.id),
+		Arn:                    aws.String(d.arn),
+		DeliverySourceName:     aws.String(d.sourceName),
+		DeliveryDestinationArn: aws.String(d.destinationARN),
+	}
+	for _, dest := range eb.deliveryDestinations {
+		if dest.arn == d.destinationARN {
+			out.DeliveryDestinationType = aws.String(destinationType(dest.resourceARN))
+		}
+	}
+	return out
+}
+
+func (eb *EventBridge) describeDeliveries(in *cloudwatchlogs.DescribeDeliveriesInput) (any, *APIError) {
+	deliveries := []deliveryOutput{}
+	for _, id := range sortedKeys(eb.deliveries) {
+		deliveries = append(deliveries, eb.deliveryOutput(eb.deliveries[id]))
+	}
+	page, next, err := paginate(deliveries, in.NextToken, aws.ToInt32(in.Limit))
+	if err != nil {
+		return nil, err
+	}
+	return map[string]any{
+		"deliveries": page,
+		"nextToken":  next,
+	}, nil
+}
+
+func (eb *EventBridge) deleteDelivery(in *cloudwatchlogs.DeleteDeliveryInput) (any, *APIError) {
+	id := aws.ToString(in.Id)
+	if _, ok := eb.deliveries[id]; !ok {
+		return nil, notFound("Delivery %s does not exist.", id)
+	}
+	delete(eb.deliveries, id)
+	return struct{}{}, nil
+}
+
+// LogDeliveries returns the ARNs of the resources the logs of the delivery
+// sources of the resource with the given ARN are delivered to, by log type
+func (eb *EventBridge) LogDeliveries(resourceARN string) map[string][]string {
+	eb.mu.Lock()
+	defer eb.mu.Unlock()
+
+	deliveries := map[string][]string{}
+	for _, id := range sortedKeys(eb.deliveries) {
+		d := eb.deliveries[id]
+		source, ok := eb.deliverySources[d.sourceName]
+		if !ok || source.resourceARN != resourceARN {
+			continue
+		}
+		for _, dest := range eb.deliveryDestinations {
+			if dest.arn == d.destinationARN {
+				deliveries[source.logType] = append(deliveries[source.logType], dest.resourceARN)
+			}
+		}
+	}
+	return deliveries
+}
+
+// DeliveryResources returns the number of delivery sources and delivery
+// destinations in the fake
+func (eb *EventBridge) DeliveryResources() (sources, destinations int) {
+	eb.mu.Lock()
+	defer eb.mu.Unlock()
+
+	return len(eb.deliverySources), len(eb.deliveryDestinations)
+}
@@ -0,0 +1,61 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package testutil
+
+import (
+	"fmt"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	svcsdk "github.com/aws/aws-sdk-go-v2/service/eventbridge"
+	svcsdktypes "github.com/aws/aws-sdk-go-v2/service/eventbridge/types"
+)
+
+// maxPutEventsEntries is the maximum number of entries of a PutEvents call
+const maxPutEventsEntries = 10
+
+func (eb *EventBridge) putEvents(in *svcsdk.PutEventsInput) (any, *APIError) {
+	if len(in.Entries) == 0 || len(in.Entries) > maxPutEventsEntries {
+		return nil, validation("1 validation error detected: Value at 'entries' failed to satisfy constraint: "+
+			"Member must have length less than or equal to %d", maxPutEventsEntries)
+	}
+
+	entries := make([]map[string]any, len(in.Entries))
+	failed := 0
+	for i, e := range in.Entries {
+		bus := eb.busARN(e.EventBusName)
+		if _, ok := eb.buses[bus]; !ok {
+			entries[i] = map[string]any{
+				"ErrorCode":    "NotFoundException",
+				"ErrorMessage": fmt.Sprintf("Event bus %s does not exist.", aws.ToString(e.EventBusName)),
+			}
+			failed++
+			continue
+		}
+		eb.events[bus] = append(eb.events[bus], e)
+		eb.eventCount++
+		entries[i] = map[string]any{"EventId": fmt.Sprintf("event-%d", eb.eventCount)}
+	}
+	return map[string]any{
+		"FailedEntryCount": failed,
+		"Entries":          entries,
+	}, nil
+}
+
+// Events returns the events put on the event bus with the given name
+func (eb *EventBridge) Events(bus string) []svcsdktypes.PutEventsRequestEntry {
+	eb.mu.Lock()
+	defer eb.mu.Unlock()
+
+	return append([]svcsdktypes.PutEventsRequestEntry(nil), eb.events[eb.busARN(aws.String(bus))]...)
+}
@@ -0,0 +1,298 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package testutil
+
+import (
+	"encoding/json"
+	"net/http"
+	"sort"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	svcsdk "github.com/aws/aws-sdk-go-v2/service/eventbridge"
+	svcsdktypes "github.com/aws/aws-sdk-go-v2/service/eventbridge/types"
+)
+
+// maxTargetsPerRule is the service quota of targets per rule
+const maxTargetsPerRule = 5
+
+type ruleKey struct {
+	bus  string
+	name string
+}
+
+type rule struct {
+	name               string
+	bus                string
+	arn                string
+	description        *string
+	eventPattern       *string
+	roleArn            *string
+	scheduleExpression *string
+	state              svcsdktypes.RuleState
+	// targets are kept in their wire representation so that fields whose
+	// wire names differ from the SDK field names (e.g. awsvpcConfiguration)
+	// round-trip unchanged
+	targets map[string]json.RawMessage
+}
+
+// ruleOutput is the wire representation of a rule, shared by the DescribeRule
+// and ListRules responses
+type ruleOutput struct {
+	Arn                *string               `json:"Arn,omitempty"`
+	Name               *string               `json:"Name,omitempty"`
+	EventBusName       *string               `json:"EventBusName,omitempty"`
+	Description        *string               `json:"Description,omitempty"`
+	EventPattern       *string               `json:"EventPattern,omitempty"`
+	RoleArn            *string               `json:"RoleArn,omitempty"`
+	ScheduleExpression *string               `json:"ScheduleExpression,omitempty"`
+	State              svcsdktypes.RuleState `json:"State,omitempty"`
+}
+
+func (r *rule) output() ruleOutput {
+	return ruleOutput{
+		Arn:                aws.String(r.arn),
+		Name:               aws.String(r.name),
+		EventBusName:       aws.String(r.bus),
+		Description:        r.description,
+		EventPattern:       r.eventPattern,
+		RoleArn:            r.roleArn,
+		ScheduleExpression: r.scheduleExpression,
+		State:              r.state,
+	}
+}
+
+// ruleARN returns the ARN of a rule. Rules on the default event bus omit the
+// event bus name.
+func (eb *EventBridge) ruleARN(bus, name string) string {
+	if bus == defaultBusName {
+		return eb.arn("rule/" + name)
+	}
+	return eb.arn("rule/" + bus + "/" + name)
+}
+
+func (eb *EventBridge) getRule(bus *string, name *string) (*rule, *APIError) {
+	b, ok := eb.buses[eb.busARN(bus)]
+	if !ok {
+		return nil, notFound("Rule %s does not exist on EventBus %s.", aws.ToString(name), aws.ToString(bus))
+	}
+	r, ok := eb.rules[ruleKey{bus: b.name, name: aws.ToString(name)}]
+	if !ok {
+		return nil, notFound("Rule %s does not exist on EventBus %s.", aws.ToString(name), b.name)
+	}
+	return r, nil
+}
+
+func (eb *EventBridge) putRule(in *svcsdk.PutRuleInput) (any, *APIError) {
+	name := aws.ToString(in.Name)
+	if name == "" {
+		return nil, validation("1 validation error detected: Value null at 'name' failed to satisfy constraint: Member must not be null")
+	}
+	b, err := eb.getEventBus(in.EventBusName)
+	if err != nil {
+		return nil, err
+	}
+	if in.EventPattern == nil && in.ScheduleExpression == nil {
+		return nil, validation("Parameter(s) EventPattern or ScheduleExpression must be specified.")
+	}
+	if in.ScheduleExpression != nil && b.name != defaultBusName {
+		return nil, validation("ScheduleExpression is supported only on the default event bus.")
+	}
+	if err := validEventPattern(in.EventPattern); err != nil {
+		return nil, err
+	}
+	state := in.State
+	if state == "" {
+		state = svcsdktypes.RuleStateEnabled
+	}
+
+	key := ruleKey{bus: b.name, name: name}
+	r, ok := eb.rules[key]
+	if !ok {
+		if err := validTags(in.Tags); err != nil {
+			return nil, err
+		}
+		r = &rule{
+			name:    name,
+			bus:     b.name,
+			arn:     eb.ruleARN(b.name, name),
+			targets: map[string]json.RawMessage{},
+		}
+		eb.rules[key] = r
+		// PutRule only applies tags when the rule is created
+		eb.addTags(r.arn, in.Tags)
+	}
+	r.description = in.Description
+	r.eventPattern = in.EventPattern
+	r.roleArn = in.RoleArn
+	r.scheduleExpression = in.ScheduleExpression
+	r.state = state
+
+	return map[string]any{
+		"RuleArn": r.arn,
+	}, nil
+}
+
+func (eb *EventBridge) describeRule(in *svcsdk.DescribeRuleInput) (any, *APIError) {
+	r, err := eb.getRule(in.EventBusName, in.Name)
+	if err != nil {
+		return nil, err
+	}
+	return r.output(), nil
+}
+
+func (eb *EventBridge) deleteRule(in *svcsdk.DeleteRuleInput) (any, *APIError) {
+	r, err := eb.getRule(in.EventBusName, in.Name)
+	if err != nil {
+		// DeleteRule succeeds for rules which do not exist
+		return struct{}{}, nil
+	}
+	key := ruleKey{bus: r.bus, name: r.name}
+	if len(r.targets) > 0 {
+		return nil, validation("Rule can't be deleted since it has targets.")
+	}
+	delete(eb.rules, key)
+	delete(eb.tags, r.arn)
+	return struct{}{}, nil
+}
+
+func (eb *EventBridge) listRules(in *svcsdk.ListRulesInput) (any, *APIError) {
+	b, err := eb.getEventBus(in.EventBusName)
+	if err != nil {
+		return nil, err
+	}
+	rules := []ruleOutput{}
+	for _, r := range eb.rulesOn(b.name) {
+		if strings.HasPrefix(r.name, aws.ToString(in.NamePrefix)) {
+			rules = append(rules, r.output())
+		}
+	}
+	page, next, err := paginate(rules, in.NextToken, aws.ToInt32(in.Limit))
+	if err != nil {
+		return nil, err
+	}
+	return map[string]any{
+		"Rules":     page,
+		"NextToken": next,
+	}, nil
+}
+
+// rulesOn returns the rules on the given event bus sorted by name
+func (eb *EventBridge) rulesOn(bus string) []*rule {
+	rules := []*rule{}
+	for key, r := range eb.rules {
+		if key.bus == bus {
+			rules = append(rules, r)
+		}
+	}
+	sort.Slice(rules, func(i, j int) bool {
+		return rules[i].name < rules[j].name
+	})
+	return rules
+}
+
+// putTargetsInput is PutTargetsInput with the targets kept in their wire
+// representation
+type putTargetsInput struct {
+	Rule         *string
+	EventBusName *string
+	Targets      []json.RawMessage
+}
+
+// targetID decodes the fields of a target needed to validate it
+type targetID struct {
+	Id  *string
+	Arn *string
+}
+
+func (eb *EventBridge) putTargets(in *putTargetsInput) (any, *APIError) {
+	r, err := eb.getRule(in.EventBusName, in.Rule)
+	if err != nil {
+		return nil, err
+	}
+
+	ids := make([]string, 0, len(in.Targets))
+	for _, raw := range in.Targets {
+		var t targetID
+		if err := json.Unmarshal(raw, &t); err != nil {
+			return nil, newError(http.StatusBadRequest, "SerializationException", err.Error())
+		}
+		if aws.ToString(t.Id) == "" || aws.ToString(t.Arn) == "" {
+			return nil, validation("1 validation error detected: Target Id and Arn must not be null")
+		}
+		ids = append(ids, *t.Id)
+	}
+
+	added := 0
+	for _, id := range ids {
+		if _, ok := r.targets[id]; !ok {
+			added++
+		}
+	}
+	if len(r.targets)+added > maxTargetsPerRule {
+		return nil, newError(http.StatusBadRequest, "LimitExceededException",
+			"The requested resource exceeds the maximum number allowed.")
+	}
+
+	failed := []svcsdktypes.PutTargetsResultEntry{}
+	for i, id := range ids {
+		if f, ok := eb.targetFailures[id]; ok {
+			failed = append(failed, svcsdktypes.PutTargetsResultEntry{
+				TargetId:     aws.String(id),
+				ErrorCode:    aws.String(f.code),
+				ErrorMessage: aws.String(f.message),
+			})
+			continue
+		}
+		r.targets[id] = in.Targets[i]
+	}
+
+	return map[string]any{
+		"FailedEntryCount": len(failed),
+		"FailedEntries":    failed,
+	}, nil
+}
+
+func (eb *EventBridge) removeTargets(in *svcsdk.RemoveTargetsInput) (any, *APIError) {
+	r, err := eb.getRule(in.EventBusName, in.Rule)
+	if err != nil {
+		return nil, err
+	}
+	for _, id := range in.Ids {
+		delete(r.targets, id)
+	}
+	return map[string]any{
+		"FailedEntryCount": 0,
+		"FailedEntries":    []svcsdktypes.RemoveTargetsResultEntry{},
+	}, nil
+}
+
+func (eb *EventBridge) listTargetsByRule(in *svcsdk.ListTargetsByRuleInput) (any, *APIError) {
+	r, err := eb.getRule(in.EventBusName, in.Rule)
+	if err != nil {
+		return nil, err
+	}
+	targets := []json.RawMessage{}
+	for _, id := range sortedKeys(r.targets) {
+		targets = append(targets, r.targets[id])
+	}
+	page, next, err := paginate(targets, in.NextToken, aws.ToInt32(in.Limit))
+	if err != nil {
+		return nil, err
+	}
+	return map[string]any{
+		"Targets":   page,
+		"NextToken": next,
+	}, nil
+}
@@ -0,0 +1,101 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package testutil
+
+import (
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	svcsdk "github.com/aws/aws-sdk-go-v2/service/eventbridge"
+	svcsdktypes "github.com/aws/aws-sdk-go-v2/service/eventbridge/types"
+)
+
+// awsTagPrefix is the prefix of AWS managed tag keys, which can't be set or
+// removed through the API
+const awsTagPrefix = "aws:"
+
+// validTags returns a ValidationException if any of the tags uses a reserved
+// key
+func validTags(tags []svcsdktypes.Tag) *APIError {
+	for _, t := range tags {
+		if strings.HasPrefix(aws.ToString(t.Key), awsTagPrefix) {
+			return validation("Invalid tag key %q: keys prefixed with %q are reserved.", aws.ToString(t.Key), awsTagPrefix)
+		}
+	}
+	return nil
+}
+
+func (eb *EventBridge) addTags(arn string, tags []svcsdktypes.Tag) {
+	if len(tags) == 0 {
+		return
+	}
+	if eb.tags[arn] == nil {
+		eb.tags[arn] = map[string]string{}
+	}
+	for _, t := range tags {
+		eb.tags[arn][aws.ToString(t.Key)] = aws.ToString(t.Value)
+	}
+}
+
+// resourceExists returns true if an event bus or rule with the given ARN
+// exists. Archives and endpoints don't support tagging.
+func (eb *EventBridge) resourceExists(arn string) bool {
+	if _, ok := eb.buses[arn]; ok {
+		return true
+	}
+	for _, r := range eb.rules {
+		if r.arn == arn {
+			return true
+		}
+	}
+	return false
+}
+
+func (eb *EventBridge) tagResource(in *svcsdk.TagResourceInput) (any, *APIError) {
+	arn := aws.ToString(in.ResourceARN)
+	if !eb.resourceExists(arn) {
+		return nil, notFound("Resource %s does not exist.", arn)
+	}
+	if err := validTags(in.Tags); err != nil {
+		return nil, err
+	}
+	eb.addTags(arn, in.Tags)
+	return struct{}{}, nil
+}
+
+func (eb *EventBridge) untagResource(in *svcsdk.UntagResourceInput) (any, *APIError) {
+	arn := aws.ToString(in.ResourceARN)
+	if !eb.resourceExists(arn) {
+		return nil, notFound("Resource %s does not exist.", arn)
+	}
+	for _, k := range in.TagKeys {
+		if strings.HasPrefix(k, awsTagPrefix) {
+			return nil, validation("Invalid tag key %q: keys prefixed with %q are reserved.", k, awsTagPrefix)
+		}
+	}
+	for _, k := range in.TagKeys {
+		delete(eb.tags[arn], k)
+	}
+	return struct{}{}, nil
+}
+
+func (eb *EventBridge) listTagsForResource(in *svcsdk.ListTagsForResourceInput) (any, *APIError) {
+	arn := aws.ToString(in.ResourceARN)
+	if !eb.resourceExists(arn) {
+		return nil, notFound("Resource %s does not exist.", arn)
+	}
+	return map[string]any{
+		"Tags": eb.sdkTags(arn),
+	}, nil
+}
@@ -0,0 +1,101 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package transformer
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Path is a JSONPath of the subset supported by EventBridge input paths: the
+// root $ followed by member names, e.g. .detail or ['detail-type'], and array
+// indexes, e.g. [0]. Wildcards, filters and slices aren't supported.
+type Path []segment
+
+type segment struct {
+	name  string
+	index int
+	// isIndex is true for array index segments
+	isIndex bool
+}
+
+// ParsePath parses a JSONPath
+func ParsePath(s string) (Path, error) {
+	if !strings.HasPrefix(s, "$") {
+		return nil, fmt.Errorf("invalid path %q: must start with $", s)
+	}
+	var p Path
+	rest := s[1:]
+	for rest != "" {
+		switch {
+		case strings.HasPrefix(rest, "."):
+			end := strings.IndexAny(rest[1:], ".[")
+			if end < 0 {
+				end = len(rest) - 1
+			}
+			name := rest[1 : end+1]
+			if name == "" || name == "*" {
+				return nil, fmt.Errorf("invalid path %q: expected a member name at %q", s, rest)
+			}
+			p = append(p, segment{name: name})
+			rest = rest[end+1:]
+		case strings.HasPrefix(rest, "['"):
+			end := strings.Index(rest, "']")
+			if end < 0 {
+				return nil, fmt.Errorf("invalid path %q: unterminated member name at %q", s, rest)
+			}
+			p = append(p, segment{name: rest[2:end]})
+			rest = rest[end+2:]
+		case strings.HasPrefix(rest, "["):
+			end := strings.Index(rest, "]")
+			if end < 0 {
+				return nil, fmt.Errorf("invalid path %q: unterminated index at %q", s, rest)
+			}
+			i, err := strconv.Atoi(rest[1:end])
+			if err != nil || i < 0 {
+				return nil, fmt.Errorf("invalid path %q: unsupported index %q", s, rest[1:end])
+			}
+			p = append(p, segment{index: i, isIndex: true})
+			rest = rest[end+1:]
+		default:
+			return nil, fmt.Errorf("invalid path %q: unexpected %q", s, rest)
+		}
+	}
+	return p, nil
+}
+
+// Eval returns the value at the path in the decoded JSON document, and
+// whether it exists
+func (p Path) Eval(doc interface{}) (interface{}, bool) {
+	v := doc
+	for _, seg := range p {
+		if seg.isIndex {
+			a, ok := v.([]interface{})
+			if !ok || seg.index >= len(a) {
+				return nil, false
+			}
+			v = a[seg.index]
+			continue
+		}
+		m, ok := v.(map[string]interface{})
+		if !ok {
+			return nil, false
+		}
+		if v, ok = m[seg.name]; !ok {
+			return nil, false
+		}
+	}
+	return v, true
+}
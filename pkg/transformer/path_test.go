@@ -0,0 +1,64 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package transformer
+
+import (
+	"encoding/json"
+	"testing"
+
+	"gotest.tools/v3/assert"
+)
+
+func TestPath(t *testing.T) {
+	var event interface{}
+	assert.NilError(t, json.Unmarshal([]byte(`{
+  "detail-type": "OrderCreated",
+  "detail": {"id": "o-1", "items": [{"sku": "a"}, {"sku": "b"}], "total.eur": 10}
+}`), &event))
+
+	tests := []struct {
+		path    string
+		want    interface{}
+		wantOK  bool
+		wantErr string
+	}{
+		{path: "$", want: event, wantOK: true},
+		{path: "$.detail.id", want: "o-1", wantOK: true},
+		{path: "$['detail-type']", want: "OrderCreated", wantOK: true},
+		{path: "$.detail.items[1].sku", want: "b", wantOK: true},
+		{path: "$.detail['total.eur']", want: float64(10), wantOK: true},
+		{path: "$.detail.items[2]"},
+		{path: "$.detail.id.nested"},
+		{path: "$.missing"},
+		{path: "detail", wantErr: "must start with $"},
+		{path: "$.detail.*", wantErr: "expected a member name"},
+		{path: "$.detail.items[*]", wantErr: "unsupported index"},
+		{path: "$.detail[", wantErr: "unterminated index"},
+		{path: "$['detail", wantErr: "unterminated member name"},
+		{path: "$detail", wantErr: "unexpected"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			p, err := ParsePath(tt.path)
+			if tt.wantErr != "" {
+				assert.ErrorContains(t, err, tt.wantErr)
+				return
+			}
+			assert.NilError(t, err)
+			got, ok := p.Eval(event)
+			assert.Equal(t, ok, tt.wantOK)
+			assert.DeepEqual(t, got, tt.want)
+		})
+	}
+}
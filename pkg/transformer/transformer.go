@@ -0,0 +1,311 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+// Package transformer validates target input transformers and renders the
+// input EventBridge sends to a target for an event, the same way EventBridge
+// does: values of placeholders outside of JSON strings are inserted as JSON,
+// so strings are quoted, and values of placeholders within JSON strings are
+// inserted as escaped text.
+package transformer
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	svcapitypes "github.com/aws-controllers-k8s/eventbridge-controller/apis/v1alpha1"
+)
+
+const (
+	maxPaths          = 100
+	maxKeyLength      = 256
+	maxPathLength     = 256
+	maxTemplateLength = 8192
+)
+
+// Predefined placeholders, which can be used without an input paths map
+// entry
+const (
+	PlaceholderRuleARN       = "aws.events.rule-arn"
+	PlaceholderRuleName      = "aws.events.rule-name"
+	PlaceholderIngestionTime = "aws.events.event.ingestion-time"
+	PlaceholderEvent         = "aws.events.event"
+	PlaceholderEventJSON     = "aws.events.event.json"
+)
+
+var (
+	keyRegexp         = regexp.MustCompile(`^[A-Za-z0-9_\-]+$`)
+	placeholderRegexp = regexp.MustCompile(`^<([A-Za-z0-9_.\-]+)>`)
+)
+
+// Context provides the values of the predefined placeholders
+type Context struct {
+	RuleARN  string
+	RuleName string
+	// IngestionTime defaults to the time of the event
+	IngestionTime time.Time
+}
+
+// token is a part of an input template, either literal text or a placeholder
+type token struct {
+	text        string
+	placeholder string
+	// quoted is true for placeholders within a JSON string
+	quoted bool
+}
+
+// Validate validates the input paths and the template of the transformer. A
+// template starting like a JSON value must be valid JSON with any value
+// inserted for its placeholders.
+func Validate(it *svcapitypes.InputTransformer) error {
+	if it == nil {
+		return nil
+	}
+	if len(it.InputPathsMap) > maxPaths {
+		return fmt.Errorf("inputPathsMap has %d entries, at most %d are supported", len(it.InputPathsMap), maxPaths)
+	}
+	for _, key := range sortedKeys(it.InputPathsMap) {
+		if len(key) > maxKeyLength || !keyRegexp.MatchString(key) {
+			return fmt.Errorf("inputPathsMap key %q must be at most %d letters, digits, '_' or '-'", key, maxKeyLength)
+		}
+		path := it.InputPathsMap[key]
+		if path == nil || len(*path) > maxPathLength {
+			return fmt.Errorf("inputPathsMap path of %q must be set and at most %d characters", key, maxPathLength)
+		}
+		if _, err := ParsePath(*path); err != nil {
+			return fmt.Errorf("inputPathsMap path of %q: %w", key, err)
+		}
+	}
+
+	template := ""
+	if it.InputTemplate != nil {
+		template = *it.InputTemplate
+	}
+	if template == "" || len(template) > maxTemplateLength {
+		return fmt.Errorf("inputTemplate must be set and at most %d characters", maxTemplateLength)
+	}
+	tokens := tokenize(template)
+	for _, t := range tokens {
+		if t.placeholder == "" || predefined(t.placeholder) {
+			continue
+		}
+		if _, ok := it.InputPathsMap[t.placeholder]; !ok {
+			return fmt.Errorf("inputTemplate placeholder <%s> is not declared in inputPathsMap", t.placeholder)
+		}
+	}
+
+	if isJSON(template) {
+		// any value is valid JSON outside of strings, and any text within
+		// strings, so null and an empty string show whether the template
+		// itself is valid
+		var b strings.Builder
+		for _, t := range tokens {
+			switch {
+			case t.placeholder == "":
+				b.WriteString(t.text)
+			case !t.quoted:
+				b.WriteString("null")
+			}
+		}
+		if !json.Valid([]byte(b.String())) {
+			return fmt.Errorf("inputTemplate is not valid JSON with values inserted for its placeholders")
+		}
+	}
+	return nil
+}
+
+// Render returns the input EventBridge sends to the target for the event
+func Render(it *svcapitypes.InputTransformer, event []byte, ctx Context) ([]byte, error) {
+	if err := Validate(it); err != nil {
+		return nil, err
+	}
+	doc, err := decode(event)
+	if err != nil {
+		return nil, err
+	}
+	template := *it.InputTemplate
+	jsonTemplate := isJSON(template)
+
+	var b strings.Builder
+	for _, t := range tokenize(template) {
+		if t.placeholder == "" {
+			b.WriteString(t.text)
+			continue
+		}
+		v, ok := placeholderValue(it, t.placeholder, doc, ctx)
+		s, err := formatValue(v, ok, t.quoted, jsonTemplate)
+		if err != nil {
+			return nil, err
+		}
+		b.WriteString(s)
+	}
+
+	out := []byte(b.String())
+	if jsonTemplate && !json.Valid(out) {
+		return nil, fmt.Errorf("rendered input is not valid JSON: %s", out)
+	}
+	return out, nil
+}
+
+// RenderTarget returns the input EventBridge sends to the target for the
+// event, which is the event itself unless the target has an input, input path
+// or input transformer
+func RenderTarget(t *svcapitypes.Target, event []byte, ctx Context) ([]byte, error) {
+	switch {
+	case t.Input != nil:
+		return []byte(*t.Input), nil
+	case t.InputTransformer != nil:
+		return Render(t.InputTransformer, event, ctx)
+	}
+	doc, err := decode(event)
+	if err != nil {
+		return nil, err
+	}
+	if t.InputPath != nil {
+		p, err := ParsePath(*t.InputPath)
+		if err != nil {
+			return nil, err
+		}
+		v, ok := p.Eval(doc)
+		if !ok {
+			return nil, fmt.Errorf("inputPath %q doesn't match the event", *t.InputPath)
+		}
+		doc = v
+	}
+	return json.Marshal(doc)
+}
+
+// tokenize splits the template into literal text and placeholders
+func tokenize(template string) []token {
+	var (
+		tokens   []token
+		text     strings.Builder
+		inString bool
+		escaped  bool
+	)
+	for i := 0; i < len(template); i++ {
+		c := template[i]
+		if c == '<' {
+			if m := placeholderRegexp.FindStringSubmatch(template[i:]); m != nil {
+				if text.Len() > 0 {
+					tokens = append(tokens, token{text: text.String()})
+					text.Reset()
+				}
+				tokens = append(tokens, token{placeholder: m[1], quoted: inString})
+				i += len(m[0]) - 1
+				escaped = false
+				continue
+			}
+		}
+		switch {
+		case escaped:
+			escaped = false
+		case c == '\\' && inString:
+			escaped = true
+		case c == '"':
+			inString = !inString
+		}
+		text.WriteByte(c)
+	}
+	if text.Len() > 0 {
+		tokens = append(tokens, token{text: text.String()})
+	}
+	return tokens
+}
+
+// placeholderValue returns the value of the placeholder for the decoded
+// event, and whether it exists
+func placeholderValue(it *svcapitypes.InputTransformer, name string, event interface{}, ctx Context) (interface{}, bool) {
+	switch name {
+	case PlaceholderRuleARN:
+		return ctx.RuleARN, true
+	case PlaceholderRuleName:
+		return ctx.RuleName, true
+	case PlaceholderIngestionTime:
+		if !ctx.IngestionTime.IsZero() {
+			return ctx.IngestionTime.UTC().Format(time.RFC3339), true
+		}
+		return Path{{name: "time"}}.Eval(event)
+	case PlaceholderEvent, PlaceholderEventJSON:
+		return event, true
+	}
+	// the path was validated
+	p, _ := ParsePath(*it.InputPathsMap[name])
+	return p.Eval(event)
+}
+
+// formatValue formats the value of a placeholder. Missing values are null
+// outside of strings and empty within strings.
+func formatValue(v interface{}, ok, quoted, jsonTemplate bool) (string, error) {
+	if !ok {
+		if quoted || !jsonTemplate {
+			return "", nil
+		}
+		return "null", nil
+	}
+	s, isString := v.(string)
+	if !isString || (jsonTemplate && !quoted) {
+		b, err := json.Marshal(v)
+		if err != nil {
+			return "", err
+		}
+		s = string(b)
+	}
+	if !quoted {
+		return s, nil
+	}
+	// escape the text for the JSON string
+	b, err := json.Marshal(s)
+	if err != nil {
+		return "", err
+	}
+	return string(b[1 : len(b)-1]), nil
+}
+
+// decode decodes the JSON event, keeping numbers as they are
+func decode(event []byte) (interface{}, error) {
+	dec := json.NewDecoder(bytes.NewReader(event))
+	dec.UseNumber()
+	var doc interface{}
+	if err := dec.Decode(&doc); err != nil {
+		return nil, fmt.Errorf("event is not valid JSON: %w", err)
+	}
+	return doc, nil
+}
+
+func predefined(name string) bool {
+	switch name {
+	case PlaceholderRuleARN, PlaceholderRuleName, PlaceholderIngestionTime, PlaceholderEvent, PlaceholderEventJSON:
+		return true
+	}
+	return false
+}
+
+// isJSON returns whether the template is a JSON template, rather than text
+func isJSON(template string) bool {
+	s := strings.TrimSpace(template)
+	return strings.HasPrefix(s, "{") || strings.HasPrefix(s, "[") || strings.HasPrefix(s, `"`)
+}
+
+func sortedKeys(m map[string]*string) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
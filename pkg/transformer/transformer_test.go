@@ -0,0 +1,213 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package transformer
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"gotest.tools/v3/assert"
+
+	svcapitypes "github.com/aws-controllers-k8s/eventbridge-controller/apis/v1alpha1"
+)
+
+const sampleEvent = `{
+  "id": "e-1",
+  "time": "2024-01-02T03:04:05Z",
+  "detail-type": "OrderCreated",
+  "detail": {"id": "o-1", "total": 10.50, "items": ["a", "b"], "note": "say \"hi\""}
+}`
+
+func transformer(template string, paths map[string]string) *svcapitypes.InputTransformer {
+	it := &svcapitypes.InputTransformer{InputTemplate: aws.String(template)}
+	if paths != nil {
+		it.InputPathsMap = make(map[string]*string)
+		for k, v := range paths {
+			it.InputPathsMap[k] = aws.String(v)
+		}
+	}
+	return it
+}
+
+func TestValidate(t *testing.T) {
+	tests := []struct {
+		name    string
+		it      *svcapitypes.InputTransformer
+		wantErr string
+	}{
+		{
+			name: "nil",
+		},
+		{
+			name: "json template",
+			it:   transformer(`{"id": <id>, "message": "order <id> created", "rule": <aws.events.rule-name>}`, map[string]string{"id": "$.detail.id"}),
+		},
+		{
+			name: "text template",
+			it:   transformer(`order <id> created`, map[string]string{"id": "$.detail.id"}),
+		},
+		{
+			name: "quoted text template",
+			it:   transformer(`"order <id> created"`, map[string]string{"id": "$.detail.id"}),
+		},
+		{
+			name:    "no template",
+			it:      transformer("", nil),
+			wantErr: "inputTemplate must be set",
+		},
+		{
+			name:    "undeclared placeholder",
+			it:      transformer(`{"id": <id>, "total": <total>}`, map[string]string{"id": "$.detail.id"}),
+			wantErr: "placeholder <total> is not declared",
+		},
+		{
+			name:    "invalid path",
+			it:      transformer(`{"id": <id>}`, map[string]string{"id": "detail.id"}),
+			wantErr: `inputPathsMap path of "id": invalid path "detail.id"`,
+		},
+		{
+			name:    "invalid key",
+			it:      transformer(`{"id": <id>}`, map[string]string{"id": "$.detail.id", "a.b": "$.detail"}),
+			wantErr: `inputPathsMap key "a.b"`,
+		},
+		{
+			name:    "placeholder within text",
+			it:      transformer(`{"message": order <id> created}`, map[string]string{"id": "$.detail.id"}),
+			wantErr: "inputTemplate is not valid JSON",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := Validate(tt.it)
+			if tt.wantErr != "" {
+				assert.ErrorContains(t, err, tt.wantErr)
+				return
+			}
+			assert.NilError(t, err)
+		})
+	}
+}
+
+func TestRender(t *testing.T) {
+	ctx := Context{RuleARN: "arn:aws:events:us-west-2:123456789012:rule/orders", RuleName: "orders"}
+	paths := map[string]string{
+		"id":      "$.detail.id",
+		"total":   "$.detail.total",
+		"items":   "$.detail.items",
+		"note":    "$.detail.note",
+		"missing": "$.detail.missing",
+	}
+	tests := []struct {
+		name     string
+		template string
+		event    string
+		want     string
+		wantErr  string
+	}{
+		{
+			name:     "values outside strings are JSON",
+			template: `{"id": <id>, "total": <total>, "items": <items>, "missing": <missing>}`,
+			want:     `{"id": "o-1", "total": 10.50, "items": ["a","b"], "missing": null}`,
+		},
+		{
+			name:     "values within strings are escaped text",
+			template: `{"message": "order <id> of <total> with <items>: <note><missing>"}`,
+			want:     `{"message": "order o-1 of 10.50 with [\"a\",\"b\"]: say \"hi\""}`,
+		},
+		{
+			name:     "text template",
+			template: `order <id> of <total>`,
+			want:     `order o-1 of 10.50`,
+		},
+		{
+			name:     "predefined placeholders",
+			template: `{"rule": <aws.events.rule-arn>, "name": "<aws.events.rule-name>", "time": <aws.events.event.ingestion-time>, "event": <aws.events.event.json>}`,
+			event:    `{"time": "2024-01-02T03:04:05Z", "detail": {}}`,
+			want:     `{"rule": "arn:aws:events:us-west-2:123456789012:rule/orders", "name": "orders", "time": "2024-01-02T03:04:05Z", "event": {"detail":{},"time":"2024-01-02T03:04:05Z"}}`,
+		},
+		{
+			name:     "invalid event",
+			template: `{"id": <id>}`,
+			event:    `{`,
+			wantErr:  "event is not valid JSON",
+		},
+		{
+			name:     "invalid transformer",
+			template: `{"id": <unknown>}`,
+			wantErr:  "placeholder <unknown> is not declared",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			event := tt.event
+			if event == "" {
+				event = sampleEvent
+			}
+			got, err := Render(transformer(tt.template, paths), []byte(event), ctx)
+			if tt.wantErr != "" {
+				assert.ErrorContains(t, err, tt.wantErr)
+				return
+			}
+			assert.NilError(t, err)
+			assert.Equal(t, string(got), tt.want)
+		})
+	}
+}
+
+func TestRenderTarget(t *testing.T) {
+	tests := []struct {
+		name    string
+		target  *svcapitypes.Target
+		want    string
+		wantErr string
+	}{
+		{
+			name:   "event",
+			target: &svcapitypes.Target{},
+			want:   `{"detail":{"id":"o-1","items":["a","b"],"note":"say \"hi\"","total":10.50},"detail-type":"OrderCreated","id":"e-1","time":"2024-01-02T03:04:05Z"}`,
+		},
+		{
+			name:   "input",
+			target: &svcapitypes.Target{Input: aws.String(`{"static": true}`)},
+			want:   `{"static": true}`,
+		},
+		{
+			name:   "input path",
+			target: &svcapitypes.Target{InputPath: aws.String("$.detail.items")},
+			want:   `["a","b"]`,
+		},
+		{
+			name:    "input path not matching",
+			target:  &svcapitypes.Target{InputPath: aws.String("$.detail.missing")},
+			wantErr: "doesn't match the event",
+		},
+		{
+			name:   "input transformer",
+			target: &svcapitypes.Target{InputTransformer: transformer(`{"id": <id>}`, map[string]string{"id": "$.detail.id"})},
+			want:   `{"id": "o-1"}`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := RenderTarget(tt.target, []byte(sampleEvent), Context{})
+			if tt.wantErr != "" {
+				assert.ErrorContains(t, err, tt.wantErr)
+				return
+			}
+			assert.NilError(t, err)
+			assert.Equal(t, strings.TrimSpace(string(got)), tt.want)
+		})
+	}
+}
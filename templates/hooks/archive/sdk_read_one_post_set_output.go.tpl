@@ -0,0 +1 @@
+observeState(ko)
@@ -0,0 +1,3 @@
+if err = rm.setPrimaryHealthCheck(ctx, desired.ko, input.RoutingConfig); err != nil {
+	return nil, err
+}
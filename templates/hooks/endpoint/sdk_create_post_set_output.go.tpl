@@ -1,3 +1,4 @@
+rm.keepHealthCheckRef(ctx, desired.ko, ko)
 if !endpointAvailable(&resource{ko}) {
 	return &resource{ko}, requeueWaitWhileCreating
 }
@@ -1,3 +1,11 @@
 if err = validateEndpointSpec(nil,desired.ko.Spec); err != nil {
 		return nil, ackerr.NewTerminalError(err)
 }
+if err = rm.validateEventBusRegions(desired.ko); err != nil {
+	return nil, ackerr.NewTerminalError(err)
+}
+// event buses may be created after the endpoint, so a missing event bus is
+// retried
+if err = rm.validateEventBusesExist(ctx, desired.ko); err != nil {
+	return nil, err
+}
@@ -0,0 +1,3 @@
+rm.setHealthStatus(ctx, ko)
+rm.keepHealthCheckRef(ctx, r.ko, ko)
+observeState(ko)
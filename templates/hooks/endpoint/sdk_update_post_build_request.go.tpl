@@ -1,2 +1,5 @@
 // we need to explicitly unset nil spec values
 unsetRemovedSpecFields(delta, desired.ko.Spec, input)
+if err = rm.setPrimaryHealthCheck(ctx, desired.ko, input.RoutingConfig); err != nil {
+	return nil, err
+}
@@ -1,7 +1,21 @@
 if err = validateEndpointSpec(delta, desired.ko.Spec); err != nil {
 	return nil, ackerr.NewTerminalError(err)
 }
+if err = rm.validateEventBusRegions(desired.ko); err != nil {
+	return nil, ackerr.NewTerminalError(err)
+}
+if delta.DifferentAt("Spec.EventBuses") {
+	if err = rm.validateEventBusesExist(ctx, desired.ko); err != nil {
+		return nil, err
+	}
+}
 
 if endpointInMutatingState(latest) {
 	return latest, requeueWaitWhileUpdating
 }
+
+if delta.DifferentAt(failoverDrillPath) {
+	if err = rm.syncFailoverDrill(ctx, desired, latest); err != nil || failoverDrillInProgress(desired.ko) || !delta.DifferentExcept(failoverDrillPath) {
+		return desired, err
+	}
+}
@@ -0,0 +1,4 @@
+keepLogDestinations(desired.ko, ko)
+if logDestinationsManaged(ko) {
+    return &resource{ko}, requeueWaitForLogDeliveries
+}
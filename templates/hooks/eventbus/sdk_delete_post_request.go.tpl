@@ -0,0 +1 @@
+invalidateReads(r)
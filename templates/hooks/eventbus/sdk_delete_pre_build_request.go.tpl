@@ -0,0 +1,3 @@
+if err = rm.deleteLogDeliveries(ctx, r.ko); err != nil {
+    return nil, err
+}
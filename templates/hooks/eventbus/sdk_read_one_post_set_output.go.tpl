@@ -1,3 +1,6 @@
 if err := rm.setResourceAdditionalFields(ctx, ko); err != nil {
     return nil, err
 }
+if err := rm.setLogConfig(ctx, r.ko, ko); err != nil {
+    return nil, err
+}
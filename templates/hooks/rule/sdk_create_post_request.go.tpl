@@ -0,0 +1,3 @@
+// tags are applied with PutRule on create
+setSyncCondition(desired, ConditionTypeAttributesSynced, err)
+setSyncCondition(desired, ConditionTypeTagsSynced, err)
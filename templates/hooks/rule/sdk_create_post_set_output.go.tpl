@@ -1,9 +1,15 @@
+setInputPreviews(ko)
+// reads cached for a rule previously created with the same ARN are stale
+invalidateReads(&resource{ko})
 if len(ko.Spec.Targets) > 0 {
-	if err = rm.syncTargets(
-	    ctx,
-		ko.Spec.Name, ko.Spec.EventBusName,
+	err = rm.syncTargets(
+		ctx,
+		ko.Namespace, ko.Status.TargetRoleARN, ko.Spec.Name, ko.Spec.EventBusName,
 		ko.Spec.Targets, nil,
-	); err != nil {
-		return nil, err
+	)
+	setSyncCondition(&resource{ko}, ConditionTypeTargetsSynced, err)
+	if err != nil {
+		return &resource{ko}, err
 	}
 }
+observeRuleSynced(&resource{ko}, true)
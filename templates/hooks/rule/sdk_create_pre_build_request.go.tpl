@@ -1,3 +1,12 @@
+defer func() {
+	rm.publishLifecycleEvent(ctx, lifecycleCreate, desired, created, err)
+}()
+if driftReportEnabled(desired) {
+    return nil, ackerr.NewTerminalError(errDriftReportCreate)
+}
 if err = validateRuleSpec(desired.ko.Spec); err != nil {
     return nil, ackerr.NewTerminalError(err)
 }
+if err = rm.checkCrossAccountTargetRoles(ctx, desired); err != nil {
+    return desired, err
+}
@@ -0,0 +1 @@
+rm.publishLifecycleEvent(ctx, lifecycleDelete, r, nil, err)
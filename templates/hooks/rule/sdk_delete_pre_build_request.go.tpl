@@ -1,7 +1,12 @@
+if driftReportEnabled(r) {
+    // the rule is left in place, like with the retain deletion policy
+    return nil, nil
+}
+defer invalidateReads(r)
 if len(r.ko.Spec.Targets) > 0 {
 	if err = rm.syncTargets(
 		ctx,
-		r.ko.Spec.Name, r.ko.Spec.EventBusName,
+		r.ko.Namespace, nil, r.ko.Spec.Name, r.ko.Spec.EventBusName,
 		nil, r.ko.Spec.Targets,
 	); err != nil {
 		return nil, err
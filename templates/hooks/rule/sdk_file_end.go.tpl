@@ -1,4 +1,5 @@
-// sdkTargetsFromResourceTargets converts the given Kubernetes resource targets to AWS service targets
+// sdkTargetsFromResourceTargets converts the given Kubernetes resource targets
+// to AWS service targets
 func sdkTargetsFromResourceTargets(
 	targets []*svcapitypes.Target,
 ) ([]*svcsdktypes.Target, error) {
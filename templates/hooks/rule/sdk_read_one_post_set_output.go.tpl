@@ -1,3 +1,13 @@
+keepEventBusName(r.ko, ko)
 if err := rm.setResourceAdditionalFields(ctx, ko); err != nil {
 	return nil, err
 }
+// the default target role of the namespace is compared with the targets
+if _, err := rm.resolveTargetRole(ctx, r, ko); err != nil {
+	return nil, err
+}
+setInputPreviews(ko)
+// drift is reported again by sdkUpdate as long as it persists
+ko.Status.Drift = nil
+ensureSyncConditions(&resource{ko})
+observeRule(ko)
@@ -0,0 +1,3 @@
+if r, err = identifiersFromARN(r); err != nil {
+    return nil, err
+}
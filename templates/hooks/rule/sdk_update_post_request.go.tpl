@@ -0,0 +1,4 @@
+setSyncCondition(desired, ConditionTypeAttributesSynced, err)
+if err == nil {
+	ensureSyncConditions(desired)
+}
@@ -1,20 +1,44 @@
+defer invalidateReads(latest)
+// the sync conditions set on desired are kept when the update fails
+defer func() {
+	if err != nil && updated == nil {
+		updated = desired
+	}
+}()
+defer func() {
+	if err == nil {
+		observeRuleSynced(updated, false)
+	}
+	rm.publishLifecycleEvent(ctx, lifecycleUpdate, desired, updated, err)
+}()
+if driftReportEnabled(desired) {
+	return reportDrift(desired, delta), nil
+}
 if err = validateRuleSpec(desired.ko.Spec); err != nil {
 		return nil, ackerr.NewTerminalError(err)
 }
+if err = rm.checkCrossAccountTargetRoles(ctx, desired); err != nil {
+	return desired, err
+}
 if delta.DifferentAt("Spec.Tags") {
-	if err = rm.syncTags(ctx, desired, latest); err != nil {
-		return nil, err
+	err = rm.syncTags(ctx, desired, latest)
+	setSyncCondition(desired, ConditionTypeTagsSynced, err)
+	if err != nil {
+		return desired, err
 	}
 }
 if delta.DifferentAt("Spec.Targets") {
-	if err = rm.syncTargets(
+	err = rm.syncTargets(
 		ctx,
-		desired.ko.Spec.Name, desired.ko.Spec.EventBusName,
+		desired.ko.Namespace, desired.ko.Status.TargetRoleARN, desired.ko.Spec.Name, desired.ko.Spec.EventBusName,
 		desired.ko.Spec.Targets, latest.ko.Spec.Targets,
-	); err != nil {
-		return nil, err
+	)
+	setSyncCondition(desired, ConditionTypeTargetsSynced, err)
+	if err != nil {
+		return desired, err
 	}
 }
 if !delta.DifferentExcept("Spec.Tags", "Spec.Targets") {
+	ensureSyncConditions(desired)
 	return desired, nil
 }
@@ -0,0 +1,120 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package integration
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	ackv1alpha1 "github.com/aws-controllers-k8s/runtime/apis/core/v1alpha1"
+	"github.com/aws/aws-sdk-go-v2/aws"
+	svcsdk "github.com/aws/aws-sdk-go-v2/service/eventbridge"
+	svcsdktypes "github.com/aws/aws-sdk-go-v2/service/eventbridge/types"
+	"gotest.tools/v3/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	svcapitypes "github.com/aws-controllers-k8s/eventbridge-controller/apis/v1alpha1"
+)
+
+const eventBusFinalizer = "finalizers.eventbridge.services.k8s.aws/EventBus"
+
+func newEventBus(name string) *svcapitypes.EventBus {
+	return &svcapitypes.EventBus{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: testNamespace,
+		},
+		Spec: svcapitypes.EventBusSpec{
+			Name: aws.String(name),
+		},
+	}
+}
+
+func TestEventBus_lifecycle(t *testing.T) {
+	requireEnvtest(t)
+	ctx := context.Background()
+	client := svcsdk.NewFromConfig(fake.Config())
+
+	bus := newEventBus("lifecycle-bus")
+	bus.Spec.Tags = []*svcapitypes.Tag{
+		{Key: aws.String("team"), Value: aws.String("a")},
+	}
+	assert.NilError(t, k8sClient.Create(ctx, bus))
+	waitSynced(t, bus, func() []*ackv1alpha1.Condition { return bus.Status.Conditions })
+
+	assert.Assert(t, controllerutil.ContainsFinalizer(bus, eventBusFinalizer))
+	assert.Equal(t,
+		string(*bus.Status.ACKResourceMetadata.ARN),
+		"arn:aws:events:us-west-2:123456789012:event-bus/lifecycle-bus",
+	)
+
+	bus.Spec.Tags = []*svcapitypes.Tag{
+		{Key: aws.String("team"), Value: aws.String("b")},
+	}
+	assert.NilError(t, k8sClient.Update(ctx, bus))
+	eventually(t, "tags to be updated", func(ctx context.Context) bool {
+		resp, err := client.ListTagsForResource(ctx, &svcsdk.ListTagsForResourceInput{
+			ResourceARN: (*string)(bus.Status.ACKResourceMetadata.ARN),
+		})
+		if err != nil {
+			return false
+		}
+		for _, tag := range resp.Tags {
+			if aws.ToString(tag.Key) == "team" {
+				return aws.ToString(tag.Value) == "b"
+			}
+		}
+		return false
+	})
+
+	deleteAndWait(t, bus)
+	_, err := client.DescribeEventBus(ctx, &svcsdk.DescribeEventBusInput{Name: aws.String("lifecycle-bus")})
+	var notFound *svcsdktypes.ResourceNotFoundException
+	assert.Assert(t, errors.As(err, &notFound))
+}
+
+func TestEventBus_adoption(t *testing.T) {
+	requireEnvtest(t)
+	ctx := context.Background()
+	client := svcsdk.NewFromConfig(fake.Config())
+
+	_, err := client.CreateEventBus(ctx, &svcsdk.CreateEventBusInput{
+		Name: aws.String("adopted-bus"),
+		Tags: []svcsdktypes.Tag{
+			{Key: aws.String("owner"), Value: aws.String("console")},
+		},
+	})
+	assert.NilError(t, err)
+
+	bus := &svcapitypes.EventBus{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "adopted-bus",
+			Namespace: testNamespace,
+			Annotations: map[string]string{
+				ackv1alpha1.AnnotationAdoptionPolicy: "adopt",
+				ackv1alpha1.AnnotationAdoptionFields: `{"name": "adopted-bus"}`,
+			},
+		},
+	}
+	assert.NilError(t, k8sClient.Create(ctx, bus))
+	waitSynced(t, bus, func() []*ackv1alpha1.Condition { return bus.Status.Conditions })
+
+	assert.Equal(t, aws.ToString(bus.Spec.Name), "adopted-bus")
+	assert.Equal(t, len(bus.Spec.Tags), 1)
+	assert.Equal(t, aws.ToString(bus.Spec.Tags[0].Value), "console")
+
+	deleteAndWait(t, bus)
+}
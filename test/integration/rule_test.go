@@ -0,0 +1,90 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+package integration
+
+import (
+	"context"
+	"testing"
+
+	ackv1alpha1 "github.com/aws-controllers-k8s/runtime/apis/core/v1alpha1"
+	"github.com/aws/aws-sdk-go-v2/aws"
+	svcsdk "github.com/aws/aws-sdk-go-v2/service/eventbridge"
+	"gotest.tools/v3/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	svcapitypes "github.com/aws-controllers-k8s/eventbridge-controller/apis/v1alpha1"
+	"github.com/aws-controllers-k8s/eventbridge-controller/pkg/resource/rule"
+)
+
+func TestRule_referenceAndTargets(t *testing.T) {
+	requireEnvtest(t)
+	ctx := context.Background()
+	client := svcsdk.NewFromConfig(fake.Config())
+
+	bus := newEventBus("rule-bus")
+	assert.NilError(t, k8sClient.Create(ctx, bus))
+	waitSynced(t, bus, func() []*ackv1alpha1.Condition { return bus.Status.Conditions })
+
+	r := &svcapitypes.Rule{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "referencing-rule",
+			Namespace: testNamespace,
+		},
+		Spec: svcapitypes.RuleSpec{
+			Name: aws.String("referencing-rule"),
+			EventBusRef: &ackv1alpha1.AWSResourceReferenceWrapper{
+				From: &ackv1alpha1.AWSResourceReference{Name: aws.String(bus.Name)},
+			},
+			EventPattern: aws.String(`{"source":["test"]}`),
+			Targets: []*svcapitypes.Target{{
+				ID:  aws.String("queue"),
+				ARN: aws.String("arn:aws:sqs:us-west-2:123456789012:queue"),
+			}},
+		},
+	}
+	assert.NilError(t, k8sClient.Create(ctx, r))
+	waitSynced(t, r, func() []*ackv1alpha1.Condition { return r.Status.Conditions })
+
+	assert.Equal(t,
+		string(*r.Status.ACKResourceMetadata.ARN),
+		"arn:aws:events:us-west-2:123456789012:rule/rule-bus/referencing-rule",
+	)
+	assert.Equal(t, conditionStatus(r.Status.Conditions, rule.ConditionTypeTargetsSynced), corev1.ConditionTrue)
+
+	r.Spec.EventPattern = aws.String(`{"source":["updated"]}`)
+	r.Spec.Targets = append(r.Spec.Targets, &svcapitypes.Target{
+		ID:  aws.String("second"),
+		ARN: aws.String("arn:aws:sqs:us-west-2:123456789012:second"),
+	})
+	assert.NilError(t, k8sClient.Update(ctx, r))
+	eventually(t, "rule to be updated", func(ctx context.Context) bool {
+		described, err := client.DescribeRule(ctx, &svcsdk.DescribeRuleInput{
+			Name:         aws.String("referencing-rule"),
+			EventBusName: aws.String("rule-bus"),
+		})
+		if err != nil || aws.ToString(described.EventPattern) != `{"source":["updated"]}` {
+			return false
+		}
+		targets, err := client.ListTargetsByRule(ctx, &svcsdk.ListTargetsByRuleInput{
+			Rule:         aws.String("referencing-rule"),
+			EventBusName: aws.String("rule-bus"),
+		})
+		return err == nil && len(targets.Targets) == 2
+	})
+
+	// the event bus can only be deleted after the rule and its targets
+	deleteAndWait(t, r)
+	deleteAndWait(t, bus)
+}
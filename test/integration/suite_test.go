@@ -0,0 +1,234 @@
+// Copyright Amazon.com Inc. or its affiliates. All Rights Reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License"). You may
+// not use this file except in compliance with the License. A copy of the
+// License is located at
+//
+//     http://aws.amazon.com/apache2.0/
+//
+// or in the "license" file accompanying this file. This file is distributed
+// on an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either
+// express or implied. See the License for the specific language governing
+// permissions and limitations under the License.
+
+// Package integration runs the service controller against a local Kubernetes
+// API server (envtest) and an in-memory fake of the EventBridge API. The tests
+// are skipped unless KUBEBUILDER_ASSETS points to the envtest binaries, see
+// the test-integration Makefile target.
+package integration
+
+import (
+	"context"
+	"fmt"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	iamapitypes "github.com/aws-controllers-k8s/iam-controller/apis/v1alpha1"
+	ackv1alpha1 "github.com/aws-controllers-k8s/runtime/apis/core/v1alpha1"
+	ackcfg "github.com/aws-controllers-k8s/runtime/pkg/config"
+	"github.com/aws-controllers-k8s/runtime/pkg/featuregate"
+	ackrt "github.com/aws-controllers-k8s/runtime/pkg/runtime"
+	acktypes "github.com/aws-controllers-k8s/runtime/pkg/types"
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	ctrlrt "sigs.k8s.io/controller-runtime"
+	ctrlrtclient "sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/envtest"
+	metricsserver "sigs.k8s.io/controller-runtime/pkg/metrics/server"
+
+	svcapitypes "github.com/aws-controllers-k8s/eventbridge-controller/apis/v1alpha1"
+	"github.com/aws-controllers-k8s/eventbridge-controller/pkg/controller"
+	"github.com/aws-controllers-k8s/eventbridge-controller/pkg/testutil"
+)
+
+const (
+	awsServiceAPIGroup = "eventbridge.services.k8s.aws"
+	awsServiceAlias    = "eventbridge"
+
+	testNamespace = "default"
+
+	// pollTimeout is the maximum time to wait for the controller to
+	// reconcile a change
+	pollTimeout  = 30 * time.Second
+	pollInterval = 250 * time.Millisecond
+)
+
+var (
+	// k8sClient talks to the envtest API server, nil if envtest is not
+	// available
+	k8sClient ctrlrtclient.Client
+	// fake is the EventBridge API the controller reconciles against
+	fake *testutil.EventBridge
+)
+
+func TestMain(m *testing.M) {
+	if os.Getenv("KUBEBUILDER_ASSETS") == "" {
+		fmt.Println("KUBEBUILDER_ASSETS not set, skipping envtest integration tests")
+		os.Exit(m.Run())
+	}
+
+	code, err := run(m)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+	os.Exit(code)
+}
+
+// run starts envtest and the service controller, runs the tests and tears
+// everything down again
+func run(m *testing.M) (int, error) {
+	testEnv := &envtest.Environment{
+		CRDDirectoryPaths:     []string{filepath.Join("..", "..", "helm", "crds")},
+		ErrorIfCRDPathMissing: true,
+	}
+	restCfg, err := testEnv.Start()
+	if err != nil {
+		return 0, fmt.Errorf("starting envtest: %w", err)
+	}
+	defer func() {
+		_ = testEnv.Stop()
+	}()
+
+	scheme := runtime.NewScheme()
+	_ = clientgoscheme.AddToScheme(scheme)
+	_ = svcapitypes.AddToScheme(scheme)
+	_ = ackv1alpha1.AddToScheme(scheme)
+	_ = iamapitypes.AddToScheme(scheme)
+
+	fake = testutil.NewEventBridge(testutil.WithTransitionReads(0))
+	server := httptest.NewServer(fake)
+	defer server.Close()
+
+	// the controller loads credentials from the default chain and the fake
+	// doesn't validate signatures
+	os.Setenv("AWS_ACCESS_KEY_ID", "AKIDTEST")
+	os.Setenv("AWS_SECRET_ACCESS_KEY", "secret")
+
+	ackCfg := ackcfg.Config{
+		AccountID:                      testutil.DefaultAccountID,
+		Partition:                      "aws",
+		Region:                         testutil.DefaultRegion,
+		EndpointURL:                    server.URL,
+		AllowUnsafeEndpointURL:         true,
+		EnableCrossNamespace:           true,
+		DeletionPolicy:                 ackv1alpha1.DeletionPolicyDelete,
+		ReconcileDefaultResyncSeconds:  36000,
+		ReconcileDefaultMaxConcurrency: 1,
+		FeatureGates:                   featuregate.GetDefaultFeatureGates(),
+	}
+
+	mgr, err := ctrlrt.NewManager(restCfg, ctrlrt.Options{
+		Scheme:                 scheme,
+		Metrics:                metricsserver.Options{BindAddress: "0"},
+		HealthProbeBindAddress: "0",
+	})
+	if err != nil {
+		return 0, fmt.Errorf("creating controller manager: %w", err)
+	}
+
+	// the resource managers are wired like in the controller binary
+	resourceManagerFactories, err := controller.Setup(mgr)
+	if err != nil {
+		return 0, fmt.Errorf("setting up resource managers: %w", err)
+	}
+
+	sc := ackrt.NewServiceController(
+		awsServiceAlias, awsServiceAPIGroup,
+		acktypes.VersionInfo{GitCommit: "test", GitVersion: "test", BuildDate: "test"},
+	).WithLogger(
+		logr.Discard(),
+	).WithResourceManagerFactories(
+		resourceManagerFactories,
+	)
+	if err := sc.BindControllerManager(mgr, ackCfg); err != nil {
+		return 0, fmt.Errorf("binding service controller: %w", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error, 1)
+	go func() {
+		done <- mgr.Start(ctx)
+	}()
+	defer func() {
+		cancel()
+		<-done
+	}()
+
+	k8sClient = mgr.GetClient()
+	return m.Run(), nil
+}
+
+// requireEnvtest skips the test if envtest is not available
+func requireEnvtest(t *testing.T) {
+	t.Helper()
+	if k8sClient == nil {
+		t.Skip("envtest not available, set KUBEBUILDER_ASSETS to run integration tests")
+	}
+}
+
+// eventually polls cond until it returns true or pollTimeout expires
+func eventually(t *testing.T, msg string, cond func(ctx context.Context) bool) {
+	t.Helper()
+	ctx, cancel := context.WithTimeout(context.Background(), pollTimeout)
+	defer cancel()
+
+	for {
+		if cond(ctx) {
+			return
+		}
+		select {
+		case <-ctx.Done():
+			t.Fatalf("timed out waiting for %s", msg)
+		case <-time.After(pollInterval):
+		}
+	}
+}
+
+// conditionStatus returns the status of the condition of the given type, or
+// an empty string if the condition is not set
+func conditionStatus(conditions []*ackv1alpha1.Condition, condType ackv1alpha1.ConditionType) corev1.ConditionStatus {
+	for _, c := range conditions {
+		if c.Type == condType {
+			return c.Status
+		}
+	}
+	return ""
+}
+
+// waitSynced waits until the object reports ACK.ResourceSynced=True.
+// conditions returns the status conditions of obj.
+func waitSynced(
+	t *testing.T,
+	obj ctrlrtclient.Object,
+	conditions func() []*ackv1alpha1.Condition,
+) {
+	t.Helper()
+	key := ctrlrtclient.ObjectKeyFromObject(obj)
+	eventually(t, fmt.Sprintf("%s to be synced", key), func(ctx context.Context) bool {
+		if err := k8sClient.Get(ctx, key, obj); err != nil {
+			return false
+		}
+		return conditionStatus(conditions(), ackv1alpha1.ConditionTypeResourceSynced) == corev1.ConditionTrue
+	})
+}
+
+// deleteAndWait deletes the object and waits until the finalizer has been
+// removed and the object is gone
+func deleteAndWait(t *testing.T, obj ctrlrtclient.Object) {
+	t.Helper()
+	ctx := context.Background()
+	if err := k8sClient.Delete(ctx, obj); err != nil {
+		t.Fatalf("deleting %s: %v", obj.GetName(), err)
+	}
+	key := ctrlrtclient.ObjectKeyFromObject(obj)
+	eventually(t, fmt.Sprintf("%s to be deleted", key), func(ctx context.Context) bool {
+		return apierrors.IsNotFound(k8sClient.Get(ctx, key, obj))
+	})
+}